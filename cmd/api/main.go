@@ -1,19 +1,26 @@
 package main
 
 import (
+	"context"
 	"log"
+	"time"
 
 	"yego/internal/adapters/datasources"
+	"yego/internal/adapters/jobs"
 	"yego/internal/adapters/web"
 	paymentHandler "yego/internal/adapters/web/handlers/payment"
 	websocketHandler "yego/internal/adapters/web/handlers/websocket"
 	"yego/internal/adapters/web/integrations"
 	"yego/internal/adapters/web/middlewares"
+	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
 	"yego/internal/platform/config"
 	"yego/internal/platform/database"
+	"yego/internal/services/mpwebhook"
 	s3service "yego/internal/services/s3"
+	"yego/internal/services/tracking"
 	"yego/internal/usecases"
+	orderUsecase "yego/internal/usecases/order"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,18 +29,159 @@ func main() {
 	cfg := config.GetInstance()
 	log.Printf("Starting Order Tracking API on port %s", cfg.ServerPort)
 
+	if err := domain.SetBusinessLocation(cfg.BusinessTimezone); err != nil {
+		log.Fatalf("Invalid BUSINESS_TIMEZONE: %v", err)
+	}
+
 	db := database.GetInstance()
 
 	if err := database.RunMigrations(); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
+	var webhookVerifier *mpwebhook.Verifier
+	if cfg.MPWebhookSecret != "" {
+		tolerance, err := time.ParseDuration(cfg.MPWebhookTolerance)
+		if err != nil {
+			log.Fatalf("Invalid MP_WEBHOOK_TOLERANCE: %v", err)
+		}
+		webhookVerifier = mpwebhook.NewVerifier(cfg.MPWebhookSecret, tolerance)
+	} else if cfg.GinMode == gin.ReleaseMode {
+		log.Fatalf("MP_WEBHOOK_SECRET is required in release mode")
+	} else {
+		log.Printf("Warning: MP_WEBHOOK_SECRET is not set, MercadoPago webhook signatures are not verified")
+	}
+
 	ds := datasources.CreateDatasources(db)
-	integrations := integrations.CreateIntegration(cfg)
+	integrations := integrations.CreateIntegration(cfg, webhookVerifier)
 	contextFactory := appcontext.NewFactory(ds, integrations, cfg)
 
 	s3Client := s3service.NewClient(cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKeyID, cfg.S3SecretAccessKey)
-	useCases := usecases.CreateUsecases(contextFactory, s3Client)
+
+	trackingLinkTTL, err := time.ParseDuration(cfg.TrackingLinkTTL)
+	if err != nil {
+		log.Fatalf("Invalid TRACKING_LINK_TTL: %v", err)
+	}
+	trackingSecret := cfg.TrackingTokenSecret
+	if trackingSecret == "" {
+		trackingSecret = cfg.JWTSecret
+	}
+	if trackingSecret == "" {
+		log.Printf("Warning: TRACKING_TOKEN_SECRET is not set, tracking links will stop working on restart")
+	}
+	trackingSigner := tracking.NewSigner(trackingSecret, trackingLinkTTL)
+
+	useCases := usecases.CreateUsecases(contextFactory, s3Client, trackingSigner)
+
+	subscriptionInterval, err := time.ParseDuration(cfg.SubscriptionSchedulerInterval)
+	if err != nil {
+		log.Fatalf("Invalid SUBSCRIPTION_SCHEDULER_INTERVAL: %v", err)
+	}
+	expiryInterval, err := time.ParseDuration(cfg.OrderExpiryInterval)
+	if err != nil {
+		log.Fatalf("Invalid ORDER_EXPIRY_INTERVAL: %v", err)
+	}
+	unpaidOrderMaxAge, err := time.ParseDuration(cfg.UnpaidOrderMaxAge)
+	if err != nil {
+		log.Fatalf("Invalid UNPAID_ORDER_MAX_AGE: %v", err)
+	}
+	webhookProcessorInterval, err := time.ParseDuration(cfg.WebhookProcessorInterval)
+	if err != nil {
+		log.Fatalf("Invalid WEBHOOK_PROCESSOR_INTERVAL: %v", err)
+	}
+	reconciliationInterval, err := time.ParseDuration(cfg.PaymentReconciliationInterval)
+	if err != nil {
+		log.Fatalf("Invalid PAYMENT_RECONCILIATION_INTERVAL: %v", err)
+	}
+	reconciliationLookback, err := time.ParseDuration(cfg.PaymentReconciliationLookback)
+	if err != nil {
+		log.Fatalf("Invalid PAYMENT_RECONCILIATION_LOOKBACK: %v", err)
+	}
+	idempotencyCleanupInterval, err := time.ParseDuration(cfg.IdempotencyKeyCleanupInterval)
+	if err != nil {
+		log.Fatalf("Invalid IDEMPOTENCY_KEY_CLEANUP_INTERVAL: %v", err)
+	}
+
+	jobs.Start(context.Background(), jobs.Exclusive(db, jobs.Job{
+		Name:     "run-due-subscriptions",
+		Interval: subscriptionInterval,
+		Run: func(ctx context.Context) error {
+			output, appErr := useCases.Order.RunDueSubscriptionsUsecase.Execute(ctx, time.Now())
+			if appErr != nil {
+				return appErr
+			}
+			if output.Processed > 0 {
+				log.Printf("Subscriptions run: %d processed, %d created, %d charged, %d failed",
+					output.Processed, output.Created, output.Charged, output.Failed)
+			}
+			return nil
+		},
+	}), jobs.Exclusive(db, jobs.Job{
+		Name:     "expire-unpaid-orders",
+		Interval: expiryInterval,
+		Run: func(ctx context.Context) error {
+			output, appErr := useCases.Order.ExpireUnpaidOrdersUsecase.Execute(ctx, orderUsecase.ExpireUnpaidOrdersInput{
+				Now:    time.Now(),
+				MaxAge: unpaidOrderMaxAge,
+			})
+			if appErr != nil {
+				return appErr
+			}
+			if output.Cancelled > 0 || output.TokensExpired > 0 {
+				log.Printf("Expiry run: %d orders cancelled, %d skipped, %d tokens expired",
+					output.Cancelled, output.Skipped, output.TokensExpired)
+			}
+			return nil
+		},
+	}), jobs.Exclusive(db, jobs.Job{
+		Name:     "process-webhook-events",
+		Interval: webhookProcessorInterval,
+		Run: func(ctx context.Context) error {
+			output, appErr := useCases.Webhook.ProcessDueUsecase.Execute(ctx, time.Now())
+			if appErr != nil {
+				return appErr
+			}
+			if output.Processed > 0 || output.Retrying > 0 || output.Failed > 0 {
+				log.Printf("Webhook events run: %d processed, %d retrying, %d failed",
+					output.Processed, output.Retrying, output.Failed)
+			}
+			return nil
+		},
+	}), jobs.Exclusive(db, jobs.Job{
+		Name:     "reconcile-payments",
+		Interval: reconciliationInterval,
+		Run: func(ctx context.Context) error {
+			output, appErr := useCases.Order.ReconcilePaymentsUsecase.Execute(ctx, orderUsecase.ReconcilePaymentsInput{
+				Now:      time.Now(),
+				Lookback: reconciliationLookback,
+				AutoFix:  cfg.PaymentReconciliationAutoFix == "true",
+			})
+			if appErr != nil {
+				return appErr
+			}
+			for _, m := range output.Mismatches {
+				log.Printf("Reconciliation: %s on order %s (fixed=%t): %s", m.Kind, m.OrderID, m.Fixed, m.Detail)
+			}
+			if len(output.Mismatches) > 0 || len(output.Errors) > 0 {
+				log.Printf("Reconciliation run: %d orders checked, %d mismatches, %d fixed, %d errors",
+					output.OrdersChecked, len(output.Mismatches), output.Fixed, len(output.Errors))
+			}
+			return nil
+		},
+	}), jobs.Exclusive(db, jobs.Job{
+		Name:     "delete-expired-idempotency-keys",
+		Interval: idempotencyCleanupInterval,
+		Run: func(ctx context.Context) error {
+			deleted, appErr := contextFactory().Repositories.IdempotencyKey.DeleteExpired(ctx, time.Now().Add(-domain.IdempotencyKeyTTL))
+			if appErr != nil {
+				return appErr
+			}
+			if deleted > 0 {
+				log.Printf("Idempotency keys run: %d expired keys deleted", deleted)
+			}
+			return nil
+		},
+	}))
 
 	gin.SetMode(cfg.GinMode)
 	app := gin.Default()
@@ -43,7 +191,9 @@ func main() {
 	hub := integrations.WebSocket.GetHub()
 	wsHandler := websocketHandler.NewHandler(hub)
 	paymentCheckHandler := paymentHandler.NewHandler(contextFactory)
-	web.RegisterRoutes(app, useCases, wsHandler, paymentCheckHandler, cfg)
+	idempotency := middlewares.IdempotencyMiddleware(contextFactory)
+
+	web.RegisterRoutes(app, useCases, wsHandler, paymentCheckHandler, idempotency, integrations.PaymentProviders, cfg)
 
 	app.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
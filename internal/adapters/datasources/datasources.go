@@ -1,6 +1,17 @@
 package datasources
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+)
+
+// DBTX is implemented by both *sql.DB and *sql.Tx, so a repository can run its queries
+// inside a database transaction
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
 
 type Datasources struct {
 	DB *sql.DB
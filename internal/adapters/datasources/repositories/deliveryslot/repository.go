@@ -0,0 +1,225 @@
+package deliveryslot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// Repository defines the interface for delivery slot operations
+type Repository interface {
+	Create(ctx context.Context, slot *domain.DeliverySlot) (*domain.DeliverySlot, apperrors.ApplicationError)
+	GetByID(ctx context.Context, id string) (*domain.DeliverySlot, apperrors.ApplicationError)
+	List(ctx context.Context, activeOnly bool) ([]*domain.DeliverySlot, apperrors.ApplicationError)
+	Update(ctx context.Context, slot *domain.DeliverySlot) (*domain.DeliverySlot, apperrors.ApplicationError)
+	Delete(ctx context.Context, id string) apperrors.ApplicationError
+	// Reserve takes one place in the slot for the given day, failing with
+	// DeliverySlotFullError when the slot is already at capacity
+	Reserve(ctx context.Context, slotID string, date time.Time) apperrors.ApplicationError
+	// Release gives back a place taken with Reserve
+	Release(ctx context.Context, slotID string, date time.Time) apperrors.ApplicationError
+	// CountBookings returns how many places are taken per slot on each day in [from, to], keyed by slot ID and date
+	CountBookings(ctx context.Context, from, to time.Time) (map[string]map[string]int, apperrors.ApplicationError)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new delivery slot repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+const slotColumns = `id, weekday, to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI'),
+		capacity, active, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSlot(scanner rowScanner) (*domain.DeliverySlot, error) {
+	var s domain.DeliverySlot
+	if err := scanner.Scan(
+		&s.ID, &s.Weekday, &s.StartTime, &s.EndTime,
+		&s.Capacity, &s.Active, &s.CreatedAt, &s.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Create stores a new delivery slot
+func (r *repository) Create(ctx context.Context, slot *domain.DeliverySlot) (*domain.DeliverySlot, apperrors.ApplicationError) {
+	if slot.ID == "" {
+		slot.ID = uuid.New().String()
+	}
+	now := time.Now()
+	slot.CreatedAt = now
+	slot.UpdatedAt = now
+
+	query := `
+		INSERT INTO delivery_slots (id, weekday, start_time, end_time, capacity, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		slot.ID, int(slot.Weekday), slot.StartTime, slot.EndTime,
+		slot.Capacity, slot.Active, slot.CreatedAt, slot.UpdatedAt,
+	)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotCreateError, err)
+	}
+	return slot, nil
+}
+
+// GetByID retrieves a delivery slot by its ID
+func (r *repository) GetByID(ctx context.Context, id string) (*domain.DeliverySlot, apperrors.ApplicationError) {
+	query := `SELECT ` + slotColumns + ` FROM delivery_slots WHERE id = $1`
+
+	slot, err := scanSlot(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewApplicationError(mappings.DeliverySlotNotFoundError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotListError, err)
+	}
+	return slot, nil
+}
+
+// List retrieves the delivery slots ordered by weekday and start time
+func (r *repository) List(ctx context.Context, activeOnly bool) ([]*domain.DeliverySlot, apperrors.ApplicationError) {
+	query := `
+		SELECT ` + slotColumns + `
+		FROM delivery_slots
+		WHERE active OR NOT $1
+		ORDER BY weekday, start_time
+	`
+	rows, err := r.db.QueryContext(ctx, query, activeOnly)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotListError, err)
+	}
+	defer rows.Close()
+
+	var slots []*domain.DeliverySlot
+	for rows.Next() {
+		slot, err := scanSlot(rows)
+		if err != nil {
+			return nil, apperrors.NewApplicationError(mappings.DeliverySlotListError, err)
+		}
+		slots = append(slots, slot)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotListError, err)
+	}
+	return slots, nil
+}
+
+// Update saves the weekday, times, capacity and active flag of a slot.
+// Lowering the capacity does not cancel bookings already made.
+func (r *repository) Update(ctx context.Context, slot *domain.DeliverySlot) (*domain.DeliverySlot, apperrors.ApplicationError) {
+	slot.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE delivery_slots
+		SET weekday = $1, start_time = $2, end_time = $3, capacity = $4, active = $5, updated_at = $6
+		WHERE id = $7
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		int(slot.Weekday), slot.StartTime, slot.EndTime, slot.Capacity, slot.Active, slot.UpdatedAt, slot.ID,
+	)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotUpdateError, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotNotFoundError, nil)
+	}
+	return r.GetByID(ctx, slot.ID)
+}
+
+// Delete removes a delivery slot; orders booked into it keep their delivery day but lose the slot
+func (r *repository) Delete(ctx context.Context, id string) apperrors.ApplicationError {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM delivery_slots WHERE id = $1`, id)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.DeliverySlotDeleteError, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return apperrors.NewApplicationError(mappings.DeliverySlotNotFoundError, nil)
+	}
+	return nil
+}
+
+// Reserve increments the booking counter of the slot for the day in a single statement.
+// The conflicting row is locked by the upsert, so concurrent bookings are serialised
+// and the capacity check is re-evaluated against the latest count.
+func (r *repository) Reserve(ctx context.Context, slotID string, date time.Time) apperrors.ApplicationError {
+	query := `
+		INSERT INTO delivery_slot_reservations (slot_id, delivery_date, booked)
+		SELECT id, $2::date, 1 FROM delivery_slots WHERE id = $1 AND capacity > 0
+		ON CONFLICT (slot_id, delivery_date) DO UPDATE
+		SET booked = delivery_slot_reservations.booked + 1
+		WHERE delivery_slot_reservations.booked < (
+			SELECT capacity FROM delivery_slots WHERE id = $1
+		)
+		RETURNING booked
+	`
+
+	var booked int
+	err := r.db.QueryRowContext(ctx, query, slotID, date.Format(domain.DeliveryDateLayout)).Scan(&booked)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.NewApplicationError(mappings.DeliverySlotFullError, nil)
+		}
+		return apperrors.NewApplicationError(mappings.DeliverySlotReservationError, err)
+	}
+	return nil
+}
+
+// Release decrements the booking counter of the slot for the day
+func (r *repository) Release(ctx context.Context, slotID string, date time.Time) apperrors.ApplicationError {
+	query := `
+		UPDATE delivery_slot_reservations
+		SET booked = GREATEST(booked - 1, 0)
+		WHERE slot_id = $1 AND delivery_date = $2
+	`
+	if _, err := r.db.ExecContext(ctx, query, slotID, date.Format(domain.DeliveryDateLayout)); err != nil {
+		return apperrors.NewApplicationError(mappings.DeliverySlotReservationError, err)
+	}
+	return nil
+}
+
+// CountBookings reads the booking counters for every slot between two days, inclusive
+func (r *repository) CountBookings(ctx context.Context, from, to time.Time) (map[string]map[string]int, apperrors.ApplicationError) {
+	query := `
+		SELECT slot_id, to_char(delivery_date, 'YYYY-MM-DD'), booked
+		FROM delivery_slot_reservations
+		WHERE delivery_date BETWEEN $1 AND $2
+	`
+	rows, err := r.db.QueryContext(ctx, query, from.Format(domain.DeliveryDateLayout), to.Format(domain.DeliveryDateLayout))
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotListError, err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]map[string]int)
+	for rows.Next() {
+		var slotID, date string
+		var booked int
+		if err := rows.Scan(&slotID, &date, &booked); err != nil {
+			return nil, apperrors.NewApplicationError(mappings.DeliverySlotListError, err)
+		}
+		if counts[slotID] == nil {
+			counts[slotID] = make(map[string]int)
+		}
+		counts[slotID][date] = booked
+	}
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotListError, err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,118 @@
+package idempotencykey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// Repository defines the interface for idempotency key operations
+type Repository interface {
+	// Reserve stores a new in-progress key. When the key is already taken it returns
+	// the stored record instead; keys older than domain.IdempotencyKeyTTL are taken over.
+	Reserve(ctx context.Context, key *domain.IdempotencyKey) (existing *domain.IdempotencyKey, appErr apperrors.ApplicationError)
+	// Complete stores the response of a reserved key
+	Complete(ctx context.Context, userID, key string, statusCode int, contentType string, body []byte) apperrors.ApplicationError
+	// Delete releases a key so the request can be retried, e.g. after a server error
+	Delete(ctx context.Context, userID, key string) apperrors.ApplicationError
+	// DeleteExpired removes the keys created before the given time and returns how many
+	DeleteExpired(ctx context.Context, before time.Time) (int64, apperrors.ApplicationError)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new idempotency key repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+// Reserve inserts the key, or takes over an expired one, in a single statement so
+// that of two concurrent requests with the same key only one gets to run
+func (r *repository) Reserve(ctx context.Context, key *domain.IdempotencyKey) (*domain.IdempotencyKey, apperrors.ApplicationError) {
+	query := fmt.Sprintf(`
+		INSERT INTO idempotency_keys (user_id, key, request_hash, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (user_id, key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash, status_code = NULL, content_type = NULL,
+			response_body = NULL, created_at = NOW(), completed_at = NULL
+		WHERE idempotency_keys.created_at < NOW() - INTERVAL '%d seconds'
+		RETURNING created_at
+	`, int(domain.IdempotencyKeyTTL.Seconds()))
+
+	err := r.db.QueryRowContext(ctx, query, key.UserID, key.Key, key.RequestHash).Scan(&key.CreatedAt)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+
+	existing := &domain.IdempotencyKey{UserID: key.UserID, Key: key.Key}
+	var statusCode sql.NullInt64
+	var contentType sql.NullString
+	var completedAt sql.NullTime
+	err = r.db.QueryRowContext(ctx, `
+		SELECT request_hash, status_code, content_type, response_body, created_at, completed_at
+		FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2
+	`, key.UserID, key.Key).Scan(&existing.RequestHash, &statusCode, &contentType, &existing.ResponseBody, &existing.CreatedAt, &completedAt)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+
+	if statusCode.Valid {
+		code := int(statusCode.Int64)
+		existing.StatusCode = &code
+	}
+	if contentType.Valid {
+		existing.ContentType = &contentType.String
+	}
+	if completedAt.Valid {
+		existing.CompletedAt = &completedAt.Time
+	}
+
+	return existing, nil
+}
+
+// Complete stores the response of a reserved key
+func (r *repository) Complete(ctx context.Context, userID, key string, statusCode int, contentType string, body []byte) apperrors.ApplicationError {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $1, content_type = $2, response_body = $3, completed_at = NOW()
+		WHERE user_id = $4 AND key = $5
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, statusCode, contentType, body, userID, key); err != nil {
+		return apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+	return nil
+}
+
+// Delete releases a key so the request can be retried
+func (r *repository) Delete(ctx context.Context, userID, key string) apperrors.ApplicationError {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE user_id = $1 AND key = $2`, userID, key); err != nil {
+		return apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+	return nil
+}
+
+// DeleteExpired removes the keys created before the given time and returns how many
+func (r *repository) DeleteExpired(ctx context.Context, before time.Time) (int64, apperrors.ApplicationError) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"log"
+
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// LockOrder serialises the changes to an order that move money, such as cancelling and
+// refunding it, across every API replica. It waits for a Postgres session advisory lock
+// keyed by the order ID and holds it until the returned release is called.
+func (r *Repositories) LockOrder(ctx context.Context, orderID string) (func(), apperrors.ApplicationError) {
+	// Session locks belong to a connection, so lock and unlock on the same one
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock(hashtext('order:' || $1))`, orderID); err != nil {
+		conn.Close()
+		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+
+	release := func() {
+		// Unlock even if ctx was cancelled while the lock was held
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock(hashtext('order:' || $1))`, orderID); err != nil {
+			log.Printf("Warning: failed to release lock of order %s: %v", orderID, err)
+		}
+		conn.Close()
+	}
+	return release, nil
+}
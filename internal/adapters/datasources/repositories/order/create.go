@@ -14,6 +14,7 @@ import (
 func (r *repository) Create(ctx context.Context, order *domain.Order) (*domain.Order, apperrors.ApplicationError) {
 	order.ID = uuid.New().String()
 	order.Status = domain.StatusCreated
+	order.Version = 1
 	order.CreatedAt = time.Now()
 	order.UpdatedAt = time.Now()
 
@@ -22,9 +23,17 @@ func (r *repository) Create(ctx context.Context, order *domain.Order) (*domain.O
 		return nil, apperrors.NewApplicationError(mappings.OrderCreateError, err)
 	}
 
+	priceBreakdownJSON, err := order.PriceBreakdownJSON()
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderCreateError, err)
+	}
+
 	query := `
-		INSERT INTO orders (id, profile_id, user_id, status, eta, data, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO orders (
+			id, profile_id, user_id, status, eta, estimated_delivery_at, estimated_delivery_window_end,
+			data, version, delivery_slot_id, delivery_date, price_breakdown, payment_provider, payment_method,
+			created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 	`
 
 	_, err = r.db.ExecContext(ctx, query,
@@ -33,7 +42,15 @@ func (r *repository) Create(ctx context.Context, order *domain.Order) (*domain.O
 		order.UserID,
 		order.Status,
 		order.ETA,
+		order.EstimatedDeliveryAt,
+		order.EstimatedDeliveryWindowEnd,
 		dataJSON,
+		order.Version,
+		order.DeliverySlotID,
+		deliveryDateParam(order.DeliveryDate),
+		priceBreakdownJSON,
+		order.PaymentProvider,
+		order.PaymentMethod,
 		order.CreatedAt,
 		order.UpdatedAt,
 	)
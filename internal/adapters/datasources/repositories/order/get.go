@@ -4,114 +4,232 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
+	"github.com/lib/pq"
 	"yego/internal/domain"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
 )
 
-// GetByID retrieves an order by its ID
-func (r *repository) GetByID(ctx context.Context, id string) (*domain.Order, apperrors.ApplicationError) {
-	query := `
-		SELECT id, profile_id, user_id, status, status_message, eta, data, created_at, updated_at
-		FROM orders
-		WHERE id = $1
-	`
+// orderColumns lists the columns read by every order query, in scanOrder order
+const orderColumns = `id, profile_id, user_id, status, status_message, eta,
+		estimated_delivery_at, estimated_delivery_window_end, data, version,
+		cancellation_reason, delivery_slot_id, delivery_date, price_breakdown, payment_flag, payment_flagged_at,
+		payment_provider, payment_method, payment_received_at, created_at, updated_at`
 
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanOrder scans a single order row selected with orderColumns
+func scanOrder(scanner rowScanner) (*domain.Order, error) {
 	var order domain.Order
 	var dataJSON []byte
 	var statusMessage sql.NullString
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	var cancellationReason sql.NullString
+	var estimatedDeliveryAt, estimatedDeliveryWindowEnd sql.NullTime
+	var deliverySlotID sql.NullString
+	var deliveryDate sql.NullTime
+	var priceBreakdownJSON []byte
+	var paymentFlag sql.NullString
+	var paymentFlaggedAt sql.NullTime
+	var paymentProvider sql.NullString
+	var paymentMethod sql.NullString
+	var paymentReceivedAt sql.NullTime
+
+	err := scanner.Scan(
 		&order.ID,
 		&order.ProfileID,
 		&order.UserID,
 		&order.Status,
 		&statusMessage,
 		&order.ETA,
+		&estimatedDeliveryAt,
+		&estimatedDeliveryWindowEnd,
 		&dataJSON,
+		&order.Version,
+		&cancellationReason,
+		&deliverySlotID,
+		&deliveryDate,
+		&priceBreakdownJSON,
+		&paymentFlag,
+		&paymentFlaggedAt,
+		&paymentProvider,
+		&paymentMethod,
+		&paymentReceivedAt,
 		&order.CreatedAt,
 		&order.UpdatedAt,
 	)
-
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, apperrors.NewApplicationError(mappings.OrderNotFoundError, err)
-		}
-		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+		return nil, err
 	}
 
 	if dataJSON != nil {
 		if err := order.SetDataFromJSON(dataJSON); err != nil {
-			return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+			return nil, err
+		}
+	}
+	if priceBreakdownJSON != nil {
+		if err := order.SetPriceBreakdownFromJSON(priceBreakdownJSON); err != nil {
+			return nil, err
 		}
 	}
-
 	if statusMessage.Valid {
 		order.StatusMessage = &statusMessage.String
 	}
+	if estimatedDeliveryAt.Valid {
+		order.EstimatedDeliveryAt = &estimatedDeliveryAt.Time
+	}
+	if estimatedDeliveryWindowEnd.Valid {
+		order.EstimatedDeliveryWindowEnd = &estimatedDeliveryWindowEnd.Time
+	}
+	if cancellationReason.Valid {
+		reason := domain.CancellationReason(cancellationReason.String)
+		order.CancellationReason = &reason
+	}
+	if deliverySlotID.Valid {
+		order.DeliverySlotID = &deliverySlotID.String
+	}
+	if deliveryDate.Valid {
+		order.DeliveryDate = &deliveryDate.Time
+	}
+	if paymentFlag.Valid {
+		flag := domain.PaymentFlag(paymentFlag.String)
+		order.PaymentFlag = &flag
+	}
+	if paymentFlaggedAt.Valid {
+		order.PaymentFlaggedAt = &paymentFlaggedAt.Time
+	}
+	if paymentProvider.Valid {
+		order.PaymentProvider = &paymentProvider.String
+	}
+	if paymentMethod.Valid {
+		method := domain.PaymentMethod(paymentMethod.String)
+		order.PaymentMethod = &method
+	}
+	if paymentReceivedAt.Valid {
+		order.PaymentReceivedAt = &paymentReceivedAt.Time
+	}
 
 	return &order, nil
 }
 
-// GetAll retrieves all orders
-func (r *repository) GetAll(ctx context.Context) ([]*domain.Order, apperrors.ApplicationError) {
+// GetByID retrieves an order by its ID
+func (r *repository) GetByID(ctx context.Context, id string) (*domain.Order, apperrors.ApplicationError) {
 	query := `
-		SELECT id, profile_id, user_id, status, status_message, eta, data, created_at, updated_at
+		SELECT ` + orderColumns + `
 		FROM orders
-		ORDER BY created_at DESC
+		WHERE id = $1
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	order, err := scanOrder(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewApplicationError(mappings.OrderNotFoundError, err)
+		}
 		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
 	}
-	defer rows.Close()
 
-	var orders []*domain.Order
-	for rows.Next() {
-		var order domain.Order
-		var dataJSON []byte
-		var statusMessage sql.NullString
-		err := rows.Scan(
-			&order.ID,
-			&order.ProfileID,
-			&order.UserID,
-			&order.Status,
-			&statusMessage,
-			&order.ETA,
-			&dataJSON,
-			&order.CreatedAt,
-			&order.UpdatedAt,
-		)
-		if err != nil {
-			return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
-		}
-		if dataJSON != nil {
-			_ = order.SetDataFromJSON(dataJSON)
-		}
-		if statusMessage.Valid {
-			order.StatusMessage = &statusMessage.String
-		}
-		orders = append(orders, &order)
-	}
+	return order, nil
+}
 
-	if err = rows.Err(); err != nil {
-		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
-	}
+// GetAll retrieves all orders
+func (r *repository) GetAll(ctx context.Context) ([]*domain.Order, apperrors.ApplicationError) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		ORDER BY created_at DESC
+	`
 
-	return orders, nil
+	return r.list(ctx, query)
 }
 
 // GetByUserID retrieves all orders for a specific user
 func (r *repository) GetByUserID(ctx context.Context, userID string) ([]*domain.Order, apperrors.ApplicationError) {
 	query := `
-		SELECT id, profile_id, user_id, status, status_message, eta, data, created_at, updated_at
+		SELECT ` + orderColumns + `
 		FROM orders
 		WHERE user_id = $1
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	return r.list(ctx, query, userID)
+}
+
+// ListByDeliverySlot retrieves the non-cancelled orders booked into a delivery slot on a given day
+func (r *repository) ListByDeliverySlot(ctx context.Context, slotID string, date time.Time) ([]*domain.Order, apperrors.ApplicationError) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE delivery_slot_id = $1 AND delivery_date = $2 AND status <> $3
+		ORDER BY created_at ASC
+	`
+
+	return r.list(ctx, query, slotID, date.Format("2006-01-02"), domain.StatusCancelled)
+}
+
+// ListCreatedBefore retrieves the oldest orders still in CREATED that were created before the given time.
+// Cash orders are left out: they are paid on delivery, not before. So are orders with an
+// approved payment, which the payment webhook moves on; otherwise they would fill every page.
+func (r *repository) ListCreatedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Order, apperrors.ApplicationError) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE status = $1 AND created_at < $2 AND payment_method IS DISTINCT FROM $3
+			AND NOT EXISTS (
+				SELECT 1 FROM transactions t
+				WHERE t.order_id = orders.id AND t.type = $4 AND t.status = $5
+			)
+		ORDER BY created_at ASC
+		LIMIT $6
+	`
+
+	return r.list(ctx, query, domain.StatusCreated, before, domain.PaymentMethodCash, domain.TransactionTypePayment, domain.TransactionStatusApproved, limit)
+}
+
+// ListActiveSince retrieves the orders updated, or with a transaction recorded, since the given time, most recent first
+func (r *repository) ListActiveSince(ctx context.Context, since time.Time, limit int) ([]*domain.Order, apperrors.ApplicationError) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE updated_at >= $1
+			OR id IN (SELECT order_id FROM transactions WHERE created_at >= $1)
+		ORDER BY updated_at DESC
+		LIMIT $2
+	`
+
+	return r.list(ctx, query, since, limit)
+}
+
+// deliveryDateParam formats the delivery day as a DATE literal so the session time zone cannot shift it
+func deliveryDateParam(date *time.Time) any {
+	if date == nil {
+		return nil
+	}
+	return date.Format("2006-01-02")
+}
+
+// CountByStatuses returns how many orders are currently in any of the given statuses
+func (r *repository) CountByStatuses(ctx context.Context, statuses []domain.OrderStatus) (int, apperrors.ApplicationError) {
+	values := make([]string, len(statuses))
+	for i, s := range statuses {
+		values[i] = string(s)
+	}
+
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM orders WHERE status = ANY($1)`, pq.Array(values)).Scan(&count)
+	if err != nil {
+		return 0, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+
+	return count, nil
+}
+
+// list runs a query selecting orderColumns and scans every row
+func (r *repository) list(ctx context.Context, query string, args ...any) ([]*domain.Order, apperrors.ApplicationError) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
 	}
@@ -119,30 +237,11 @@ func (r *repository) GetByUserID(ctx context.Context, userID string) ([]*domain.
 
 	var orders []*domain.Order
 	for rows.Next() {
-		var order domain.Order
-		var dataJSON []byte
-		var statusMessage sql.NullString
-		err := rows.Scan(
-			&order.ID,
-			&order.ProfileID,
-			&order.UserID,
-			&order.Status,
-			&statusMessage,
-			&order.ETA,
-			&dataJSON,
-			&order.CreatedAt,
-			&order.UpdatedAt,
-		)
+		order, err := scanOrder(rows)
 		if err != nil {
 			return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
 		}
-		if dataJSON != nil {
-			_ = order.SetDataFromJSON(dataJSON)
-		}
-		if statusMessage.Valid {
-			order.StatusMessage = &statusMessage.String
-		}
-		orders = append(orders, &order)
+		orders = append(orders, order)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -156,7 +255,7 @@ func (r *repository) GetByUserID(ctx context.Context, userID string) ([]*domain.
 func (r *repository) AssignUser(ctx context.Context, orderID string, userID string) apperrors.ApplicationError {
 	query := `
 		UPDATE orders
-		SET user_id = $1, updated_at = NOW()
+		SET user_id = $1, version = version + 1, updated_at = NOW()
 		WHERE id = $2
 	`
 
@@ -181,7 +280,7 @@ func (r *repository) AssignUser(ctx context.Context, orderID string, userID stri
 func (r *repository) AssignProfile(ctx context.Context, orderID string, profileID string) apperrors.ApplicationError {
 	query := `
 		UPDATE orders
-		SET profile_id = $1, updated_at = NOW()
+		SET profile_id = $1, version = version + 1, updated_at = NOW()
 		WHERE id = $2
 	`
 
@@ -0,0 +1,230 @@
+package order
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+// orderAmountExpr is the billed items subtotal of an order: the stored price snapshot when
+// the order was priced, otherwise computed from its data JSONB with substituted items
+// counted at their substitute's price and unavailable items not at all
+const orderAmountExpr = `COALESCE((price_breakdown->>'subtotal')::numeric, (SELECT COALESCE(SUM(CASE i->>'fulfilment'
+			WHEN 'unavailable' THEN 0
+			WHEN 'substituted' THEN COALESCE((i->'substitute'->>'price')::numeric * (i->'substitute'->>'quantity')::numeric, 0)
+			ELSE (i->>'price')::numeric * (i->>'quantity')::numeric
+		END), 0)
+		FROM jsonb_array_elements(COALESCE(data->'items', '[]'::jsonb)) i))`
+
+// sortSpec maps a sort option to its SQL key expression, the type used to
+// read the key back from a cursor, and the direction
+type sortSpec struct {
+	expr     string
+	castType string
+	desc     bool
+}
+
+var sortSpecs = map[domain.OrderSort]sortSpec{
+	domain.OrderSortCreatedAtDesc: {expr: "created_at", castType: "timestamptz", desc: true},
+	domain.OrderSortCreatedAtAsc:  {expr: "created_at", castType: "timestamptz"},
+	domain.OrderSortUpdatedAtDesc: {expr: "updated_at", castType: "timestamptz", desc: true},
+	domain.OrderSortUpdatedAtAsc:  {expr: "updated_at", castType: "timestamptz"},
+	domain.OrderSortAmountDesc:    {expr: orderAmountExpr, castType: "numeric", desc: true},
+	domain.OrderSortAmountAsc:     {expr: orderAmountExpr, castType: "numeric"},
+}
+
+// listCursor is the position after the last row of a page: its sort key and ID
+type listCursor struct {
+	Key string `json:"k"`
+	ID  string `json:"id"`
+}
+
+func encodeCursor(c listCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (*listCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c listCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	if c.ID == "" {
+		return nil, errors.New("cursor has no id")
+	}
+	return &c, nil
+}
+
+// keyScanner reads the order columns followed by the sort key of the row
+type keyScanner struct {
+	rowScanner
+	key *string
+}
+
+func (s keyScanner) Scan(dest ...any) error {
+	return s.rowScanner.Scan(append(dest, s.key)...)
+}
+
+// List returns a filtered, sorted page of orders using keyset pagination,
+// along with the total number of orders matching the filter
+func (r *repository) List(ctx context.Context, filter domain.OrderFilter) (*domain.OrderPage, apperrors.ApplicationError) {
+	sort := filter.Sort
+	if sort == "" {
+		sort = domain.OrderSortCreatedAtDesc
+	}
+	spec, ok := sortSpecs[sort]
+	if !ok {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidFilterError, fmt.Errorf("unknown sort %q", sort))
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	where, args := buildOrderFilter(filter)
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM orders` + where
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+
+	comparison, direction := ">", "ASC"
+	if spec.desc {
+		comparison, direction = "<", "DESC"
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := decodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, apperrors.NewApplicationError(mappings.OrderInvalidFilterError, fmt.Errorf("invalid cursor: %w", err))
+		}
+		args = append(args, cursor.Key, cursor.ID)
+		keyset := fmt.Sprintf("(%s, id) %s ($%d::%s, $%d::uuid)", spec.expr, comparison, len(args)-1, spec.castType, len(args))
+		if where == "" {
+			where = " WHERE " + keyset
+		} else {
+			where += " AND " + keyset
+		}
+	}
+
+	args = append(args, limit+1)
+	query := fmt.Sprintf(`
+		SELECT %s, (%s)::text
+		FROM orders%s
+		ORDER BY %s %s, id %s
+		LIMIT $%d
+	`, orderColumns, spec.expr, where, spec.expr, direction, direction, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+	defer rows.Close()
+
+	page := &domain.OrderPage{Total: total}
+	var lastKey string
+	for rows.Next() {
+		var key string
+		order, err := scanOrder(keyScanner{rowScanner: rows, key: &key})
+		if err != nil {
+			return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+		}
+		if len(page.Orders) == limit {
+			// The extra row only tells us there is a next page
+			last := page.Orders[len(page.Orders)-1]
+			next := encodeCursor(listCursor{Key: lastKey, ID: last.ID})
+			page.NextCursor = &next
+			break
+		}
+		page.Orders = append(page.Orders, order)
+		lastKey = key
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+
+	return page, nil
+}
+
+// buildOrderFilter turns a filter into a WHERE clause and its arguments
+func buildOrderFilter(filter domain.OrderFilter) (string, []any) {
+	var conditions []string
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, s := range filter.Statuses {
+			statuses[i] = string(s)
+		}
+		conditions = append(conditions, "status = ANY("+arg(pq.Array(statuses))+")")
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < "+arg(*filter.CreatedTo))
+	}
+	if filter.ProfileID != "" {
+		conditions = append(conditions, "profile_id = "+arg(filter.ProfileID))
+	}
+	if filter.UserID != "" {
+		conditions = append(conditions, "user_id = "+arg(filter.UserID))
+	}
+	if filter.Phone != "" {
+		phone := arg("%" + escapeLike(filter.Phone) + "%")
+		conditions = append(conditions, `(profile_id IN (SELECT id FROM profiles WHERE phone_number ILIKE `+phone+`)
+			OR id IN (SELECT order_id FROM order_tokens WHERE phone_number ILIKE `+phone+`))`)
+	}
+	if filter.MinAmount != nil {
+		conditions = append(conditions, orderAmountExpr+" >= "+arg(*filter.MinAmount))
+	}
+	if filter.MaxAmount != nil {
+		conditions = append(conditions, orderAmountExpr+" <= "+arg(*filter.MaxAmount))
+	}
+	if filter.Search != "" {
+		search := arg("%" + escapeLike(filter.Search) + "%")
+		// order_items_search_text has a trigram index, a per-element scan would not
+		conditions = append(conditions, "order_items_search_text(data) ILIKE "+search)
+	}
+
+	if filter.Flagged {
+		conditions = append(conditions, "payment_flag IS NOT NULL")
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// escapeLike escapes the LIKE wildcards in user input
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
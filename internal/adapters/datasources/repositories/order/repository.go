@@ -2,8 +2,9 @@ package order
 
 import (
 	"context"
-	"database/sql"
+	"time"
 
+	"yego/internal/adapters/datasources"
 	"yego/internal/domain"
 	apperrors "yego/internal/platform/errors"
 )
@@ -14,17 +15,26 @@ type Repository interface {
 	GetByID(ctx context.Context, id string) (*domain.Order, apperrors.ApplicationError)
 	GetAll(ctx context.Context) ([]*domain.Order, apperrors.ApplicationError)
 	GetByUserID(ctx context.Context, userID string) ([]*domain.Order, apperrors.ApplicationError)
+	ListByDeliverySlot(ctx context.Context, slotID string, date time.Time) ([]*domain.Order, apperrors.ApplicationError)
+	List(ctx context.Context, filter domain.OrderFilter) (*domain.OrderPage, apperrors.ApplicationError)
+	CountByStatuses(ctx context.Context, statuses []domain.OrderStatus) (int, apperrors.ApplicationError)
+	ListCreatedBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Order, apperrors.ApplicationError)
+	ListActiveSince(ctx context.Context, since time.Time, limit int) ([]*domain.Order, apperrors.ApplicationError)
 	UpdateStatus(ctx context.Context, id string, status domain.OrderStatus) (*domain.Order, apperrors.ApplicationError)
+	UpdateStatusFrom(ctx context.Context, id string, from, to domain.OrderStatus) (*domain.Order, apperrors.ApplicationError)
 	Update(ctx context.Context, order *domain.Order) (*domain.Order, apperrors.ApplicationError)
 	AssignUser(ctx context.Context, orderID string, userID string) apperrors.ApplicationError
 	AssignProfile(ctx context.Context, orderID string, profileID string) apperrors.ApplicationError
+	SetPriceBreakdown(ctx context.Context, orderID string, breakdown *domain.PriceBreakdown) apperrors.ApplicationError
+	SetPaymentFlag(ctx context.Context, orderID string, flag domain.PaymentFlag) apperrors.ApplicationError
+	MarkPaymentReceived(ctx context.Context, orderID string, receivedAt time.Time) (*domain.Order, apperrors.ApplicationError)
 }
 
 type repository struct {
-	db *sql.DB
+	db datasources.DBTX
 }
 
 // NewRepository creates a new order repository
-func NewRepository(db *sql.DB) Repository {
+func NewRepository(db datasources.DBTX) Repository {
 	return &repository{db: db}
 }
@@ -3,6 +3,8 @@ package order
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"yego/internal/domain"
@@ -14,7 +16,7 @@ import (
 func (r *repository) UpdateStatus(ctx context.Context, id string, status domain.OrderStatus) (*domain.Order, apperrors.ApplicationError) {
 	query := `
 		UPDATE orders
-		SET status = $1, updated_at = $2
+		SET status = $1, version = version + 1, updated_at = $2
 		WHERE id = $3
 	`
 
@@ -35,7 +37,39 @@ func (r *repository) UpdateStatus(ctx context.Context, id string, status domain.
 	return r.GetByID(ctx, id)
 }
 
-// Update updates an order (status, eta, data, etc.)
+// UpdateStatusFrom moves an order from one status to another. The write only happens while
+// the order is still in from; otherwise it fails with a version conflict and the caller
+// should re-read the order.
+func (r *repository) UpdateStatusFrom(ctx context.Context, id string, from, to domain.OrderStatus) (*domain.Order, apperrors.ApplicationError) {
+	query := `
+		UPDATE orders
+		SET status = $1, version = version + 1, updated_at = $2
+		WHERE id = $3 AND status = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, to, time.Now(), id, from)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	if rowsAffected == 0 {
+		if _, appErr := r.GetByID(ctx, id); appErr != nil {
+			return nil, appErr
+		}
+		return nil, apperrors.NewApplicationError(mappings.OrderVersionConflictError, fmt.Errorf("order is no longer %s", from))
+	}
+
+	return r.GetByID(ctx, id)
+}
+
+// Update updates an order (status, eta, data, etc.).
+// The write only succeeds if the stored version still matches order.Version,
+// so concurrent edits fail with a version conflict instead of overwriting each other.
 func (r *repository) Update(ctx context.Context, order *domain.Order) (*domain.Order, apperrors.ApplicationError) {
 	order.UpdatedAt = time.Now()
 
@@ -44,10 +78,18 @@ func (r *repository) Update(ctx context.Context, order *domain.Order) (*domain.O
 		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
 	}
 
+	priceBreakdownJSON, err := order.PriceBreakdownJSON()
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
 	query := `
 		UPDATE orders
-		SET status = $1, status_message = $2, eta = $3, data = $4, updated_at = $5
-		WHERE id = $6
+		SET status = $1, status_message = $2, eta = $3, estimated_delivery_at = $4,
+			estimated_delivery_window_end = $5, data = $6, cancellation_reason = $7,
+			delivery_slot_id = $8, delivery_date = $9, price_breakdown = $10, payment_method = $11,
+			version = version + 1, updated_at = $12
+		WHERE id = $13 AND version = $14
 	`
 
 	var statusMessage sql.NullString
@@ -55,7 +97,22 @@ func (r *repository) Update(ctx context.Context, order *domain.Order) (*domain.O
 		statusMessage = sql.NullString{String: *order.StatusMessage, Valid: true}
 	}
 
-	result, err := r.db.ExecContext(ctx, query, order.Status, statusMessage, order.ETA, dataJSON, order.UpdatedAt, order.ID)
+	result, err := r.db.ExecContext(ctx, query,
+		order.Status,
+		statusMessage,
+		order.ETA,
+		order.EstimatedDeliveryAt,
+		order.EstimatedDeliveryWindowEnd,
+		dataJSON,
+		order.CancellationReason,
+		order.DeliverySlotID,
+		deliveryDateParam(order.DeliveryDate),
+		priceBreakdownJSON,
+		order.PaymentMethod,
+		order.UpdatedAt,
+		order.ID,
+		order.Version,
+	)
 	if err != nil {
 		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
 	}
@@ -66,8 +123,97 @@ func (r *repository) Update(ctx context.Context, order *domain.Order) (*domain.O
 	}
 
 	if rowsAffected == 0 {
+		var exists bool
+		if err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM orders WHERE id = $1)`, order.ID).Scan(&exists); err != nil {
+			return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+		}
+		if exists {
+			return nil, apperrors.NewApplicationError(mappings.OrderVersionConflictError, fmt.Errorf("order %s is no longer at version %d", order.ID, order.Version))
+		}
 		return nil, apperrors.NewApplicationError(mappings.OrderNotFoundError, nil)
 	}
 
 	return r.GetByID(ctx, order.ID)
 }
+
+// SetPriceBreakdown stores a price snapshot on an order that was never priced.
+// It does not bump the version: the snapshot is derived from data the version already covers.
+func (r *repository) SetPriceBreakdown(ctx context.Context, orderID string, breakdown *domain.PriceBreakdown) apperrors.ApplicationError {
+	data, err := json.Marshal(breakdown)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, `UPDATE orders SET price_breakdown = $1 WHERE id = $2`, data, orderID)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewApplicationError(mappings.OrderNotFoundError, nil)
+	}
+
+	return nil
+}
+
+// SetPaymentFlag flags an order's payment for review. Like SetPriceBreakdown it does not
+// bump the version: the flag is set by payment notifications, not by edits to the order.
+// The first flag is kept when the order is already flagged.
+func (r *repository) SetPaymentFlag(ctx context.Context, orderID string, flag domain.PaymentFlag) apperrors.ApplicationError {
+	query := `
+		UPDATE orders
+		SET payment_flag = COALESCE(payment_flag, $1), payment_flagged_at = COALESCE(payment_flagged_at, NOW())
+		WHERE id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, flag, orderID)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewApplicationError(mappings.OrderNotFoundError, nil)
+	}
+
+	return nil
+}
+
+// MarkPaymentReceived records that a cash or transfer payment of the order was received.
+// It bumps the version: unlike a payment flag, it changes what the order may do next.
+func (r *repository) MarkPaymentReceived(ctx context.Context, orderID string, receivedAt time.Time) (*domain.Order, apperrors.ApplicationError) {
+	query := `
+		UPDATE orders
+		SET payment_received_at = $1, version = version + 1, updated_at = $2
+		WHERE id = $3 AND payment_received_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, receivedAt, time.Now(), orderID)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderUpdateError, err)
+	}
+
+	if rowsAffected == 0 {
+		order, appErr := r.GetByID(ctx, orderID)
+		if appErr != nil {
+			return nil, appErr
+		}
+		return nil, apperrors.NewApplicationError(mappings.OrderPaymentAlreadyReceivedError, fmt.Errorf("payment of order %s received at %s", orderID, order.PaymentReceivedAt.Format("2006-01-02T15:04:05Z")))
+	}
+
+	return r.GetByID(ctx, orderID)
+}
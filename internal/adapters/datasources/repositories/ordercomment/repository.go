@@ -0,0 +1,97 @@
+package ordercomment
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for order comment operations
+type Repository interface {
+	Create(ctx context.Context, comment *domain.OrderComment) (*domain.OrderComment, apperrors.ApplicationError)
+	// ListByOrderID returns the comments of an order oldest first; internal notes are
+	// left out unless includeInternal is set
+	ListByOrderID(ctx context.Context, orderID string, includeInternal bool) ([]*domain.OrderComment, apperrors.ApplicationError)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new order comment repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+// Create stores a new comment on an order
+func (r *repository) Create(ctx context.Context, comment *domain.OrderComment) (*domain.OrderComment, apperrors.ApplicationError) {
+	if comment.ID == "" {
+		comment.ID = uuid.New().String()
+	}
+	if comment.CreatedAt.IsZero() {
+		comment.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO order_comments (
+			id, order_id, author_user_id, author_role, visibility, body, attachment_key, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		comment.ID, comment.OrderID, comment.AuthorUserID, comment.AuthorRole,
+		comment.Visibility, comment.Body, comment.AttachmentKey, comment.CreatedAt,
+	)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderCommentCreateError, err)
+	}
+
+	return comment, nil
+}
+
+// ListByOrderID retrieves the comments of an order in chronological order
+func (r *repository) ListByOrderID(ctx context.Context, orderID string, includeInternal bool) ([]*domain.OrderComment, apperrors.ApplicationError) {
+	query := `
+		SELECT id, order_id, author_user_id, author_role, visibility, body, attachment_key, created_at
+		FROM order_comments
+		WHERE order_id = $1 AND ($2 OR visibility = $3)
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orderID, includeInternal, domain.CommentVisibilityCustomer)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderCommentListError, err)
+	}
+	defer rows.Close()
+
+	var comments []*domain.OrderComment
+	for rows.Next() {
+		var c domain.OrderComment
+		var attachmentKey sql.NullString
+
+		if err := rows.Scan(
+			&c.ID, &c.OrderID, &c.AuthorUserID, &c.AuthorRole,
+			&c.Visibility, &c.Body, &attachmentKey, &c.CreatedAt,
+		); err != nil {
+			return nil, apperrors.NewApplicationError(mappings.OrderCommentListError, err)
+		}
+
+		if attachmentKey.Valid {
+			c.AttachmentKey = &attachmentKey.String
+		}
+
+		comments = append(comments, &c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderCommentListError, err)
+	}
+
+	return comments, nil
+}
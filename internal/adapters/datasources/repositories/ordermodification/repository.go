@@ -0,0 +1,215 @@
+package ordermodification
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Repository defines the interface for order modification request operations
+type Repository interface {
+	Create(ctx context.Context, request *domain.OrderModificationRequest) (*domain.OrderModificationRequest, apperrors.ApplicationError)
+	GetByID(ctx context.Context, id string) (*domain.OrderModificationRequest, apperrors.ApplicationError)
+	ListByOrderID(ctx context.Context, orderID string) ([]*domain.OrderModificationRequest, apperrors.ApplicationError)
+	ListByStatus(ctx context.Context, status domain.ModificationRequestStatus) ([]*domain.OrderModificationRequest, apperrors.ApplicationError)
+	Review(ctx context.Context, request *domain.OrderModificationRequest) (*domain.OrderModificationRequest, apperrors.ApplicationError)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new order modification request repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+const requestColumns = `id, order_id, requested_by_user_id, status, previous_status, changes, proposed_data,
+		note, reviewed_by_user_id, review_note, reviewed_at, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRequest(scanner rowScanner) (*domain.OrderModificationRequest, error) {
+	var m domain.OrderModificationRequest
+	var changesJSON, proposedJSON []byte
+	var note, reviewedBy, reviewNote sql.NullString
+	var reviewedAt sql.NullTime
+
+	err := scanner.Scan(
+		&m.ID, &m.OrderID, &m.RequestedByUserID, &m.Status, &m.PreviousStatus,
+		&changesJSON, &proposedJSON,
+		&note, &reviewedBy, &reviewNote, &reviewedAt,
+		&m.CreatedAt, &m.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(changesJSON, &m.Changes); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(proposedJSON, &m.ProposedData); err != nil {
+		return nil, err
+	}
+	if note.Valid {
+		m.Note = &note.String
+	}
+	if reviewedBy.Valid {
+		m.ReviewedByUserID = &reviewedBy.String
+	}
+	if reviewNote.Valid {
+		m.ReviewNote = &reviewNote.String
+	}
+	if reviewedAt.Valid {
+		m.ReviewedAt = &reviewedAt.Time
+	}
+
+	return &m, nil
+}
+
+// Create stores a new pending modification request.
+// Only one pending request per order is allowed.
+func (r *repository) Create(ctx context.Context, request *domain.OrderModificationRequest) (*domain.OrderModificationRequest, apperrors.ApplicationError) {
+	if request.ID == "" {
+		request.ID = uuid.New().String()
+	}
+	if request.Status == "" {
+		request.Status = domain.ModificationRequestPending
+	}
+	now := time.Now()
+	request.CreatedAt = now
+	request.UpdatedAt = now
+
+	changesJSON, err := json.Marshal(request.Changes)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderModificationCreateError, err)
+	}
+	proposedJSON, err := json.Marshal(request.ProposedData)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderModificationCreateError, err)
+	}
+
+	query := `
+		INSERT INTO order_modification_requests (
+			id, order_id, requested_by_user_id, status, previous_status, changes, proposed_data,
+			note, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		request.ID, request.OrderID, request.RequestedByUserID, request.Status, request.PreviousStatus,
+		changesJSON, proposedJSON, request.Note, request.CreatedAt, request.UpdatedAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, apperrors.NewApplicationError(mappings.OrderModificationPendingError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.OrderModificationCreateError, err)
+	}
+
+	return request, nil
+}
+
+// GetByID retrieves a modification request by ID
+func (r *repository) GetByID(ctx context.Context, id string) (*domain.OrderModificationRequest, apperrors.ApplicationError) {
+	query := `SELECT ` + requestColumns + ` FROM order_modification_requests WHERE id = $1`
+
+	m, err := scanRequest(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, apperrors.NewApplicationError(mappings.OrderModificationNotFoundError, err)
+	}
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderModificationGetError, err)
+	}
+
+	return m, nil
+}
+
+// ListByOrderID retrieves every modification request of an order, newest first
+func (r *repository) ListByOrderID(ctx context.Context, orderID string) ([]*domain.OrderModificationRequest, apperrors.ApplicationError) {
+	query := `
+		SELECT ` + requestColumns + `
+		FROM order_modification_requests
+		WHERE order_id = $1
+		ORDER BY created_at DESC
+	`
+
+	return r.list(ctx, query, orderID)
+}
+
+// ListByStatus retrieves modification requests in a review state, oldest first
+func (r *repository) ListByStatus(ctx context.Context, status domain.ModificationRequestStatus) ([]*domain.OrderModificationRequest, apperrors.ApplicationError) {
+	query := `
+		SELECT ` + requestColumns + `
+		FROM order_modification_requests
+		WHERE status = $1
+		ORDER BY created_at ASC
+	`
+
+	return r.list(ctx, query, status)
+}
+
+func (r *repository) list(ctx context.Context, query string, args ...any) ([]*domain.OrderModificationRequest, apperrors.ApplicationError) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderModificationListError, err)
+	}
+	defer rows.Close()
+
+	var requests []*domain.OrderModificationRequest
+	for rows.Next() {
+		m, err := scanRequest(rows)
+		if err != nil {
+			return nil, apperrors.NewApplicationError(mappings.OrderModificationListError, err)
+		}
+		requests = append(requests, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderModificationListError, err)
+	}
+
+	return requests, nil
+}
+
+// Review stores the outcome of a pending request. It fails with a not-pending
+// error when another reviewer got there first.
+func (r *repository) Review(ctx context.Context, request *domain.OrderModificationRequest) (*domain.OrderModificationRequest, apperrors.ApplicationError) {
+	now := time.Now()
+	request.ReviewedAt = &now
+	request.UpdatedAt = now
+
+	query := `
+		UPDATE order_modification_requests
+		SET status = $1, reviewed_by_user_id = $2, review_note = $3, reviewed_at = $4, updated_at = $5
+		WHERE id = $6 AND status = $7
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		request.Status, request.ReviewedByUserID, request.ReviewNote, request.ReviewedAt, request.UpdatedAt,
+		request.ID, domain.ModificationRequestPending,
+	)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderModificationUpdateError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderModificationUpdateError, err)
+	}
+	if rowsAffected == 0 {
+		return nil, apperrors.NewApplicationError(mappings.OrderModificationNotPendingError, nil)
+	}
+
+	return request, nil
+}
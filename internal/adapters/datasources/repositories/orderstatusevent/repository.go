@@ -0,0 +1,102 @@
+package orderstatusevent
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for order status event operations
+type Repository interface {
+	Create(ctx context.Context, event *domain.OrderStatusEvent) (*domain.OrderStatusEvent, apperrors.ApplicationError)
+	ListByOrderID(ctx context.Context, orderID string) ([]*domain.OrderStatusEvent, apperrors.ApplicationError)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new order status event repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+// Create records a status change for an order
+func (r *repository) Create(ctx context.Context, event *domain.OrderStatusEvent) (*domain.OrderStatusEvent, apperrors.ApplicationError) {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO order_status_events (
+			id, order_id, from_status, to_status, actor_user_id, status_message, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		event.ID, event.OrderID, event.FromStatus, event.ToStatus,
+		event.ActorUserID, event.StatusMessage, event.CreatedAt,
+	)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderStatusEventCreateError, err)
+	}
+
+	return event, nil
+}
+
+// ListByOrderID retrieves the status changes of an order in chronological order
+func (r *repository) ListByOrderID(ctx context.Context, orderID string) ([]*domain.OrderStatusEvent, apperrors.ApplicationError) {
+	query := `
+		SELECT id, order_id, from_status, to_status, actor_user_id, status_message, created_at
+		FROM order_status_events
+		WHERE order_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderStatusEventListError, err)
+	}
+	defer rows.Close()
+
+	var events []*domain.OrderStatusEvent
+	for rows.Next() {
+		var e domain.OrderStatusEvent
+		var fromStatus, actorUserID, statusMessage sql.NullString
+
+		if err := rows.Scan(
+			&e.ID, &e.OrderID, &fromStatus, &e.ToStatus,
+			&actorUserID, &statusMessage, &e.CreatedAt,
+		); err != nil {
+			return nil, apperrors.NewApplicationError(mappings.OrderStatusEventListError, err)
+		}
+
+		if fromStatus.Valid {
+			s := domain.OrderStatus(fromStatus.String)
+			e.FromStatus = &s
+		}
+		if actorUserID.Valid {
+			e.ActorUserID = &actorUserID.String
+		}
+		if statusMessage.Valid {
+			e.StatusMessage = &statusMessage.String
+		}
+
+		events = append(events, &e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderStatusEventListError, err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,32 @@
+package ordertoken
+
+import (
+	"context"
+	"time"
+
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// MarkExpired stamps expired_at on every unclaimed token that is past its expiry
+// or whose order was cancelled, and returns how many tokens it marked
+func (r *repository) MarkExpired(ctx context.Context, now time.Time) (int64, apperrors.ApplicationError) {
+	query := `
+		UPDATE order_tokens
+		SET expired_at = $1
+		WHERE claimed_at IS NULL AND expired_at IS NULL
+			AND (expires_at < $1 OR order_id IN (SELECT id FROM orders WHERE status = 'CANCELLED'))
+	`
+
+	result, err := r.db.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+
+	return rowsAffected, nil
+}
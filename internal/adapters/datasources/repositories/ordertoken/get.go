@@ -14,7 +14,7 @@ import (
 // GetByToken retrieves an order token by its token value
 func (r *repository) GetByToken(ctx context.Context, token string) (*domain.OrderToken, apperrors.ApplicationError) {
 	query := `
-		SELECT id, order_id, token, phone_number, claimed_at, claimed_by_user_id, expires_at, created_at
+		SELECT id, order_id, token, phone_number, claimed_at, claimed_by_user_id, expires_at, expired_at, created_at
 		FROM order_tokens
 		WHERE token = $1
 	`
@@ -28,6 +28,7 @@ func (r *repository) GetByToken(ctx context.Context, token string) (*domain.Orde
 		&orderToken.ClaimedAt,
 		&orderToken.ClaimedByUserID,
 		&orderToken.ExpiresAt,
+		&orderToken.ExpiredAt,
 		&orderToken.CreatedAt,
 	)
 
@@ -44,7 +45,7 @@ func (r *repository) GetByToken(ctx context.Context, token string) (*domain.Orde
 // GetByOrderID retrieves an order token by order ID
 func (r *repository) GetByOrderID(ctx context.Context, orderID string) (*domain.OrderToken, apperrors.ApplicationError) {
 	query := `
-		SELECT id, order_id, token, phone_number, claimed_at, claimed_by_user_id, expires_at, created_at
+		SELECT id, order_id, token, phone_number, claimed_at, claimed_by_user_id, expires_at, expired_at, created_at
 		FROM order_tokens
 		WHERE order_id = $1
 	`
@@ -58,6 +59,7 @@ func (r *repository) GetByOrderID(ctx context.Context, orderID string) (*domain.
 		&orderToken.ClaimedAt,
 		&orderToken.ClaimedByUserID,
 		&orderToken.ExpiresAt,
+		&orderToken.ExpiredAt,
 		&orderToken.CreatedAt,
 	)
 
@@ -3,6 +3,7 @@ package ordertoken
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"yego/internal/domain"
 	apperrors "yego/internal/platform/errors"
@@ -14,6 +15,7 @@ type Repository interface {
 	GetByToken(ctx context.Context, token string) (*domain.OrderToken, apperrors.ApplicationError)
 	GetByOrderID(ctx context.Context, orderID string) (*domain.OrderToken, apperrors.ApplicationError)
 	MarkAsClaimed(ctx context.Context, token string, userID string) apperrors.ApplicationError
+	MarkExpired(ctx context.Context, now time.Time) (int64, apperrors.ApplicationError)
 }
 
 type repository struct {
@@ -1,24 +1,42 @@
 package repositories
 
 import (
+	"database/sql"
+
 	"yego/internal/adapters/datasources"
 	"yego/internal/adapters/datasources/repositories/coupon"
+	"yego/internal/adapters/datasources/repositories/deliveryslot"
+	"yego/internal/adapters/datasources/repositories/idempotencykey"
 	"yego/internal/adapters/datasources/repositories/importrecord"
 	"yego/internal/adapters/datasources/repositories/order"
+	"yego/internal/adapters/datasources/repositories/ordercomment"
+	"yego/internal/adapters/datasources/repositories/ordermodification"
+	"yego/internal/adapters/datasources/repositories/orderstatusevent"
 	"yego/internal/adapters/datasources/repositories/ordertoken"
 	"yego/internal/adapters/datasources/repositories/profile"
 	"yego/internal/adapters/datasources/repositories/settings"
+	"yego/internal/adapters/datasources/repositories/subscription"
 	"yego/internal/adapters/datasources/repositories/transaction"
+	"yego/internal/adapters/datasources/repositories/webhookevent"
 )
 
 type Repositories struct {
-	Coupon       coupon.Repository
-	ImportRecord importrecord.Repository
-	Order        order.Repository
-	OrderToken   ordertoken.Repository
-	Profile      profile.Repository
-	Settings     settings.Repository
-	Transaction  transaction.Repository
+	Coupon            coupon.Repository
+	DeliverySlot      deliveryslot.Repository
+	IdempotencyKey    idempotencykey.Repository
+	ImportRecord      importrecord.Repository
+	Order             order.Repository
+	OrderComment      ordercomment.Repository
+	OrderModification ordermodification.Repository
+	OrderStatusEvent  orderstatusevent.Repository
+	OrderToken        ordertoken.Repository
+	Profile           profile.Repository
+	Settings          settings.Repository
+	Subscription      subscription.Repository
+	Transaction       transaction.Repository
+	WebhookEvent      webhookevent.Repository
+
+	db *sql.DB
 }
 
 type Factory func() *Repositories
@@ -26,13 +44,22 @@ type Factory func() *Repositories
 func NewFactory(datasources *datasources.Datasources) func() *Repositories {
 	return func() *Repositories {
 		return &Repositories{
-			Coupon:       coupon.NewRepository(datasources.DB),
-			ImportRecord: importrecord.NewRepository(datasources.DB),
-			Order:        order.NewRepository(datasources.DB),
-			OrderToken:   ordertoken.NewRepository(datasources.DB),
-			Profile:      profile.NewRepository(datasources.DB),
-			Settings:     settings.NewRepository(datasources.DB),
-			Transaction:  transaction.NewRepository(datasources.DB),
+			Coupon:            coupon.NewRepository(datasources.DB),
+			DeliverySlot:      deliveryslot.NewRepository(datasources.DB),
+			IdempotencyKey:    idempotencykey.NewRepository(datasources.DB),
+			ImportRecord:      importrecord.NewRepository(datasources.DB),
+			Order:             order.NewRepository(datasources.DB),
+			OrderComment:      ordercomment.NewRepository(datasources.DB),
+			OrderModification: ordermodification.NewRepository(datasources.DB),
+			OrderStatusEvent:  orderstatusevent.NewRepository(datasources.DB),
+			OrderToken:        ordertoken.NewRepository(datasources.DB),
+			Profile:           profile.NewRepository(datasources.DB),
+			Settings:          settings.NewRepository(datasources.DB),
+			Subscription:      subscription.NewRepository(datasources.DB),
+			Transaction:       transaction.NewRepository(datasources.DB),
+			WebhookEvent:      webhookevent.NewRepository(datasources.DB),
+
+			db: datasources.DB,
 		}
 	}
 }
@@ -0,0 +1,312 @@
+package subscription
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for subscription operations
+type Repository interface {
+	Create(ctx context.Context, subscription *domain.Subscription) (*domain.Subscription, apperrors.ApplicationError)
+	GetByID(ctx context.Context, id string) (*domain.Subscription, apperrors.ApplicationError)
+	ListByUserID(ctx context.Context, userID string) ([]*domain.Subscription, apperrors.ApplicationError)
+	// List returns every subscription, optionally only those in the given status
+	List(ctx context.Context, status domain.SubscriptionStatus) ([]*domain.Subscription, apperrors.ApplicationError)
+	Update(ctx context.Context, subscription *domain.Subscription) (*domain.Subscription, apperrors.ApplicationError)
+	// ListDue returns active subscriptions whose next run is at or before now, oldest first
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Subscription, apperrors.ApplicationError)
+	// ClaimRun moves an active subscription from its current next run to the following one.
+	// It returns false when another worker already claimed that run or the subscription changed.
+	ClaimRun(ctx context.Context, id string, scheduledFor time.Time, nextRunAt time.Time) (bool, apperrors.ApplicationError)
+	SetLastOrder(ctx context.Context, id string, orderID string) apperrors.ApplicationError
+	CreateRun(ctx context.Context, run *domain.SubscriptionRun) (*domain.SubscriptionRun, apperrors.ApplicationError)
+	ListRuns(ctx context.Context, subscriptionID string) ([]*domain.SubscriptionRun, apperrors.ApplicationError)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new subscription repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+const subscriptionColumns = `id, user_id, profile_id, items, frequency, status, next_run_at, anchor_day,
+		auto_charge, last_run_at, last_order_id, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSubscription(scanner rowScanner) (*domain.Subscription, error) {
+	var s domain.Subscription
+	var itemsJSON []byte
+	var profileID, lastOrderID sql.NullString
+	var lastRunAt sql.NullTime
+
+	err := scanner.Scan(
+		&s.ID, &s.UserID, &profileID, &itemsJSON, &s.Frequency, &s.Status, &s.NextRunAt, &s.AnchorDay,
+		&s.AutoCharge, &lastRunAt, &lastOrderID, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(itemsJSON, &s.Items); err != nil {
+		return nil, err
+	}
+	if profileID.Valid {
+		s.ProfileID = &profileID.String
+	}
+	if lastRunAt.Valid {
+		s.LastRunAt = &lastRunAt.Time
+	}
+	if lastOrderID.Valid {
+		s.LastOrderID = &lastOrderID.String
+	}
+
+	return &s, nil
+}
+
+// Create stores a new subscription
+func (r *repository) Create(ctx context.Context, subscription *domain.Subscription) (*domain.Subscription, apperrors.ApplicationError) {
+	if subscription.ID == "" {
+		subscription.ID = uuid.New().String()
+	}
+	if subscription.Status == "" {
+		subscription.Status = domain.SubscriptionActive
+	}
+	now := time.Now()
+	subscription.CreatedAt = now
+	subscription.UpdatedAt = now
+
+	itemsJSON, err := json.Marshal(subscription.Items)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionCreateError, err)
+	}
+
+	query := `
+		INSERT INTO subscriptions (
+			id, user_id, profile_id, items, frequency, status, next_run_at, anchor_day, auto_charge, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		subscription.ID, subscription.UserID, subscription.ProfileID, itemsJSON, subscription.Frequency,
+		subscription.Status, subscription.NextRunAt, subscription.AnchorDay, subscription.AutoCharge, subscription.CreatedAt, subscription.UpdatedAt,
+	)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionCreateError, err)
+	}
+
+	return subscription, nil
+}
+
+// GetByID retrieves a subscription by its ID
+func (r *repository) GetByID(ctx context.Context, id string) (*domain.Subscription, apperrors.ApplicationError) {
+	query := `SELECT ` + subscriptionColumns + ` FROM subscriptions WHERE id = $1`
+
+	subscription, err := scanSubscription(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewApplicationError(mappings.SubscriptionNotFoundError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionListError, err)
+	}
+
+	return subscription, nil
+}
+
+// ListByUserID retrieves the subscriptions of a user, newest first
+func (r *repository) ListByUserID(ctx context.Context, userID string) ([]*domain.Subscription, apperrors.ApplicationError) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	return r.list(ctx, query, userID)
+}
+
+// List retrieves all subscriptions, newest first
+func (r *repository) List(ctx context.Context, status domain.SubscriptionStatus) ([]*domain.Subscription, apperrors.ApplicationError) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE $1 = '' OR status = $1
+		ORDER BY created_at DESC
+	`
+
+	return r.list(ctx, query, string(status))
+}
+
+// ListDue retrieves the active subscriptions that should place an order now
+func (r *repository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.Subscription, apperrors.ApplicationError) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE status = $1 AND next_run_at <= $2
+		ORDER BY next_run_at ASC
+		LIMIT $3
+	`
+
+	return r.list(ctx, query, domain.SubscriptionActive, now, limit)
+}
+
+// Update saves the editable fields of a subscription
+func (r *repository) Update(ctx context.Context, subscription *domain.Subscription) (*domain.Subscription, apperrors.ApplicationError) {
+	subscription.UpdatedAt = time.Now()
+
+	itemsJSON, err := json.Marshal(subscription.Items)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionUpdateError, err)
+	}
+
+	query := `
+		UPDATE subscriptions
+		SET profile_id = $1, items = $2, frequency = $3, status = $4, next_run_at = $5,
+			anchor_day = $6, auto_charge = $7, updated_at = $8
+		WHERE id = $9
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		subscription.ProfileID, itemsJSON, subscription.Frequency, subscription.Status,
+		subscription.NextRunAt, subscription.AnchorDay, subscription.AutoCharge, subscription.UpdatedAt, subscription.ID,
+	)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionUpdateError, err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionNotFoundError, nil)
+	}
+
+	return r.GetByID(ctx, subscription.ID)
+}
+
+// ClaimRun advances next_run_at only if it still holds the run being claimed,
+// so two schedulers never place the same run twice
+func (r *repository) ClaimRun(ctx context.Context, id string, scheduledFor time.Time, nextRunAt time.Time) (bool, apperrors.ApplicationError) {
+	query := `
+		UPDATE subscriptions
+		SET next_run_at = $1, last_run_at = NOW(), updated_at = NOW()
+		WHERE id = $2 AND status = $3 AND next_run_at = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, nextRunAt, id, domain.SubscriptionActive, scheduledFor)
+	if err != nil {
+		return false, apperrors.NewApplicationError(mappings.SubscriptionUpdateError, err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewApplicationError(mappings.SubscriptionUpdateError, err)
+	}
+
+	return n == 1, nil
+}
+
+// SetLastOrder records the order placed by the latest run
+func (r *repository) SetLastOrder(ctx context.Context, id string, orderID string) apperrors.ApplicationError {
+	query := `UPDATE subscriptions SET last_order_id = $1, updated_at = NOW() WHERE id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, orderID, id); err != nil {
+		return apperrors.NewApplicationError(mappings.SubscriptionUpdateError, err)
+	}
+	return nil
+}
+
+// CreateRun records the outcome of a scheduler run
+func (r *repository) CreateRun(ctx context.Context, run *domain.SubscriptionRun) (*domain.SubscriptionRun, apperrors.ApplicationError) {
+	if run.ID == "" {
+		run.ID = uuid.New().String()
+	}
+	if run.CreatedAt.IsZero() {
+		run.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO subscription_runs (id, subscription_id, order_id, scheduled_for, status, error, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		run.ID, run.SubscriptionID, run.OrderID, run.ScheduledFor, run.Status, run.Error, run.CreatedAt,
+	)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionRunCreateError, err)
+	}
+
+	return run, nil
+}
+
+// ListRuns retrieves the runs of a subscription, newest first
+func (r *repository) ListRuns(ctx context.Context, subscriptionID string) ([]*domain.SubscriptionRun, apperrors.ApplicationError) {
+	query := `
+		SELECT id, subscription_id, order_id, scheduled_for, status, error, created_at
+		FROM subscription_runs
+		WHERE subscription_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, subscriptionID)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionListError, err)
+	}
+	defer rows.Close()
+
+	var runs []*domain.SubscriptionRun
+	for rows.Next() {
+		var run domain.SubscriptionRun
+		var orderID, runErr sql.NullString
+		if err := rows.Scan(&run.ID, &run.SubscriptionID, &orderID, &run.ScheduledFor, &run.Status, &runErr, &run.CreatedAt); err != nil {
+			return nil, apperrors.NewApplicationError(mappings.SubscriptionListError, err)
+		}
+		if orderID.Valid {
+			run.OrderID = &orderID.String
+		}
+		if runErr.Valid {
+			run.Error = &runErr.String
+		}
+		runs = append(runs, &run)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionListError, err)
+	}
+
+	return runs, nil
+}
+
+// list runs a query selecting subscriptionColumns and scans every row
+func (r *repository) list(ctx context.Context, query string, args ...any) ([]*domain.Subscription, apperrors.ApplicationError) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionListError, err)
+	}
+	defer rows.Close()
+
+	var subscriptions []*domain.Subscription
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, apperrors.NewApplicationError(mappings.SubscriptionListError, err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionListError, err)
+	}
+
+	return subscriptions, nil
+}
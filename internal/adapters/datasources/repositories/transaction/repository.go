@@ -3,8 +3,11 @@ package transaction
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"time"
 
+	"yego/internal/adapters/datasources"
 	"yego/internal/domain"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
@@ -14,90 +17,73 @@ import (
 
 // Repository defines the interface for transaction operations
 type Repository interface {
-	Create(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, apperrors.ApplicationError)
+	// Create stores a transaction along with the first entry of its status history
+	Create(ctx context.Context, transaction *domain.Transaction, source string) (*domain.Transaction, apperrors.ApplicationError)
+	// UpsertByGatewayPaymentID stores a gateway payment the first time it is seen and
+	// afterwards moves its status along the lifecycle, recording every change. It returns
+	// the stored transaction and whether anything was written.
+	UpsertByGatewayPaymentID(ctx context.Context, transaction *domain.Transaction, source string) (*domain.Transaction, bool, apperrors.ApplicationError)
 	GetByID(ctx context.Context, id string) (*domain.Transaction, apperrors.ApplicationError)
+	GetByGatewayPaymentID(ctx context.Context, provider string, gatewayPaymentID string) (*domain.Transaction, apperrors.ApplicationError)
+	ListStatusEvents(ctx context.Context, transactionID string) ([]*domain.TransactionStatusEvent, apperrors.ApplicationError)
 	GetByOrderID(ctx context.Context, orderID string) (*domain.Transaction, apperrors.ApplicationError)
+	ListByOrderID(ctx context.Context, orderID string) ([]*domain.Transaction, apperrors.ApplicationError)
 	ListByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.Transaction, apperrors.ApplicationError)
 	GetAll(ctx context.Context, limit, offset int) ([]*domain.Transaction, apperrors.ApplicationError)
 	Count(ctx context.Context) (int, apperrors.ApplicationError)
 }
 
 type repository struct {
-	db *sql.DB
+	db datasources.DBTX
 }
 
 // NewRepository creates a new transaction repository
-func NewRepository(db *sql.DB) Repository {
+func NewRepository(db datasources.DBTX) Repository {
 	return &repository{db: db}
 }
 
-// Create creates a new transaction
-func (r *repository) Create(ctx context.Context, transaction *domain.Transaction) (*domain.Transaction, apperrors.ApplicationError) {
-	if transaction.ID == "" {
-		transaction.ID = uuid.New().String()
-	}
-	if transaction.CreatedAt.IsZero() {
-		transaction.CreatedAt = time.Now()
-	}
-	transaction.UpdatedAt = time.Now()
-
-	query := `
-		INSERT INTO transactions (
-			id, order_id, user_id, profile_id, amount, currency, status,
-			payment_id, gateway_payment_id, collector_id, description,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
-	`
-
-	_, err := r.db.ExecContext(ctx, query,
-		transaction.ID, transaction.OrderID, transaction.UserID, transaction.ProfileID,
-		transaction.Amount, transaction.Currency, transaction.Status,
-		transaction.PaymentID, transaction.GatewayPaymentID, transaction.CollectorID,
-		transaction.Description, transaction.CreatedAt, transaction.UpdatedAt,
-	)
-
-	if err != nil {
-		return nil, apperrors.NewApplicationError(mappings.TransactionCreateError, err)
-	}
+// transactionColumns lists the columns read by every transaction query, in scanTransaction order
+const transactionColumns = `id, order_id, user_id, profile_id, type, provider, parent_transaction_id,
+		amount, currency, status, status_detail, payment_id, gateway_payment_id, collector_id, description,
+		confirmed_by_user_id, proof_key, created_at, updated_at`
 
-	return transaction, nil
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
 }
 
-// GetByID retrieves a transaction by ID
-func (r *repository) GetByID(ctx context.Context, id string) (*domain.Transaction, apperrors.ApplicationError) {
-	query := `
-		SELECT id, order_id, user_id, profile_id, amount, currency, status,
-			   payment_id, gateway_payment_id, collector_id, description,
-			   created_at, updated_at
-		FROM transactions
-		WHERE id = $1
-	`
-
+// scanTransaction scans a single transaction row selected with transactionColumns
+func scanTransaction(scanner rowScanner) (*domain.Transaction, error) {
 	var t domain.Transaction
 	var profileID sql.NullString
+	var parentTransactionID sql.NullString
+	var statusDetail sql.NullString
 	var paymentID sql.NullInt64
 	var gatewayPaymentID sql.NullString
 	var collectorID sql.NullString
 	var description sql.NullString
+	var confirmedByUserID sql.NullString
+	var proofKey sql.NullString
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&t.ID, &t.OrderID, &t.UserID, &profileID,
-		&t.Amount, &t.Currency, &t.Status,
+	err := scanner.Scan(
+		&t.ID, &t.OrderID, &t.UserID, &profileID, &t.Type, &t.Provider, &parentTransactionID,
+		&t.Amount, &t.Currency, &t.Status, &statusDetail,
 		&paymentID, &gatewayPaymentID, &collectorID, &description,
-		&t.CreatedAt, &t.UpdatedAt,
+		&confirmedByUserID, &proofKey, &t.CreatedAt, &t.UpdatedAt,
 	)
-
-	if err == sql.ErrNoRows {
-		return nil, apperrors.NewApplicationError(mappings.TransactionNotFoundError, err)
-	}
-
 	if err != nil {
-		return nil, apperrors.NewApplicationError(mappings.TransactionGetError, err)
+		return nil, err
 	}
 
 	if profileID.Valid {
 		t.ProfileID = &profileID.String
 	}
+	if parentTransactionID.Valid {
+		t.ParentTransactionID = &parentTransactionID.String
+	}
+	if statusDetail.Valid {
+		t.StatusDetail = &statusDetail.String
+	}
 	if paymentID.Valid {
 		pid := int(paymentID.Int64)
 		t.PaymentID = &pid
@@ -111,126 +97,219 @@ func (r *repository) GetByID(ctx context.Context, id string) (*domain.Transactio
 	if description.Valid {
 		t.Description = &description.String
 	}
+	if confirmedByUserID.Valid {
+		t.ConfirmedByUserID = &confirmedByUserID.String
+	}
+	if proofKey.Valid {
+		t.ProofKey = &proofKey.String
+	}
 
 	return &t, nil
 }
 
-// GetByOrderID retrieves a transaction by order ID
-func (r *repository) GetByOrderID(ctx context.Context, orderID string) (*domain.Transaction, apperrors.ApplicationError) {
-	query := `
-		SELECT id, order_id, user_id, profile_id, amount, currency, status,
-			   payment_id, gateway_payment_id, collector_id, description,
-			   created_at, updated_at
-		FROM transactions
-		WHERE order_id = $1
-		ORDER BY created_at DESC
-		LIMIT 1
+// insertColumns lists the columns written when a transaction is created, in insertArgs order
+const insertColumns = `id, order_id, user_id, profile_id, type, provider, parent_transaction_id,
+			amount, currency, status, status_detail, payment_id, gateway_payment_id, collector_id, description,
+			confirmed_by_user_id, proof_key, created_at, updated_at`
+
+// prepareInsert fills in the defaults of a new transaction and returns its insert arguments
+func prepareInsert(transaction *domain.Transaction) []any {
+	if transaction.ID == "" {
+		transaction.ID = uuid.New().String()
+	}
+	if transaction.Type == "" {
+		transaction.Type = domain.TransactionTypePayment
+	}
+	if transaction.CreatedAt.IsZero() {
+		transaction.CreatedAt = time.Now()
+	}
+	transaction.UpdatedAt = time.Now()
+
+	return []any{
+		transaction.ID, transaction.OrderID, transaction.UserID, transaction.ProfileID,
+		transaction.Type, transaction.Provider, transaction.ParentTransactionID,
+		transaction.Amount, transaction.Currency, transaction.Status, transaction.StatusDetail,
+		transaction.PaymentID, transaction.GatewayPaymentID, transaction.CollectorID,
+		transaction.Description, transaction.ConfirmedByUserID, transaction.ProofKey,
+		transaction.CreatedAt, transaction.UpdatedAt,
+	}
+}
+
+// insertQuery inserts a transaction and, in the same statement, the first entry of its
+// status history. The event ID and source are the two arguments after the insert arguments.
+func insertQuery(onConflict string) string {
+	return `
+		WITH inserted AS (
+			INSERT INTO transactions (` + insertColumns + `)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+			` + onConflict + `
+			RETURNING id, status, status_detail, created_at
+		)
+		INSERT INTO transaction_status_events (id, transaction_id, from_status, to_status, status_detail, source, created_at)
+		SELECT $20, id, NULL, status, status_detail, $21, created_at FROM inserted
+		RETURNING transaction_id
 	`
+}
 
-	var t domain.Transaction
-	var profileID sql.NullString
-	var paymentID sql.NullInt64
-	var gatewayPaymentID sql.NullString
-	var collectorID sql.NullString
-	var description sql.NullString
+// Create creates a new transaction
+func (r *repository) Create(ctx context.Context, transaction *domain.Transaction, source string) (*domain.Transaction, apperrors.ApplicationError) {
+	args := append(prepareInsert(transaction), uuid.New().String(), source)
 
-	err := r.db.QueryRowContext(ctx, query, orderID).Scan(
-		&t.ID, &t.OrderID, &t.UserID, &profileID,
-		&t.Amount, &t.Currency, &t.Status,
-		&paymentID, &gatewayPaymentID, &collectorID, &description,
-		&t.CreatedAt, &t.UpdatedAt,
-	)
+	if _, err := r.db.ExecContext(ctx, insertQuery(""), args...); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.TransactionCreateError, err)
+	}
 
+	return transaction, nil
+}
+
+// UpsertByGatewayPaymentID inserts the payment, or moves the stored one to the new status
+// when the lifecycle allows it. Both writes are conditional single statements, so two
+// notifications for the same payment racing each other cannot record the same change twice;
+// the loser re-reads the stored transaction and tries again.
+func (r *repository) UpsertByGatewayPaymentID(ctx context.Context, transaction *domain.Transaction, source string) (*domain.Transaction, bool, apperrors.ApplicationError) {
+	if transaction.GatewayPaymentID == nil || *transaction.GatewayPaymentID == "" {
+		return nil, false, apperrors.NewApplicationError(mappings.TransactionCreateError, errors.New("transaction has no gateway payment id"))
+	}
+	if transaction.Provider == "" {
+		return nil, false, apperrors.NewApplicationError(mappings.TransactionCreateError, errors.New("transaction has no payment provider"))
+	}
+	transaction.Type = domain.TransactionTypePayment
+
+	const attempts = 3
+	for i := 0; i < attempts; i++ {
+		args := append(prepareInsert(transaction), uuid.New().String(), source)
+		var insertedID string
+		err := r.db.QueryRowContext(ctx, insertQuery(`ON CONFLICT (provider, gateway_payment_id)
+				WHERE type = 'payment' AND gateway_payment_id IS NOT NULL AND gateway_payment_id <> ''
+				DO NOTHING`), args...).Scan(&insertedID)
+		if err == nil {
+			return transaction, true, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, false, apperrors.NewApplicationError(mappings.TransactionCreateError, err)
+		}
+
+		existing, appErr := r.GetByGatewayPaymentID(ctx, transaction.Provider, *transaction.GatewayPaymentID)
+		if appErr != nil {
+			if appErr.Code() == mappings.TransactionNotFoundError.Code {
+				// Deleted between the insert and the read; insert again
+				continue
+			}
+			return nil, false, appErr
+		}
+		if !domain.CanMoveTransactionStatus(existing.Status, transaction.Status) {
+			return existing, false, nil
+		}
+
+		query := `
+			WITH updated AS (
+				UPDATE transactions
+				SET status = $1, status_detail = COALESCE($2, status_detail), updated_at = $3
+				WHERE id = $4 AND status = $5
+				RETURNING id, status, status_detail, updated_at
+			)
+			INSERT INTO transaction_status_events (id, transaction_id, from_status, to_status, status_detail, source, created_at)
+			SELECT $6, id, $5, status, status_detail, $7, updated_at FROM updated
+			RETURNING transaction_id
+		`
+		now := time.Now()
+		var updatedID string
+		err = r.db.QueryRowContext(ctx, query,
+			transaction.Status, transaction.StatusDetail, now, existing.ID, existing.Status,
+			uuid.New().String(), source,
+		).Scan(&updatedID)
+		if err == nil {
+			existing.Status = transaction.Status
+			if transaction.StatusDetail != nil {
+				existing.StatusDetail = transaction.StatusDetail
+			}
+			existing.UpdatedAt = now
+			return existing, true, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, false, apperrors.NewApplicationError(mappings.TransactionUpdateError, err)
+		}
+		// The status changed since it was read; look again
+	}
+
+	return nil, false, apperrors.NewApplicationError(mappings.TransactionUpdateError,
+		fmt.Errorf("gateway payment %s kept changing while being updated", *transaction.GatewayPaymentID))
+}
+
+// GetByID retrieves a transaction by ID
+func (r *repository) GetByID(ctx context.Context, id string) (*domain.Transaction, apperrors.ApplicationError) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE id = $1`
+
+	t, err := scanTransaction(r.db.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
 		return nil, apperrors.NewApplicationError(mappings.TransactionNotFoundError, err)
 	}
-
 	if err != nil {
 		return nil, apperrors.NewApplicationError(mappings.TransactionGetError, err)
 	}
 
-	if profileID.Valid {
-		t.ProfileID = &profileID.String
-	}
-	if paymentID.Valid {
-		pid := int(paymentID.Int64)
-		t.PaymentID = &pid
-	}
-	if gatewayPaymentID.Valid {
-		t.GatewayPaymentID = &gatewayPaymentID.String
-	}
-	if collectorID.Valid {
-		t.CollectorID = &collectorID.String
+	return t, nil
+}
+
+// GetByGatewayPaymentID retrieves the payment transaction of a provider's gateway payment
+func (r *repository) GetByGatewayPaymentID(ctx context.Context, provider string, gatewayPaymentID string) (*domain.Transaction, apperrors.ApplicationError) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE provider = $1 AND gateway_payment_id = $2 AND type = $3`
+
+	t, err := scanTransaction(r.db.QueryRowContext(ctx, query, provider, gatewayPaymentID, domain.TransactionTypePayment))
+	if err == sql.ErrNoRows {
+		return nil, apperrors.NewApplicationError(mappings.TransactionNotFoundError, err)
 	}
-	if description.Valid {
-		t.Description = &description.String
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.TransactionGetError, err)
 	}
 
-	return &t, nil
+	return t, nil
 }
 
-// ListByUserID retrieves transactions for a user
-func (r *repository) ListByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.Transaction, apperrors.ApplicationError) {
+// GetByOrderID retrieves the latest payment transaction of an order
+func (r *repository) GetByOrderID(ctx context.Context, orderID string) (*domain.Transaction, apperrors.ApplicationError) {
 	query := `
-		SELECT id, order_id, user_id, profile_id, amount, currency, status,
-			   payment_id, gateway_payment_id, collector_id, description,
-			   created_at, updated_at
+		SELECT ` + transactionColumns + `
 		FROM transactions
-		WHERE user_id = $1
+		WHERE order_id = $1 AND type = $2
 		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
+		LIMIT 1
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	t, err := scanTransaction(r.db.QueryRowContext(ctx, query, orderID, domain.TransactionTypePayment))
+	if err == sql.ErrNoRows {
+		return nil, apperrors.NewApplicationError(mappings.TransactionNotFoundError, err)
+	}
 	if err != nil {
-		return nil, apperrors.NewApplicationError(mappings.TransactionListError, err)
+		return nil, apperrors.NewApplicationError(mappings.TransactionGetError, err)
 	}
-	defer rows.Close()
 
-	var transactions []*domain.Transaction
-	for rows.Next() {
-		var t domain.Transaction
-		var profileID sql.NullString
-		var paymentID sql.NullInt64
-		var gatewayPaymentID sql.NullString
-		var collectorID sql.NullString
-		var description sql.NullString
-
-		err := rows.Scan(
-			&t.ID, &t.OrderID, &t.UserID, &profileID,
-			&t.Amount, &t.Currency, &t.Status,
-			&paymentID, &gatewayPaymentID, &collectorID, &description,
-			&t.CreatedAt, &t.UpdatedAt,
-		)
-		if err != nil {
-			return nil, apperrors.NewApplicationError(mappings.TransactionListError, err)
-		}
+	return t, nil
+}
 
-		if profileID.Valid {
-			t.ProfileID = &profileID.String
-		}
-		if paymentID.Valid {
-			pid := int(paymentID.Int64)
-			t.PaymentID = &pid
-		}
-		if gatewayPaymentID.Valid {
-			t.GatewayPaymentID = &gatewayPaymentID.String
-		}
-		if collectorID.Valid {
-			t.CollectorID = &collectorID.String
-		}
-		if description.Valid {
-			t.Description = &description.String
-		}
+// ListByOrderID retrieves every transaction of an order, payments and refunds, oldest first
+func (r *repository) ListByOrderID(ctx context.Context, orderID string) ([]*domain.Transaction, apperrors.ApplicationError) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE order_id = $1
+		ORDER BY created_at ASC
+	`
 
-		transactions = append(transactions, &t)
-	}
+	return r.list(ctx, query, orderID)
+}
 
-	if err = rows.Err(); err != nil {
-		return nil, apperrors.NewApplicationError(mappings.TransactionListError, err)
-	}
+// ListByUserID retrieves transactions for a user
+func (r *repository) ListByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.Transaction, apperrors.ApplicationError) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
 
-	return transactions, nil
+	return r.list(ctx, query, userID, limit, offset)
 }
 
 // GetAll retrieves all transactions
@@ -243,15 +322,18 @@ func (r *repository) GetAll(ctx context.Context, limit, offset int) ([]*domain.T
 	}
 
 	query := `
-		SELECT id, order_id, user_id, profile_id, amount, currency, status,
-			   payment_id, gateway_payment_id, collector_id, description,
-			   created_at, updated_at
+		SELECT ` + transactionColumns + `
 		FROM transactions
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	return r.list(ctx, query, limit, offset)
+}
+
+// list runs a query selecting transactionColumns and scans every row
+func (r *repository) list(ctx context.Context, query string, args ...any) ([]*domain.Transaction, apperrors.ApplicationError) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, apperrors.NewApplicationError(mappings.TransactionListError, err)
 	}
@@ -259,41 +341,11 @@ func (r *repository) GetAll(ctx context.Context, limit, offset int) ([]*domain.T
 
 	var transactions []*domain.Transaction
 	for rows.Next() {
-		var t domain.Transaction
-		var profileID sql.NullString
-		var paymentID sql.NullInt64
-		var gatewayPaymentID sql.NullString
-		var collectorID sql.NullString
-		var description sql.NullString
-
-		err := rows.Scan(
-			&t.ID, &t.OrderID, &t.UserID, &profileID,
-			&t.Amount, &t.Currency, &t.Status,
-			&paymentID, &gatewayPaymentID, &collectorID, &description,
-			&t.CreatedAt, &t.UpdatedAt,
-		)
+		t, err := scanTransaction(rows)
 		if err != nil {
 			return nil, apperrors.NewApplicationError(mappings.TransactionListError, err)
 		}
-
-		if profileID.Valid {
-			t.ProfileID = &profileID.String
-		}
-		if paymentID.Valid {
-			pid := int(paymentID.Int64)
-			t.PaymentID = &pid
-		}
-		if gatewayPaymentID.Valid {
-			t.GatewayPaymentID = &gatewayPaymentID.String
-		}
-		if collectorID.Valid {
-			t.CollectorID = &collectorID.String
-		}
-		if description.Valid {
-			t.Description = &description.String
-		}
-
-		transactions = append(transactions, &t)
+		transactions = append(transactions, t)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -315,3 +367,42 @@ func (r *repository) Count(ctx context.Context) (int, apperrors.ApplicationError
 
 	return count, nil
 }
+
+// ListStatusEvents retrieves the status history of a transaction, oldest first
+func (r *repository) ListStatusEvents(ctx context.Context, transactionID string) ([]*domain.TransactionStatusEvent, apperrors.ApplicationError) {
+	query := `
+		SELECT id, transaction_id, from_status, to_status, status_detail, source, created_at
+		FROM transaction_status_events
+		WHERE transaction_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, transactionID)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.TransactionListError, err)
+	}
+	defer rows.Close()
+
+	var events []*domain.TransactionStatusEvent
+	for rows.Next() {
+		var e domain.TransactionStatusEvent
+		var fromStatus, statusDetail sql.NullString
+
+		if err := rows.Scan(&e.ID, &e.TransactionID, &fromStatus, &e.ToStatus, &statusDetail, &e.Source, &e.CreatedAt); err != nil {
+			return nil, apperrors.NewApplicationError(mappings.TransactionListError, err)
+		}
+		if fromStatus.Valid {
+			e.FromStatus = &fromStatus.String
+		}
+		if statusDetail.Valid {
+			e.StatusDetail = &statusDetail.String
+		}
+		events = append(events, &e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.TransactionListError, err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"yego/internal/adapters/datasources/repositories/order"
+	"yego/internal/adapters/datasources/repositories/transaction"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// InTransaction runs fn with order and transaction repositories bound to a single database
+// transaction. It commits when fn succeeds and rolls everything back when fn fails.
+func (r *Repositories) InTransaction(ctx context.Context, fn func(orders order.Repository, transactions transaction.Repository) apperrors.ApplicationError) apperrors.ApplicationError {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+	// Also rolls back when fn panics; after a commit it is a no-op
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("Warning: failed to roll back transaction: %v", rollbackErr)
+		}
+	}()
+
+	if appErr := fn(order.NewRepository(tx), transaction.NewRepository(tx)); appErr != nil {
+		return appErr
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+	return nil
+}
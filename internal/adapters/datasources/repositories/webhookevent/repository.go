@@ -0,0 +1,334 @@
+package webhookevent
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+	// staleProcessingAfter is how long an event may stay PROCESSING before a
+	// worker that died mid-run is assumed and the event is claimed again
+	staleProcessingAfter = 5 * time.Minute
+)
+
+// Repository defines the interface for webhook event operations
+type Repository interface {
+	// Create stores a received event. An event whose resource already has an open
+	// (pending or processing) event is stored as a duplicate instead.
+	Create(ctx context.Context, event *domain.WebhookEvent) (*domain.WebhookEvent, apperrors.ApplicationError)
+	GetByID(ctx context.Context, id string) (*domain.WebhookEvent, apperrors.ApplicationError)
+	List(ctx context.Context, filter domain.WebhookEventFilter) ([]*domain.WebhookEvent, apperrors.ApplicationError)
+	// ClaimDue marks up to limit due events as processing, counting the attempt, and returns them
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookEvent, apperrors.ApplicationError)
+	MarkProcessed(ctx context.Context, id string) apperrors.ApplicationError
+	// MarkFailed records a failed attempt: the event is retried at retryAt, or left
+	// FAILED when retryAt is nil
+	MarkFailed(ctx context.Context, id string, lastError string, retryAt *time.Time) apperrors.ApplicationError
+	// Replay queues a finished event again with a fresh set of attempts
+	Replay(ctx context.Context, id string) (*domain.WebhookEvent, apperrors.ApplicationError)
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new webhook event repository
+func NewRepository(db *sql.DB) Repository {
+	return &repository{db: db}
+}
+
+// webhookEventColumns lists the columns read by every webhook event query, in scanWebhookEvent order
+const webhookEventColumns = `id, provider, topic, resource_id, request_id, query, raw_body, status,
+		attempts, last_error, next_attempt_at, processed_at, created_at, updated_at`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanWebhookEvent scans a single row selected with webhookEventColumns
+func scanWebhookEvent(scanner rowScanner) (*domain.WebhookEvent, error) {
+	var e domain.WebhookEvent
+	var requestID, query, rawBody, lastError sql.NullString
+	var processedAt sql.NullTime
+
+	err := scanner.Scan(
+		&e.ID, &e.Provider, &e.Topic, &e.ResourceID, &requestID, &query, &rawBody, &e.Status,
+		&e.Attempts, &lastError, &e.NextAttemptAt, &processedAt, &e.CreatedAt, &e.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if requestID.Valid {
+		e.RequestID = &requestID.String
+	}
+	e.Query = query.String
+	e.RawBody = rawBody.String
+	if lastError.Valid {
+		e.LastError = &lastError.String
+	}
+	if processedAt.Valid {
+		e.ProcessedAt = &processedAt.Time
+	}
+
+	return &e, nil
+}
+
+// Create stores a received event. The partial unique index on pending events makes the
+// insert a no-op for a resource that is already waiting, in which case the event is
+// kept for the record as a duplicate. An event whose resource is being processed is
+// queued, since it may carry a newer state.
+func (r *repository) Create(ctx context.Context, event *domain.WebhookEvent) (*domain.WebhookEvent, apperrors.ApplicationError) {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.Status == "" {
+		event.Status = domain.WebhookEventPending
+	}
+	now := time.Now()
+	event.CreatedAt = now
+	event.UpdatedAt = now
+	event.NextAttemptAt = now
+
+	insert := func(onConflict string) (bool, error) {
+		result, err := r.db.ExecContext(ctx, `
+			INSERT INTO webhook_events (
+				id, provider, topic, resource_id, request_id, query, raw_body, status,
+				attempts, next_attempt_at, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 0, $9, $10, $11)
+		`+onConflict,
+			event.ID, event.Provider, event.Topic, event.ResourceID, event.RequestID,
+			event.Query, event.RawBody, event.Status,
+			event.NextAttemptAt, event.CreatedAt, event.UpdatedAt,
+		)
+		if err != nil {
+			return false, err
+		}
+		rows, err := result.RowsAffected()
+		return rows > 0, err
+	}
+
+	if event.Status != domain.WebhookEventPending {
+		if _, err := insert(""); err != nil {
+			return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+		}
+		return event, nil
+	}
+
+	inserted, err := insert(`ON CONFLICT (provider, topic, resource_id) WHERE status = 'PENDING' DO NOTHING`)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+	if !inserted {
+		event.Status = domain.WebhookEventDuplicate
+		if _, err := insert(""); err != nil {
+			return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+		}
+	}
+
+	return event, nil
+}
+
+// GetByID retrieves a webhook event by its ID
+func (r *repository) GetByID(ctx context.Context, id string) (*domain.WebhookEvent, apperrors.ApplicationError) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.WebhookEventNotFoundError, err)
+	}
+
+	query := `SELECT ` + webhookEventColumns + ` FROM webhook_events WHERE id = $1`
+
+	event, err := scanWebhookEvent(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewApplicationError(mappings.WebhookEventNotFoundError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+
+	return event, nil
+}
+
+// List returns the most recent webhook events matching the filter
+func (r *repository) List(ctx context.Context, filter domain.WebhookEventFilter) ([]*domain.WebhookEvent, apperrors.ApplicationError) {
+	var conditions []string
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Status != "" {
+		conditions = append(conditions, "status = "+arg(filter.Status))
+	}
+	if filter.Topic != "" {
+		conditions = append(conditions, "topic = "+arg(filter.Topic))
+	}
+	if filter.ResourceID != "" {
+		conditions = append(conditions, "resource_id = "+arg(filter.ResourceID))
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query := `SELECT ` + webhookEventColumns + ` FROM webhook_events` + where +
+		` ORDER BY created_at DESC, id DESC LIMIT ` + arg(limit)
+
+	return r.list(ctx, query, args...)
+}
+
+// ClaimDue locks the due events with SKIP LOCKED so concurrent workers never
+// pick the same event, and moves them to PROCESSING in the same statement
+func (r *repository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookEvent, apperrors.ApplicationError) {
+	query := `
+		UPDATE webhook_events
+		SET status = $1, attempts = attempts + 1, updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM webhook_events
+			WHERE (status = $2 AND next_attempt_at <= $3)
+				OR (status = $1 AND updated_at < $4)
+			ORDER BY next_attempt_at ASC
+			LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookEventColumns
+
+	return r.list(ctx, query, domain.WebhookEventProcessing, domain.WebhookEventPending,
+		now, now.Add(-staleProcessingAfter), limit)
+}
+
+// MarkProcessed records that an event was handled successfully
+func (r *repository) MarkProcessed(ctx context.Context, id string) apperrors.ApplicationError {
+	query := `
+		UPDATE webhook_events
+		SET status = $1, last_error = NULL, processed_at = NOW(), updated_at = NOW()
+		WHERE id = $2
+	`
+
+	return r.exec(ctx, query, domain.WebhookEventProcessed, id)
+}
+
+// MarkFailed records a failed attempt and schedules the next one, if any. When a newer
+// event for the same resource is already waiting, the retry is left to it and this one
+// is kept as a duplicate.
+func (r *repository) MarkFailed(ctx context.Context, id string, lastError string, retryAt *time.Time) apperrors.ApplicationError {
+	if retryAt == nil {
+		query := `
+			UPDATE webhook_events
+			SET status = $1, last_error = $2, updated_at = NOW()
+			WHERE id = $3
+		`
+		return r.exec(ctx, query, domain.WebhookEventFailed, lastError, id)
+	}
+
+	query := `
+		UPDATE webhook_events
+		SET status = $1, last_error = $2, next_attempt_at = $3, updated_at = NOW()
+		WHERE id = $4
+	`
+	appErr := r.exec(ctx, query, domain.WebhookEventPending, lastError, *retryAt, id)
+	var pqErr *pq.Error
+	if appErr != nil && errors.As(appErr.OriginalError(), &pqErr) && pqErr.Code == "23505" {
+		query := `
+			UPDATE webhook_events
+			SET status = $1, last_error = $2, updated_at = NOW()
+			WHERE id = $3
+		`
+		return r.exec(ctx, query, domain.WebhookEventDuplicate, lastError, id)
+	}
+	return appErr
+}
+
+// Replay queues a finished event again. It fails with a conflict when the event,
+// or another event for the same resource, is already queued.
+func (r *repository) Replay(ctx context.Context, id string) (*domain.WebhookEvent, apperrors.ApplicationError) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.WebhookEventNotFoundError, err)
+	}
+
+	query := `
+		UPDATE webhook_events
+		SET status = $1, attempts = 0, next_attempt_at = NOW(), processed_at = NULL, updated_at = NOW()
+		WHERE id = $2 AND status NOT IN ($1, $3)
+		RETURNING ` + webhookEventColumns
+
+	event, err := scanWebhookEvent(r.db.QueryRowContext(ctx, query, domain.WebhookEventPending, id, domain.WebhookEventProcessing))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			if _, appErr := r.GetByID(ctx, id); appErr != nil {
+				return nil, appErr
+			}
+			return nil, apperrors.NewApplicationError(mappings.WebhookEventReplayNotAllowedError, err)
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, apperrors.NewApplicationError(mappings.WebhookEventReplayNotAllowedError, err)
+		}
+		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+
+	return event, nil
+}
+
+// exec runs an update on a single event
+func (r *repository) exec(ctx context.Context, query string, args ...any) apperrors.ApplicationError {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.NewApplicationError(mappings.WebhookEventNotFoundError, errors.New("webhook event not found"))
+	}
+
+	return nil
+}
+
+// list runs a query returning webhookEventColumns and scans every row
+func (r *repository) list(ctx context.Context, query string, args ...any) ([]*domain.WebhookEvent, apperrors.ApplicationError) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+	defer rows.Close()
+
+	var events []*domain.WebhookEvent
+	for rows.Next() {
+		event, err := scanWebhookEvent(rows)
+		if err != nil {
+			return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.InternalServerError, err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+)
+
+// Exclusive wraps a job so that only one API replica runs it at a time. Each run
+// tries a Postgres session advisory lock keyed by the job name; a replica that does
+// not get the lock skips the run.
+func Exclusive(db *sql.DB, job Job) Job {
+	run := job.Run
+	job.Run = func(ctx context.Context) error {
+		// Session locks belong to a connection, so lock and unlock on the same one
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		var acquired bool
+		if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, job.Name).Scan(&acquired); err != nil {
+			return err
+		}
+		if !acquired {
+			return nil
+		}
+		defer func() {
+			// Unlock even if ctx was cancelled mid-run
+			if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, job.Name); err != nil {
+				log.Printf("Warning: failed to release lock of job %s: %v", job.Name, err)
+			}
+		}()
+
+		return run(ctx)
+	}
+	return job
+}
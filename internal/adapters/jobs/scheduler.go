@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job is a unit of background work that runs on a fixed interval
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Start runs every job on its own ticker until ctx is cancelled.
+// A run that is still in progress when the next tick fires delays it rather than overlapping.
+func Start(ctx context.Context, jobs ...Job) {
+	for _, job := range jobs {
+		if job.Interval <= 0 {
+			log.Printf("Warning: job %s has no interval, not scheduling it", job.Name)
+			continue
+		}
+		go run(ctx, job)
+	}
+}
+
+func run(ctx context.Context, job Job) {
+	log.Printf("Scheduling job %s every %s", job.Name, job.Interval)
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job.Run(ctx); err != nil {
+				log.Printf("Warning: job %s failed: %v", job.Name, err)
+			}
+		}
+	}
+}
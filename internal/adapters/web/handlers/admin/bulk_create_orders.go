@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+// BulkMaxRequestSize caps the bulk upload request, leaving room for the multipart envelope
+// on top of the file itself
+const BulkMaxRequestSize = orderUsecase.BulkMaxFileSize + 1<<20
+
+// NewBulkCreateOrdersHandler creates a handler for creating orders with claim links from an
+// Excel or CSV upload. It responds with the claim links sheet, or with JSON when ?format=json.
+func NewBulkCreateOrdersHandler(usecase orderUsecase.BulkCreateWithLinkUsecase, frontendURL string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, BulkMaxRequestSize)
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			appErr := apperrors.NewApplicationError(mappings.OrderBulkFileParseError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		if fileHeader.Size > orderUsecase.BulkMaxFileSize {
+			appErr := apperrors.NewApplicationError(mappings.OrderBulkTooLargeError, fmt.Errorf("file is %d bytes", fileHeader.Size))
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			appErr := apperrors.NewApplicationError(mappings.OrderBulkFileParseError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+		defer file.Close()
+
+		output, appErr := usecase.Execute(c, orderUsecase.BulkCreateWithLinkInput{
+			File:     file,
+			Filename: fileHeader.Filename,
+		}, frontendURL)
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		if c.Query("format") == "json" {
+			c.JSON(http.StatusCreated, output)
+			return
+		}
+
+		filename := "links-" + time.Now().Format("2006-01-02") + ".xlsx"
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		c.Data(http.StatusCreated, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", output.Sheet)
+	}
+}
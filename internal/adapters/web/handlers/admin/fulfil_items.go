@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+type FulfilItemsInput struct {
+	Items []orderUsecase.ItemFulfilmentInput `json:"items" binding:"required"`
+}
+
+// NewFulfilItemsHandler creates a handler for marking items picked, substituted or unavailable while preparing an order
+func NewFulfilItemsHandler(usecase orderUsecase.FulfilItemsUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input FulfilItemsInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		expectedVersion, err := middlewares.GetIfMatchVersion(c)
+		if err != nil {
+			appErr := apperrors.NewApplicationError(mappings.OrderInvalidIfMatchError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		userID, _ := middlewares.GetUserIDFromContext(c)
+
+		output, appErr := usecase.Execute(c, orderUsecase.FulfilItemsInput{
+			OrderID:         c.Param("id"),
+			UserID:          userID,
+			Items:           input.Items,
+			ExpectedVersion: expectedVersion,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			if output != nil {
+				// Version conflict: answer with the current state so the client can retry
+				middlewares.SetETag(c, output.Data.Version)
+				c.JSON(appErr.StatusCode(), gin.H{"code": appErr.Code(), "message": appErr.Message(), "current": output.Data})
+				return
+			}
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		middlewares.SetETag(c, output.Data.Version)
+		c.JSON(http.StatusOK, output)
+	}
+}
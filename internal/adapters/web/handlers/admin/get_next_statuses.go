@@ -0,0 +1,24 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	adminUsecase "yego/internal/usecases/admin"
+)
+
+// NewGetNextStatusesHandler creates a handler for listing the statuses an order can move to
+func NewGetNextStatusesHandler(usecase adminUsecase.GetNextStatusesUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		output, appErr := usecase.Execute(c, id)
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
@@ -0,0 +1,44 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+// NewGetOrderHandler creates a handler for getting any order with its full data
+func NewGetOrderHandler(usecase orderUsecase.GetUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, orderUsecase.GetInput{
+			OrderID: c.Param("id"),
+			AsAdmin: true,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		middlewares.SetETag(c, output.Data.Version)
+		c.JSON(http.StatusOK, output)
+	}
+}
+
+// NewCreateOrderTrackingLinkHandler creates a handler for getting a public tracking link to any order
+func NewCreateOrderTrackingLinkHandler(usecase orderUsecase.CreateTrackingLinkUsecase, frontendURL string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, orderUsecase.CreateTrackingLinkInput{
+			OrderID: c.Param("id"),
+			AsAdmin: true,
+		}, frontendURL)
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, output)
+	}
+}
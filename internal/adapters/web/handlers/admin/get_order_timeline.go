@@ -0,0 +1,25 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+// NewGetOrderTimelineHandler creates a handler for getting the full status timeline of any order
+func NewGetOrderTimelineHandler(usecase orderUsecase.GetTimelineUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, orderUsecase.GetTimelineInput{
+			OrderID: c.Param("id"),
+			AsAdmin: true,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
@@ -0,0 +1,22 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	adminUsecase "yego/internal/usecases/admin"
+)
+
+// NewGetTransactionHandler creates a handler for inspecting a transaction and its status history
+func NewGetTransactionHandler(usecase adminUsecase.GetTransactionUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, c.Param("id"))
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
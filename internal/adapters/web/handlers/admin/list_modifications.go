@@ -0,0 +1,27 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+// NewListModificationsHandler creates a handler for listing modification requests.
+// Under /orders/:id it lists the requests of that order; otherwise the queue filtered by ?status= (default PENDING).
+func NewListModificationsHandler(usecase orderUsecase.ListModificationsUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, orderUsecase.ListModificationsInput{
+			OrderID: c.Param("id"),
+			Status:  c.Query("status"),
+			AsAdmin: true,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
@@ -2,15 +2,43 @@ package admin
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	adminUsecase "yego/internal/usecases/admin"
 )
 
-// NewListOrdersHandler creates a handler for listing all orders
+// NewListOrdersHandler creates a handler for listing orders.
+// Query params: status (repeatable or comma-separated), from, to, profile_id, user_id,
+// phone, min_amount, max_amount, q, flagged (true for orders with a payment flag), sort, cursor and limit.
 func NewListOrdersHandler(usecase adminUsecase.ListOrdersUsecase) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		output, appErr := usecase.Execute(c)
+		var statuses []string
+		for _, s := range c.QueryArray("status") {
+			statuses = append(statuses, strings.Split(s, ",")...)
+		}
+
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+		if err != nil || limit < 1 {
+			limit = 50
+		}
+
+		output, appErr := usecase.Execute(c, adminUsecase.ListOrdersInput{
+			Statuses:  statuses,
+			From:      c.Query("from"),
+			To:        c.Query("to"),
+			ProfileID: c.Query("profile_id"),
+			UserID:    c.Query("user_id"),
+			Phone:     c.Query("phone"),
+			MinAmount: c.Query("min_amount"),
+			MaxAmount: c.Query("max_amount"),
+			Search:    c.Query("q"),
+			Flagged:   c.Query("flagged") == "true",
+			Sort:      c.Query("sort"),
+			Cursor:    c.Query("cursor"),
+			Limit:     limit,
+		})
 		if appErr != nil {
 			appErr.Log(c)
 			c.JSON(appErr.StatusCode(), appErr)
@@ -0,0 +1,24 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	adminUsecase "yego/internal/usecases/admin"
+)
+
+// NewListSlotBookingsHandler creates a handler for the per-slot view of booked orders on ?date= (default today)
+func NewListSlotBookingsHandler(usecase adminUsecase.ListSlotBookingsUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, adminUsecase.ListSlotBookingsInput{
+			Date: c.Query("date"),
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
@@ -0,0 +1,66 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+type PostOrderCommentInput struct {
+	Body          string `json:"body"`
+	AttachmentKey string `json:"attachment_key"`
+	// Internal keeps the comment between managers
+	Internal bool `json:"internal"`
+}
+
+// NewPostOrderCommentHandler creates a handler for a manager replying to a customer or adding an internal note
+func NewPostOrderCommentHandler(usecase orderUsecase.PostCommentUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input PostOrderCommentInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		userID, _ := middlewares.GetUserIDFromContext(c)
+
+		output, appErr := usecase.Execute(c, orderUsecase.PostCommentInput{
+			OrderID:       c.Param("id"),
+			UserID:        userID,
+			Body:          input.Body,
+			AttachmentKey: input.AttachmentKey,
+			AsAdmin:       true,
+			Internal:      input.Internal,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, output)
+	}
+}
+
+// NewListOrderCommentsHandler creates a handler for listing every comment and internal note of an order
+func NewListOrderCommentsHandler(usecase orderUsecase.ListCommentsUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, orderUsecase.ListCommentsInput{
+			OrderID: c.Param("id"),
+			AsAdmin: true,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+// NewGetOrderReceiptHandler creates a handler for downloading the PDF receipt of any order
+func NewGetOrderReceiptHandler(usecase orderUsecase.GetReceiptUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, orderUsecase.GetReceiptInput{
+			OrderID: c.Param("id"),
+			AsAdmin: true,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		if output.URL != nil {
+			c.Header("X-Receipt-URL", *output.URL)
+		}
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, output.Filename))
+		c.Data(http.StatusOK, "application/pdf", output.Content)
+	}
+}
@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+type RefundOrderInput struct {
+	// Amount to refund; omitted refunds everything left of the captured payments
+	Amount *float64 `json:"amount"`
+	Reason string   `json:"reason" binding:"required"`
+}
+
+// NewRefundOrderHandler creates a handler for a manager giving money back on an order
+func NewRefundOrderHandler(usecase orderUsecase.RefundUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input RefundOrderInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		userID, _ := middlewares.GetUserIDFromContext(c)
+
+		output, appErr := usecase.Execute(c, orderUsecase.RefundInput{
+			OrderID: c.Param("id"),
+			UserID:  userID,
+			Amount:  input.Amount,
+			Reason:  input.Reason,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, output)
+	}
+}
+
+// NewListOrderRefundsHandler creates a handler for listing the refunds of any order
+func NewListOrderRefundsHandler(usecase orderUsecase.ListRefundsUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, orderUsecase.ListRefundsInput{
+			OrderID: c.Param("id"),
+			AsAdmin: true,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
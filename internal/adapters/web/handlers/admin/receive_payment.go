@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+type ReceivePaymentInput struct {
+	// Amount received; omitted takes the order total
+	Amount *float64 `json:"amount"`
+	// Key of the transfer proof returned by /api/admin/uploads/presign; required for transfers
+	ProofKey string `json:"proof_key"`
+}
+
+// NewReceivePaymentHandler creates a handler for a courier or manager confirming a cash or transfer payment
+func NewReceivePaymentHandler(usecase orderUsecase.ReceivePaymentUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input ReceivePaymentInput
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&input); err != nil {
+				appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+				appErr.Log(c)
+				c.JSON(appErr.StatusCode(), appErr)
+				return
+			}
+		}
+
+		userID, _ := middlewares.GetUserIDFromContext(c)
+
+		output, appErr := usecase.Execute(c, orderUsecase.ReceivePaymentInput{
+			OrderID:  c.Param("id"),
+			UserID:   userID,
+			Amount:   input.Amount,
+			ProofKey: input.ProofKey,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, output)
+	}
+}
@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+// NewReconcilePaymentsHandler creates a handler that compares recent orders with the payment gateway.
+// Query params: lookback (a duration such as 24h, default 72h) and fix=true to apply the safe fixes.
+func NewReconcilePaymentsHandler(usecase orderUsecase.ReconcilePaymentsUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var lookback time.Duration
+		if raw := c.Query("lookback"); raw != "" {
+			parsed, err := time.ParseDuration(raw)
+			if err != nil {
+				appErr := apperrors.NewApplicationError(mappings.PaymentReconciliationInvalidInputError, err)
+				appErr.Log(c)
+				c.JSON(appErr.StatusCode(), appErr)
+				return
+			}
+			lookback = parsed
+		}
+
+		output, appErr := usecase.Execute(c, orderUsecase.ReconcilePaymentsInput{
+			Now:      time.Now(),
+			Lookback: lookback,
+			AutoFix:  c.Query("fix") == "true",
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
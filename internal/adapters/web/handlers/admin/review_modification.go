@@ -0,0 +1,47 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+type ReviewModificationInput struct {
+	Note string `json:"note"`
+}
+
+// NewReviewModificationHandler creates a handler that approves or rejects a modification request
+func NewReviewModificationHandler(usecase orderUsecase.ReviewModificationUsecase, approve bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input ReviewModificationInput
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&input); err != nil {
+				appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+				appErr.Log(c)
+				c.JSON(appErr.StatusCode(), appErr)
+				return
+			}
+		}
+
+		userID, _ := middlewares.GetUserIDFromContext(c)
+
+		output, appErr := usecase.Execute(c, orderUsecase.ReviewModificationInput{
+			RequestID:  c.Param("id"),
+			UserID:     userID,
+			Approve:    approve,
+			ReviewNote: input.Note,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		middlewares.SetETag(c, output.Order.Version)
+		c.JSON(http.StatusOK, output)
+	}
+}
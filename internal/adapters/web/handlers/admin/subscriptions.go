@@ -0,0 +1,58 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	orderUsecase "yego/internal/usecases/order"
+	subscriptionUsecase "yego/internal/usecases/subscription"
+)
+
+// NewListSubscriptionsHandler creates a handler for listing every subscription, optionally filtered by ?status=
+func NewListSubscriptionsHandler(usecase subscriptionUsecase.ListUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, subscriptionUsecase.ListInput{
+			AsAdmin: true,
+			Status:  c.Query("status"),
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
+
+// NewGetSubscriptionHandler creates a handler for inspecting any subscription and its runs
+func NewGetSubscriptionHandler(usecase subscriptionUsecase.GetUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, subscriptionUsecase.GetInput{
+			SubscriptionID: c.Param("id"),
+			AsAdmin:        true,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
+
+// NewRunDueSubscriptionsHandler creates a handler that places the orders of every due subscription now
+func NewRunDueSubscriptionsHandler(usecase orderUsecase.RunDueSubscriptionsUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, time.Now())
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
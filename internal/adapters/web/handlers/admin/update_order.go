@@ -3,8 +3,10 @@ package admin
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
 	"yego/internal/domain"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
@@ -12,10 +14,13 @@ import (
 )
 
 type UpdateOrderInput struct {
-	Status        *string           `json:"status,omitempty"`
-	StatusMessage *string           `json:"status_message,omitempty"`
-	ETA           *string           `json:"eta,omitempty"`
-	Data          *domain.OrderData `json:"data,omitempty"`
+	Status        *string `json:"status,omitempty"`
+	StatusMessage *string `json:"status_message,omitempty"`
+	ETA           *string `json:"eta,omitempty"`
+	// EstimatedDeliveryAt and EstimatedDeliveryWindowEnd are RFC 3339 timestamps
+	EstimatedDeliveryAt        *time.Time        `json:"estimated_delivery_at,omitempty"`
+	EstimatedDeliveryWindowEnd *time.Time        `json:"estimated_delivery_window_end,omitempty"`
+	Data                       *domain.OrderData `json:"data,omitempty"`
 }
 
 // NewUpdateOrderHandler creates a handler for updating an order
@@ -37,19 +42,41 @@ func NewUpdateOrderHandler(usecase adminUsecase.UpdateOrderUsecase) gin.HandlerF
 			token = strings.TrimPrefix(authHeader, "Bearer ")
 		}
 
+		expectedVersion, err := middlewares.GetIfMatchVersion(c)
+		if err != nil {
+			appErr := apperrors.NewApplicationError(mappings.OrderInvalidIfMatchError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		userID, _ := middlewares.GetUserIDFromContext(c)
+
 		output, appErr := usecase.Execute(c, id, adminUsecase.UpdateOrderInput{
 			Status:        input.Status,
 			StatusMessage: input.StatusMessage,
 			ETA:           input.ETA,
-			Data:          input.Data,
-			Token:         token,
+
+			EstimatedDeliveryAt:        input.EstimatedDeliveryAt,
+			EstimatedDeliveryWindowEnd: input.EstimatedDeliveryWindowEnd,
+			Data:                       input.Data,
+			Token:                      token,
+			UserID:                     userID,
+			ExpectedVersion:            expectedVersion,
 		})
 		if appErr != nil {
 			appErr.Log(c)
+			if output != nil {
+				// Version conflict: answer with the current state so the client can retry
+				middlewares.SetETag(c, output.Version)
+				c.JSON(appErr.StatusCode(), gin.H{"code": appErr.Code(), "message": appErr.Message(), "current": output})
+				return
+			}
 			c.JSON(appErr.StatusCode(), appErr)
 			return
 		}
 
+		middlewares.SetETag(c, output.Version)
 		c.JSON(http.StatusOK, output)
 	}
 }
@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	webhookUsecase "yego/internal/usecases/webhook"
+)
+
+// NewListWebhookEventsHandler creates a handler for listing received webhook events.
+// Query params: status, topic, resource_id and limit (default 50, max 200).
+func NewListWebhookEventsHandler(usecase webhookUsecase.ListUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, _ := strconv.Atoi(c.Query("limit"))
+
+		output, appErr := usecase.Execute(c, webhookUsecase.ListInput{
+			Status:     c.Query("status"),
+			Topic:      c.Query("topic"),
+			ResourceID: c.Query("resource_id"),
+			Limit:      limit,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
+
+// NewGetWebhookEventHandler creates a handler for inspecting a webhook event and its payload
+func NewGetWebhookEventHandler(usecase webhookUsecase.GetUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, c.Param("id"))
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
+
+// NewReplayWebhookEventHandler creates a handler that queues a webhook event to be processed again
+func NewReplayWebhookEventHandler(usecase webhookUsecase.ReplayUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, c.Param("id"))
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, output)
+	}
+}
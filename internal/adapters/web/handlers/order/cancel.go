@@ -0,0 +1,67 @@
+package order
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+type CancelInput struct {
+	Reason string `json:"reason" binding:"required"`
+	Note   string `json:"note"`
+}
+
+// NewCancelHandler creates a handler for a customer cancelling their own order
+func NewCancelHandler(usecase orderUsecase.CancelUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middlewares.GetUserIDFromContext(c)
+		if !exists {
+			appErr := apperrors.NewApplicationError(mappings.UnauthorizedError, nil)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		var input CancelInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		expectedVersion, err := middlewares.GetIfMatchVersion(c)
+		if err != nil {
+			appErr := apperrors.NewApplicationError(mappings.OrderInvalidIfMatchError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, orderUsecase.CancelInput{
+			OrderID:         c.Param("id"),
+			UserID:          userID,
+			Reason:          input.Reason,
+			Note:            input.Note,
+			ExpectedVersion: expectedVersion,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			if output != nil {
+				// Version conflict: answer with the current state so the client can retry
+				middlewares.SetETag(c, output.Data.Version)
+				c.JSON(appErr.StatusCode(), gin.H{"code": appErr.Code(), "message": appErr.Message(), "current": output.Data})
+				return
+			}
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		middlewares.SetETag(c, output.Data.Version)
+		c.JSON(http.StatusOK, output)
+	}
+}
@@ -10,6 +10,14 @@ import (
 	orderUsecase "yego/internal/usecases/order"
 )
 
+type ClaimInput struct {
+	// Optional scheduled delivery: a slot from /api/settings/delivery-slots/availability and its day (YYYY-MM-DD)
+	DeliverySlotID string `json:"delivery_slot_id"`
+	DeliveryDate   string `json:"delivery_date"`
+	// Optional payment method: CARD, CHECKOUT_LINK, CASH or TRANSFER
+	PaymentMethod string `json:"payment_method"`
+}
+
 // NewClaimHandler creates a handler for claiming orders via token
 // This endpoint requires authentication - user_id comes from JWT context
 func NewClaimHandler(usecase orderUsecase.ClaimUsecase) gin.HandlerFunc {
@@ -30,9 +38,22 @@ func NewClaimHandler(usecase orderUsecase.ClaimUsecase) gin.HandlerFunc {
 			return
 		}
 
+		var input ClaimInput
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&input); err != nil {
+				appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+				appErr.Log(c)
+				c.JSON(appErr.StatusCode(), appErr)
+				return
+			}
+		}
+
 		output, appErr := usecase.Execute(c, orderUsecase.ClaimInput{
-			Token:  token,
-			UserID: userID,
+			Token:          token,
+			UserID:         userID,
+			DeliverySlotID: input.DeliverySlotID,
+			DeliveryDate:   input.DeliveryDate,
+			PaymentMethod:  input.PaymentMethod,
 		})
 		if appErr != nil {
 			appErr.Log(c)
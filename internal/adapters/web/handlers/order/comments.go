@@ -0,0 +1,115 @@
+package order
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+type PostCommentInput struct {
+	Body          string `json:"body"`
+	AttachmentKey string `json:"attachment_key"`
+}
+
+// NewPostCommentHandler creates a handler for a customer posting a message on their order
+func NewPostCommentHandler(usecase orderUsecase.PostCommentUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middlewares.GetUserIDFromContext(c)
+		if !exists {
+			appErr := apperrors.NewApplicationError(mappings.UnauthorizedError, nil)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		var input PostCommentInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, orderUsecase.PostCommentInput{
+			OrderID:       c.Param("id"),
+			UserID:        userID,
+			Body:          input.Body,
+			AttachmentKey: input.AttachmentKey,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, output)
+	}
+}
+
+type PresignCommentAttachmentInput struct {
+	Filename string `json:"filename" binding:"required"`
+}
+
+// NewPresignCommentAttachmentHandler creates a handler for a customer presigning the upload
+// of an attachment for a message on their order
+func NewPresignCommentAttachmentHandler(usecase orderUsecase.PresignCommentAttachmentUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middlewares.GetUserIDFromContext(c)
+		if !exists {
+			appErr := apperrors.NewApplicationError(mappings.UnauthorizedError, nil)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		var input PresignCommentAttachmentInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, orderUsecase.PresignCommentAttachmentInput{
+			OrderID:  c.Param("id"),
+			UserID:   userID,
+			Filename: input.Filename,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
+
+// NewListCommentsHandler creates a handler for listing the messages on the user's order
+func NewListCommentsHandler(usecase orderUsecase.ListCommentsUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middlewares.GetUserIDFromContext(c)
+		if !exists {
+			appErr := apperrors.NewApplicationError(mappings.UnauthorizedError, nil)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, orderUsecase.ListCommentsInput{
+			OrderID: c.Param("id"),
+			UserID:  userID,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
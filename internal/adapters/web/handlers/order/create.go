@@ -14,6 +14,13 @@ type CreateInput struct {
 	ProfileID    string `json:"profile_id" binding:"required"`
 	ETA          string `json:"eta"`
 	SecurityCode string `json:"security_code"`
+	// Optional scheduled delivery: a slot from /api/settings/delivery-slots/availability and its day (YYYY-MM-DD)
+	DeliverySlotID string `json:"delivery_slot_id"`
+	DeliveryDate   string `json:"delivery_date"`
+	// Optional payment provider; omitted uses the default one
+	PaymentProvider string `json:"payment_provider"`
+	// Optional payment method: CARD, CHECKOUT_LINK, CASH or TRANSFER
+	PaymentMethod string `json:"payment_method"`
 }
 
 // NewCreateHandler creates a handler for creating orders
@@ -39,6 +46,12 @@ func NewCreateHandler(usecase orderUsecase.CreateUsecase) gin.HandlerFunc {
 			ETA:          input.ETA,
 			SecurityCode: input.SecurityCode,
 			Token:        token,
+
+			DeliverySlotID: input.DeliverySlotID,
+			DeliveryDate:   input.DeliveryDate,
+
+			PaymentProvider: input.PaymentProvider,
+			PaymentMethod:   input.PaymentMethod,
 		})
 		if appErr != nil {
 			appErr.Log(c)
@@ -11,7 +11,7 @@ import (
 	orderUsecase "yego/internal/usecases/order"
 )
 
-// NewCreatePaymentLinkHandler creates a handler for generating a MercadoPago Checkout Pro payment link
+// NewCreatePaymentLinkHandler creates a handler for generating a checkout payment link with the order's payment provider
 func NewCreatePaymentLinkHandler(usecase orderUsecase.CreatePaymentLinkUsecase, frontendURL string, backendURL string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		orderID := c.Param("id")
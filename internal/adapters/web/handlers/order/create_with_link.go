@@ -23,9 +23,11 @@ type CreateWithLinkDataInput struct {
 }
 
 type CreateWithLinkInput struct {
-	PhoneNumber string                   `json:"phone_number"`
-	ETA         string                   `json:"eta"`
-	Data        *CreateWithLinkDataInput `json:"data,omitempty"`
+	PhoneNumber     string                   `json:"phone_number"`
+	ETA             string                   `json:"eta"`
+	Data            *CreateWithLinkDataInput `json:"data,omitempty"`
+	PaymentProvider string                   `json:"payment_provider,omitempty"`
+	PaymentMethod   string                   `json:"payment_method,omitempty"`
 }
 
 // NewCreateWithLinkHandler creates a handler for creating orders with claim links
@@ -42,8 +44,10 @@ func NewCreateWithLinkHandler(usecase orderUsecase.CreateWithLinkUsecase, fronte
 
 		// Map handler input to usecase input
 		usecaseInput := orderUsecase.CreateWithLinkInput{
-			PhoneNumber: input.PhoneNumber,
-			ETA:         input.ETA,
+			PhoneNumber:     input.PhoneNumber,
+			ETA:             input.ETA,
+			PaymentProvider: input.PaymentProvider,
+			PaymentMethod:   input.PaymentMethod,
 		}
 
 		if input.Data != nil && len(input.Data.Items) > 0 {
@@ -4,21 +4,34 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
 	orderUsecase "yego/internal/usecases/order"
 )
 
-// NewGetHandler creates a handler for getting orders
+// NewGetHandler creates a handler for getting one of the user's orders
 func NewGetHandler(usecase orderUsecase.GetUsecase) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id := c.Param("id")
+		userID, exists := middlewares.GetUserIDFromContext(c)
+		if !exists {
+			appErr := apperrors.NewApplicationError(mappings.UnauthorizedError, nil)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
 
-		output, appErr := usecase.Execute(c, id)
+		output, appErr := usecase.Execute(c, orderUsecase.GetInput{
+			OrderID: c.Param("id"),
+			UserID:  userID,
+		})
 		if appErr != nil {
 			appErr.Log(c)
 			c.JSON(appErr.StatusCode(), appErr)
 			return
 		}
 
+		middlewares.SetETag(c, output.Data.Version)
 		c.JSON(http.StatusOK, output)
 	}
 }
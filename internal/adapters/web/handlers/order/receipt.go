@@ -0,0 +1,46 @@
+package order
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
+)
+
+// NewGetReceiptHandler creates a handler for downloading the PDF receipt of the user's order
+func NewGetReceiptHandler(usecase orderUsecase.GetReceiptUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := middlewares.GetUserIDFromContext(c)
+		if !exists {
+			appErr := apperrors.NewApplicationError(mappings.UnauthorizedError, nil)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, orderUsecase.GetReceiptInput{
+			OrderID: c.Param("id"),
+			UserID:  userID,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		writeReceipt(c, output)
+	}
+}
+
+// writeReceipt sends a rendered receipt as a PDF download
+func writeReceipt(c *gin.Context, output *orderUsecase.GetReceiptOutput) {
+	if output.URL != nil {
+		c.Header("X-Receipt-URL", *output.URL)
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, output.Filename))
+	c.Data(http.StatusOK, "application/pdf", output.Content)
+}
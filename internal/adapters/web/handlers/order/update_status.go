@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
 	orderUsecase "yego/internal/usecases/order"
@@ -32,16 +33,35 @@ func NewUpdateStatusHandler(usecase orderUsecase.UpdateStatusUsecase) gin.Handle
 			token = authHeader[7:]
 		}
 
+		expectedVersion, err := middlewares.GetIfMatchVersion(c)
+		if err != nil {
+			appErr := apperrors.NewApplicationError(mappings.OrderInvalidIfMatchError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		userID, _ := middlewares.GetUserIDFromContext(c)
+
 		output, appErr := usecase.Execute(c, id, orderUsecase.UpdateStatusInput{
-			Status: input.Status,
-			Token:  token,
+			Status:          input.Status,
+			Token:           token,
+			UserID:          userID,
+			ExpectedVersion: expectedVersion,
 		})
 		if appErr != nil {
 			appErr.Log(c)
+			if output != nil {
+				// Version conflict: answer with the current state so the client can retry
+				middlewares.SetETag(c, output.Data.Version)
+				c.JSON(appErr.StatusCode(), gin.H{"code": appErr.Code(), "message": appErr.Message(), "current": output.Data})
+				return
+			}
 			c.JSON(appErr.StatusCode(), appErr)
 			return
 		}
 
+		middlewares.SetETag(c, output.Data.Version)
 		c.JSON(http.StatusOK, output)
 	}
 }
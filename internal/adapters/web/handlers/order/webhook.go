@@ -1,52 +1,62 @@
 package order
 
 import (
+	"errors"
+	"io"
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	orderUsecase "yego/internal/usecases/order"
+	"yego/internal/adapters/web/integrations/payments"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	webhookUsecase "yego/internal/usecases/webhook"
 )
 
-// NewPaymentWebhookHandler handles MercadoPago payment notifications (IPN + Webhooks format)
-func NewPaymentWebhookHandler(usecase orderUsecase.HandlePaymentWebhookUsecase) gin.HandlerFunc {
+// NewPaymentWebhookHandler handles the payment notifications of the provider named in the path.
+// The provider verifies and parses the notification; accepted notifications are stored and
+// processed in the background. The handler only fails when the notification could not be
+// stored, so that the gateway sends it again.
+func NewPaymentWebhookHandler(usecase webhookUsecase.RecordUsecase, providers *payments.Registry) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// MP sends different formats:
-		// IPN v1:        ?id=123&topic=payment
-		// IPN v2/Webhooks: ?data.id=123&type=payment  OR JSON body
-		topic := c.Query("topic")
-		if topic == "" {
-			topic = c.Query("type")
-		}
-		paymentID := c.Query("id")
-		if paymentID == "" {
-			paymentID = c.Query("data.id")
+		provider, err := providers.Get(c.Param("provider"))
+		if err != nil {
+			appErr := apperrors.NewApplicationError(mappings.PaymentProviderNotFoundError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
 		}
 
-		// JSON body fallback: {"type": "payment", "data": {"id": "123"}}
-		if paymentID == "" || topic == "" {
-			var body struct {
-				Type string `json:"type"`
-				Data struct {
-					ID string `json:"id"`
-				} `json:"data"`
-			}
-			if err := c.ShouldBindJSON(&body); err == nil {
-				if topic == "" {
-					topic = body.Type
-				}
-				if paymentID == "" {
-					paymentID = body.Data.ID
-				}
-			}
-		}
+		rawBody, _ := io.ReadAll(c.Request.Body)
 
-		if (topic != "payment" && topic != "merchant_order") || paymentID == "" {
-			c.JSON(http.StatusOK, gin.H{"ok": true})
+		notification, err := provider.ParseWebhook(payments.WebhookRequest{
+			Query:  c.Request.URL.Query(),
+			Header: c.Request.Header,
+			Body:   rawBody,
+		})
+		if err != nil {
+			log.Printf("Webhook: rejected %s notification from %s: %v", provider.Name(), c.ClientIP(), err)
+			details := mappings.RequestBodyParsingError
+			if errors.Is(err, payments.ErrInvalidSignature) {
+				details = mappings.WebhookSignatureInvalidError
+			}
+			appErr := apperrors.NewApplicationError(details, err)
+			c.JSON(appErr.StatusCode(), appErr)
 			return
 		}
 
-		if appErr := usecase.Execute(c.Request.Context(), paymentID, topic); appErr != nil {
+		_, appErr := usecase.Execute(c.Request.Context(), webhookUsecase.RecordInput{
+			Provider:   provider.Name(),
+			Topic:      notification.Topic,
+			ResourceID: notification.ResourceID,
+			RequestID:  notification.RequestID,
+			Query:      c.Request.URL.RawQuery,
+			RawBody:    string(rawBody),
+		})
+		if appErr != nil {
 			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
 		}
 
 		c.JSON(http.StatusOK, gin.H{"ok": true})
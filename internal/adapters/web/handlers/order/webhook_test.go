@@ -0,0 +1,112 @@
+package order
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/integrations/payments"
+	"yego/internal/platform/config"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/services/mpwebhook"
+	webhookUsecase "yego/internal/usecases/webhook"
+)
+
+const testWebhookSecret = "test-webhook-secret"
+
+// recordUsecaseStub keeps the notifications the handler stored
+type recordUsecaseStub struct {
+	recorded []webhookUsecase.RecordInput
+}
+
+func (s *recordUsecaseStub) Execute(_ context.Context, input webhookUsecase.RecordInput) (*webhookUsecase.RecordOutput, apperrors.ApplicationError) {
+	s.recorded = append(s.recorded, input)
+	return &webhookUsecase.RecordOutput{ID: "event-1", Status: "PENDING"}, nil
+}
+
+func newWebhookRouter(usecase webhookUsecase.RecordUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	registry := payments.NewRegistry(payments.ProviderMercadoPago)
+	verifier := mpwebhook.NewVerifier(testWebhookSecret, 5*time.Minute)
+	registry.Register(payments.NewMercadoPagoProvider(nil, &config.ConfigurationService{}, verifier))
+
+	router := gin.New()
+	router.POST("/api/orders/webhook/:provider", NewPaymentWebhookHandler(usecase, registry))
+	return router
+}
+
+func newWebhookRequest(dataID string) *http.Request {
+	body := `{"type":"payment","data":{"id":"` + dataID + `"}}`
+	return httptest.NewRequest(http.MethodPost, "/api/orders/webhook/mercadopago?type=payment&data.id="+dataID, strings.NewReader(body))
+}
+
+func TestPaymentWebhookHandler_AcceptsSignedNotification(t *testing.T) {
+	usecase := &recordUsecaseStub{}
+	router := newWebhookRouter(usecase)
+
+	req := newWebhookRequest("123456")
+	mpwebhook.SignRequest(req, testWebhookSecret, "123456")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if len(usecase.recorded) != 1 {
+		t.Fatalf("recorded %d notifications, want 1", len(usecase.recorded))
+	}
+	if got := usecase.recorded[0]; got.Topic != "payment" || got.ResourceID != "123456" {
+		t.Errorf("recorded %s %q, want payment %q", got.Topic, got.ResourceID, "123456")
+	}
+}
+
+func TestPaymentWebhookHandler_RejectsInvalidSignatures(t *testing.T) {
+	tests := []struct {
+		name string
+		sign func(req *http.Request)
+	}{
+		{
+			name: "unsigned",
+			sign: func(*http.Request) {},
+		},
+		{
+			name: "wrong secret",
+			sign: func(req *http.Request) { mpwebhook.SignRequest(req, "other-secret", "123456") },
+		},
+		{
+			name: "other resource",
+			sign: func(req *http.Request) { mpwebhook.SignRequest(req, testWebhookSecret, "654321") },
+		},
+		{
+			name: "expired",
+			sign: func(req *http.Request) {
+				req.Header.Set(mpwebhook.RequestIDHeader, "request-1")
+				req.Header.Set(mpwebhook.SignatureHeader, mpwebhook.Sign(testWebhookSecret, "123456", "request-1", time.Now().Add(-time.Hour)))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := &recordUsecaseStub{}
+			router := newWebhookRouter(usecase)
+
+			req := newWebhookRequest("123456")
+			tt.sign(req)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusUnauthorized {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusUnauthorized, rec.Body.String())
+			}
+			if len(usecase.recorded) != 0 {
+				t.Errorf("recorded %d notifications, want none", len(usecase.recorded))
+			}
+		})
+	}
+}
@@ -41,7 +41,14 @@ func (h *Handler) CheckPaymentMethod(c *gin.Context) {
 		internalUserID = username
 	}
 
-	hasPaymentMethod, err := app.Integrations.Payments.HasPaymentMethod(internalUserID)
+	// ?provider= checks a specific payment provider, otherwise the default one
+	provider, err := app.Integrations.PaymentProviders.Get(c.Query("provider"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown payment provider"})
+		return
+	}
+
+	hasPaymentMethod, err := provider.HasPaymentMethod(internalUserID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check payment method"})
 		return
@@ -2,6 +2,7 @@ package settings
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	apperrors "yego/internal/platform/errors"
@@ -35,6 +36,11 @@ type UpdateInput struct {
 	DeliveryPricePerKm  *float64 `json:"delivery_price_per_km,omitempty"`
 	DeliveryPricePerKg  *float64 `json:"delivery_price_per_kg,omitempty"`
 	ManagerCollectorID  *string  `json:"manager_collector_id,omitempty"`
+
+	PreparationTimeMinutes *int     `json:"preparation_time_minutes,omitempty"`
+	QueueMinutesPerOrder   *int     `json:"queue_minutes_per_order,omitempty"`
+	DeliveryMinutesPerKm   *float64 `json:"delivery_minutes_per_km,omitempty"`
+	ETAWindowMinutes       *int     `json:"eta_window_minutes,omitempty"`
 }
 
 // NewUpdateHandler creates a handler for updating settings
@@ -60,6 +66,11 @@ func NewUpdateHandler(usecase settingsUsecase.UpdateUsecase) gin.HandlerFunc {
 			DeliveryPricePerKm: input.DeliveryPricePerKm,
 			DeliveryPricePerKg: input.DeliveryPricePerKg,
 			ManagerCollectorID: input.ManagerCollectorID,
+
+			PreparationTimeMinutes: input.PreparationTimeMinutes,
+			QueueMinutesPerOrder:   input.QueueMinutesPerOrder,
+			DeliveryMinutesPerKm:   input.DeliveryMinutesPerKm,
+			ETAWindowMinutes:       input.ETAWindowMinutes,
 		})
 		if appErr != nil {
 			appErr.Log(c)
@@ -120,3 +131,125 @@ func NewCalculateDeliveryFeeHandler(usecase settingsUsecase.CalculateDeliveryFee
 		c.JSON(http.StatusOK, output)
 	}
 }
+
+type EstimateDeliveryTimeInput struct {
+	UserLatitude  float64 `json:"user_latitude" binding:"required"`
+	UserLongitude float64 `json:"user_longitude" binding:"required"`
+}
+
+// NewEstimateDeliveryTimeHandler creates a handler for proposing a delivery time
+func NewEstimateDeliveryTimeHandler(usecase settingsUsecase.EstimateDeliveryTimeUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input EstimateDeliveryTimeInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, settingsUsecase.EstimateDeliveryTimeInput{
+			UserLatitude:  input.UserLatitude,
+			UserLongitude: input.UserLongitude,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
+
+// NewListDeliverySlotsHandler creates a handler for listing delivery slots.
+// Inactive slots are included with ?all=true.
+func NewListDeliverySlotsHandler(usecase settingsUsecase.ListDeliverySlotsUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		output, appErr := usecase.Execute(c, c.Query("all") != "true")
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
+
+// NewCreateDeliverySlotHandler creates a handler for adding a delivery slot
+func NewCreateDeliverySlotHandler(usecase settingsUsecase.CreateDeliverySlotUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input settingsUsecase.DeliverySlotInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, input)
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, output.Slot)
+	}
+}
+
+// NewUpdateDeliverySlotHandler creates a handler for changing a delivery slot
+func NewUpdateDeliverySlotHandler(usecase settingsUsecase.UpdateDeliverySlotUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input settingsUsecase.DeliverySlotInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, c.Param("id"), input)
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output.Slot)
+	}
+}
+
+// NewDeleteDeliverySlotHandler creates a handler for removing a delivery slot
+func NewDeleteDeliverySlotHandler(usecase settingsUsecase.DeleteDeliverySlotUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if appErr := usecase.Execute(c, c.Param("id")); appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusNoContent, nil)
+	}
+}
+
+// NewGetSlotAvailabilityHandler creates a handler for listing bookable slots.
+// Query params: from (YYYY-MM-DD, default today) and days (default 7).
+func NewGetSlotAvailabilityHandler(usecase settingsUsecase.GetSlotAvailabilityUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		days, _ := strconv.Atoi(c.Query("days"))
+
+		output, appErr := usecase.Execute(c, settingsUsecase.GetSlotAvailabilityInput{
+			From: c.Query("from"),
+			Days: days,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
@@ -0,0 +1,169 @@
+package subscription
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/adapters/web/middlewares"
+	"yego/internal/domain"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	subscriptionUsecase "yego/internal/usecases/subscription"
+)
+
+type CreateInput struct {
+	Items      []domain.OrderItem `json:"items" binding:"required"`
+	Frequency  string             `json:"frequency" binding:"required"`
+	AutoCharge bool               `json:"auto_charge"`
+	FirstRunAt *time.Time         `json:"first_run_at,omitempty"`
+}
+
+type UpdateInput struct {
+	Items      []domain.OrderItem `json:"items,omitempty"`
+	Frequency  *string            `json:"frequency,omitempty"`
+	AutoCharge *bool              `json:"auto_charge,omitempty"`
+	NextRunAt  *time.Time         `json:"next_run_at,omitempty"`
+}
+
+// requireUserID writes an unauthorized response when the request has no user
+func requireUserID(c *gin.Context) (string, bool) {
+	userID, exists := middlewares.GetUserIDFromContext(c)
+	if !exists {
+		appErr := apperrors.NewApplicationError(mappings.UnauthorizedError, nil)
+		appErr.Log(c)
+		c.JSON(appErr.StatusCode(), appErr)
+	}
+	return userID, exists
+}
+
+// NewCreateHandler creates a handler for subscribing to a recurring order
+func NewCreateHandler(usecase subscriptionUsecase.CreateUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := requireUserID(c)
+		if !ok {
+			return
+		}
+
+		var input CreateInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, subscriptionUsecase.CreateInput{
+			UserID:     userID,
+			Items:      input.Items,
+			Frequency:  input.Frequency,
+			AutoCharge: input.AutoCharge,
+			FirstRunAt: input.FirstRunAt,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusCreated, output)
+	}
+}
+
+// NewListHandler creates a handler for listing the user's subscriptions
+func NewListHandler(usecase subscriptionUsecase.ListUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := requireUserID(c)
+		if !ok {
+			return
+		}
+
+		output, appErr := usecase.Execute(c, subscriptionUsecase.ListInput{UserID: userID})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
+
+// NewGetHandler creates a handler for getting one of the user's subscriptions with its runs
+func NewGetHandler(usecase subscriptionUsecase.GetUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := requireUserID(c)
+		if !ok {
+			return
+		}
+
+		output, appErr := usecase.Execute(c, subscriptionUsecase.GetInput{
+			SubscriptionID: c.Param("id"),
+			UserID:         userID,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
+
+// NewUpdateHandler creates a handler for editing a subscription
+func NewUpdateHandler(usecase subscriptionUsecase.UpdateUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := requireUserID(c)
+		if !ok {
+			return
+		}
+
+		var input UpdateInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			appErr := apperrors.NewApplicationError(mappings.RequestBodyParsingError, err)
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		output, appErr := usecase.Execute(c, subscriptionUsecase.UpdateInput{
+			SubscriptionID: c.Param("id"),
+			UserID:         userID,
+			Items:          input.Items,
+			Frequency:      input.Frequency,
+			AutoCharge:     input.AutoCharge,
+			NextRunAt:      input.NextRunAt,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
+
+// NewSetStatusHandler creates a handler that pauses, resumes or cancels a subscription
+func NewSetStatusHandler(usecase subscriptionUsecase.SetStatusUsecase, status domain.SubscriptionStatus) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := requireUserID(c)
+		if !ok {
+			return
+		}
+
+		output, appErr := usecase.Execute(c, subscriptionUsecase.SetStatusInput{
+			SubscriptionID: c.Param("id"),
+			UserID:         userID,
+			Status:         status,
+		})
+		if appErr != nil {
+			appErr.Log(c)
+			c.JSON(appErr.StatusCode(), appErr)
+			return
+		}
+
+		c.JSON(http.StatusOK, output)
+	}
+}
@@ -55,6 +55,7 @@ func (h *Handler) HandleWebSocket(c *gin.Context) {
 		Conn:      conn,
 		Send:      make(chan []byte, 256),
 		IsManager: true,
+		UserID:    userID,
 	}
 
 	h.hub.Register <- client
@@ -1,22 +1,41 @@
 package integrations
 
 import (
+	"log"
+
 	"yego/internal/adapters/web/integrations/auth"
 	"yego/internal/adapters/web/integrations/payments"
 	"yego/internal/adapters/web/integrations/websocket"
 	"yego/internal/platform/config"
+	"yego/internal/services/mpwebhook"
 )
 
 type Integrations struct {
-	WebSocket websocket.Integration
-	Payments  payments.Integration
-	Auth      auth.Integration
+	WebSocket        websocket.Integration
+	Payments         payments.Integration
+	PaymentProviders *payments.Registry
+	Auth             auth.Integration
 }
 
-func CreateIntegration(cfg *config.ConfigurationService) *Integrations {
+// CreateIntegration creates every integration. The MercadoPago webhook verifier is optional.
+func CreateIntegration(cfg *config.ConfigurationService, mpWebhookVerifier *mpwebhook.Verifier) *Integrations {
+	paymentsIntegration := payments.NewIntegration(cfg)
+
+	providers := payments.NewRegistry(cfg.PaymentProvider)
+	// "mp" is the path of the webhook URL registered with MercadoPago
+	providers.Register(payments.NewMercadoPagoProvider(paymentsIntegration, cfg, mpWebhookVerifier), "mp")
+	if cfg.PaymentFakeProviderEnabled == "true" {
+		log.Printf("Warning: the fake payment provider is enabled, its payments are not real")
+		providers.Register(payments.NewFakeProvider())
+	}
+	if _, err := providers.Default(); err != nil {
+		log.Fatalf("Invalid PAYMENT_PROVIDER: %v", err)
+	}
+
 	return &Integrations{
-		WebSocket: websocket.NewIntegration(cfg),
-		Payments:  payments.NewIntegration(cfg),
-		Auth:      auth.NewIntegration(cfg),
+		WebSocket:        websocket.NewIntegration(cfg),
+		Payments:         paymentsIntegration,
+		PaymentProviders: providers,
+		Auth:             auth.NewIntegration(cfg),
 	}
 }
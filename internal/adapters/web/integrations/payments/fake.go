@@ -0,0 +1,148 @@
+package payments
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// ProviderFake is the name of the fake provider
+const ProviderFake = "fake"
+
+// FakeProvider is an in-memory gateway for local development and tests. Charges are
+// approved, checkout links create a pending payment, and SetPaymentStatus plays the
+// part of the customer or the gateway changing a payment afterwards.
+type FakeProvider struct {
+	mu       sync.Mutex
+	nextID   int
+	payments map[string]*Payment
+	// ChargeStatus is the status new charges get; empty approves them
+	ChargeStatus string
+}
+
+// NewFakeProvider creates an empty fake provider
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{payments: make(map[string]*Payment)}
+}
+
+func (p *FakeProvider) Name() string {
+	return ProviderFake
+}
+
+func (p *FakeProvider) HasPaymentMethod(userID string) (bool, error) {
+	return true, nil
+}
+
+func (p *FakeProvider) Charge(req ChargeRequest) (*ChargeResponse, error) {
+	status := p.ChargeStatus
+	if status == "" {
+		status = "approved"
+	}
+	payment := p.add(req.OrderID, status, req.Amount)
+	return &ChargeResponse{GatewayPaymentID: payment.ID, Status: payment.Status}, nil
+}
+
+func (p *FakeProvider) CreateCheckoutLink(req CheckoutRequest) (*CheckoutLink, error) {
+	var amount float64
+	for _, item := range req.Items {
+		amount += item.UnitPrice * float64(item.Quantity)
+	}
+	payment := p.add(req.OrderID, "pending", amount)
+	url := fmt.Sprintf("%s?fake_payment_id=%s", req.ReturnURL, payment.ID)
+	return &CheckoutLink{ID: payment.ID, URL: url, SandboxURL: url}, nil
+}
+
+func (p *FakeProvider) FetchPayment(paymentID string) (*Payment, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[paymentID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, paymentID)
+	}
+	copied := *payment
+	return &copied, nil
+}
+
+func (p *FakeProvider) SearchPayments(orderID string) ([]Payment, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var payments []Payment
+	for _, payment := range p.payments {
+		if payment.OrderID == orderID {
+			payments = append(payments, *payment)
+		}
+	}
+	return payments, nil
+}
+
+func (p *FakeProvider) Refund(paymentID string, amount float64, reason string) (*RefundResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[paymentID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, paymentID)
+	}
+	if amount >= payment.Amount {
+		payment.Status = "refunded"
+	}
+	p.nextID++
+	return &RefundResponse{ID: fmt.Sprintf("fake-refund-%d", p.nextID), Status: "approved", Amount: amount}, nil
+}
+
+// ParseWebhook accepts {"type": "payment", "data": {"id": "..."}} without a signature
+func (p *FakeProvider) ParseWebhook(req WebhookRequest) (*WebhookNotification, error) {
+	var body struct {
+		Type string `json:"type"`
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(req.Body, &body); err != nil {
+		return nil, err
+	}
+	return &WebhookNotification{Topic: body.Type, ResourceID: body.Data.ID}, nil
+}
+
+func (p *FakeProvider) FetchNotified(topic string, resourceID string) (string, []Payment, error) {
+	if topic != "payment" {
+		return "", nil, nil
+	}
+	payment, err := p.FetchPayment(resourceID)
+	if err != nil {
+		return "", nil, err
+	}
+	return payment.OrderID, []Payment{*payment}, nil
+}
+
+// SetPaymentStatus changes the status of a payment, as the gateway would
+func (p *FakeProvider) SetPaymentStatus(paymentID string, status string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[paymentID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, paymentID)
+	}
+	payment.Status = status
+	return nil
+}
+
+// add stores a new payment for an order
+func (p *FakeProvider) add(orderID string, status string, amount float64) *Payment {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.nextID++
+	payment := &Payment{
+		ID:      fmt.Sprintf("fake-%d", p.nextID),
+		OrderID: orderID,
+		Status:  status,
+		Amount:  amount,
+	}
+	p.payments[payment.ID] = payment
+	copied := *payment
+	return &copied
+}
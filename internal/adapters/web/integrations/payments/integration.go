@@ -15,6 +15,7 @@ type Integration interface {
 	GetDefaultPaymentMethod(userID string) (*PaymentMethod, error)
 	ProcessPaymentWithSavedMethod(userID string, amount float64, description string, externalReference string, payerEmail string, collectorID string, securityCode string) (*ProcessPaymentResponse, error)
 	CreatePreference(items []PreferenceItem, payerEmail string, externalReference string, backURLSuccess string, backURLFailure string, backURLPending string, notificationURL string) (*PreferenceResponse, error)
+	Refund(gatewayPaymentID string, amount float64, reason string) (*RefundResponse, error)
 }
 
 type ProcessPaymentResponse struct {
@@ -23,6 +24,12 @@ type ProcessPaymentResponse struct {
 	Status           string `json:"status"`
 }
 
+type RefundResponse struct {
+	ID     string  `json:"id"`
+	Status string  `json:"status"`
+	Amount float64 `json:"amount"`
+}
+
 type PreferenceItem struct {
 	Title      string  `json:"title"`
 	Quantity   int     `json:"quantity"`
@@ -272,3 +279,40 @@ func (i *integration) ProcessPaymentWithSavedMethod(userID string, amount float6
 
 	return &paymentResponse, nil
 }
+
+func (i *integration) Refund(gatewayPaymentID string, amount float64, reason string) (*RefundResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/payments/%s/refunds", i.baseURL, gatewayPaymentID)
+
+	payload := map[string]interface{}{
+		"amount": amount,
+		"reason": reason,
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := i.newRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("payment service error: %s", string(body))
+	}
+
+	var refundResponse RefundResponse
+	if err := json.NewDecoder(resp.Body).Decode(&refundResponse); err != nil {
+		return nil, err
+	}
+
+	return &refundResponse, nil
+}
@@ -0,0 +1,321 @@
+package payments
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"yego/internal/platform/config"
+	"yego/internal/services/mpwebhook"
+)
+
+// ProviderMercadoPago is the name of the MercadoPago provider
+const ProviderMercadoPago = "mercadopago"
+
+// mercadoPagoProvider charges saved cards, creates Checkout Pro preferences and refunds through
+// the payments API, and reads payments straight from the MercadoPago API
+type mercadoPagoProvider struct {
+	integration Integration
+	// accessToken is the token saved card payments are made with
+	accessToken string
+	// checkoutProToken is the token Checkout Pro preferences are created with; MercadoPago
+	// only shows a merchant order to the collector that created the preference
+	checkoutProToken string
+	verifier         *mpwebhook.Verifier
+	client           *http.Client
+}
+
+// NewMercadoPagoProvider creates the MercadoPago provider. Without a verifier,
+// webhook signatures are not checked.
+func NewMercadoPagoProvider(integration Integration, cfg *config.ConfigurationService, verifier *mpwebhook.Verifier) Provider {
+	checkoutProToken := cfg.MPCheckoutProAccessToken
+	if checkoutProToken == "" {
+		checkoutProToken = cfg.MPAccessToken
+	}
+
+	return &mercadoPagoProvider{
+		integration:      integration,
+		accessToken:      cfg.MPAccessToken,
+		checkoutProToken: checkoutProToken,
+		verifier:         verifier,
+		client:           &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (p *mercadoPagoProvider) Name() string {
+	return ProviderMercadoPago
+}
+
+func (p *mercadoPagoProvider) HasPaymentMethod(userID string) (bool, error) {
+	return p.integration.HasPaymentMethod(userID)
+}
+
+func (p *mercadoPagoProvider) Charge(req ChargeRequest) (*ChargeResponse, error) {
+	resp, err := p.integration.ProcessPaymentWithSavedMethod(
+		req.UserID,
+		req.Amount,
+		req.Description,
+		req.OrderID,
+		req.PayerEmail,
+		req.CollectorID,
+		req.SecurityCode,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentID := resp.PaymentID
+	return &ChargeResponse{
+		PaymentID:        &paymentID,
+		GatewayPaymentID: resp.GatewayPaymentID,
+		Status:           resp.Status,
+	}, nil
+}
+
+func (p *mercadoPagoProvider) CreateCheckoutLink(req CheckoutRequest) (*CheckoutLink, error) {
+	resp, err := p.integration.CreatePreference(
+		req.Items,
+		req.PayerEmail,
+		req.OrderID,
+		req.ReturnURL,
+		req.ReturnURL,
+		req.ReturnURL,
+		req.NotificationURL,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CheckoutLink{
+		ID:         resp.PreferenceID,
+		URL:        resp.InitPoint,
+		SandboxURL: resp.SandboxInitPoint,
+	}, nil
+}
+
+func (p *mercadoPagoProvider) Refund(paymentID string, amount float64, reason string) (*RefundResponse, error) {
+	return p.integration.Refund(paymentID, amount, reason)
+}
+
+// tokens returns the tokens payments may have been made with, saved card one first
+func (p *mercadoPagoProvider) tokens() []string {
+	var tokens []string
+	if p.accessToken != "" {
+		tokens = append(tokens, p.accessToken)
+	}
+	if p.checkoutProToken != "" && p.checkoutProToken != p.accessToken {
+		tokens = append(tokens, p.checkoutProToken)
+	}
+	return tokens
+}
+
+// FetchPayment looks the payment up with every token, since a payment is only visible to its collector
+func (p *mercadoPagoProvider) FetchPayment(paymentID string) (*Payment, error) {
+	tokens := p.tokens()
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: MP_ACCESS_TOKEN not set", ErrNotConfigured)
+	}
+
+	for _, token := range tokens {
+		body, err := p.get("/v1/payments/"+url.PathEscape(paymentID), token)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var payment mpPayment
+		if err := json.Unmarshal(body, &payment); err != nil {
+			return nil, err
+		}
+		log.Printf("MercadoPago: payment %s status=%s detail=%s external_reference=%s amount=%.2f",
+			paymentID, payment.Status, payment.StatusDetail, payment.ExternalReference, payment.TransactionAmount)
+		return payment.toPayment(), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, paymentID)
+}
+
+// SearchPayments searches the payments referencing the order with every token, newest first
+func (p *mercadoPagoProvider) SearchPayments(orderID string) ([]Payment, error) {
+	tokens := p.tokens()
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: MP_ACCESS_TOKEN not set", ErrNotConfigured)
+	}
+
+	query := url.Values{}
+	query.Set("external_reference", orderID)
+	query.Set("sort", "date_created")
+	query.Set("criteria", "desc")
+
+	seen := make(map[string]bool)
+	var payments []Payment
+	for _, token := range tokens {
+		body, err := p.get("/v1/payments/search?"+query.Encode(), token)
+		if err != nil {
+			return nil, err
+		}
+		var result struct {
+			Results []mpPayment `json:"results"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, err
+		}
+		for _, r := range result.Results {
+			payment := r.toPayment()
+			if !seen[payment.ID] {
+				seen[payment.ID] = true
+				payments = append(payments, *payment)
+			}
+		}
+	}
+
+	return payments, nil
+}
+
+// ParseWebhook reads both notification formats MercadoPago sends:
+// IPN (?id=123&topic=payment) and Webhooks (?data.id=123&type=payment or a JSON body).
+// MercadoPago only signs the Webhooks format, so IPN must be turned off for the
+// application when signatures are verified.
+func (p *mercadoPagoProvider) ParseWebhook(req WebhookRequest) (*WebhookNotification, error) {
+	topic := req.Query.Get("topic")
+	if topic == "" {
+		topic = req.Query.Get("type")
+	}
+	resourceID := req.Query.Get("id")
+	if resourceID == "" {
+		resourceID = req.Query.Get("data.id")
+	}
+
+	// JSON body fallback: {"type": "payment", "data": {"id": "123"}}
+	if resourceID == "" || topic == "" {
+		var body struct {
+			Type string `json:"type"`
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(req.Body, &body); err == nil {
+			if topic == "" {
+				topic = body.Type
+			}
+			if resourceID == "" {
+				resourceID = body.Data.ID
+			}
+		}
+	}
+
+	requestID := req.Header.Get(mpwebhook.RequestIDHeader)
+	if p.verifier != nil {
+		// The signed resource ID is the data.id query parameter when present
+		dataID := req.Query.Get("data.id")
+		if dataID == "" {
+			dataID = resourceID
+		}
+		if err := p.verifier.Verify(req.Header.Get(mpwebhook.SignatureHeader), requestID, dataID); err != nil {
+			return nil, fmt.Errorf("%w: %s notification for %q (request %q): %v", ErrInvalidSignature, topic, dataID, requestID, err)
+		}
+	}
+
+	return &WebhookNotification{Topic: topic, ResourceID: resourceID, RequestID: requestID}, nil
+}
+
+// FetchNotified resolves a payment or a Checkout Pro merchant order notification
+func (p *mercadoPagoProvider) FetchNotified(topic string, resourceID string) (string, []Payment, error) {
+	switch topic {
+	case "payment":
+		payment, err := p.FetchPayment(resourceID)
+		if err != nil {
+			return "", nil, err
+		}
+		return payment.OrderID, []Payment{*payment}, nil
+	case "merchant_order":
+		return p.fetchMerchantOrder(resourceID)
+	default:
+		return "", nil, nil
+	}
+}
+
+// fetchMerchantOrder fetches a Checkout Pro merchant order, its order reference and every payment made for it
+func (p *mercadoPagoProvider) fetchMerchantOrder(merchantOrderID string) (string, []Payment, error) {
+	if p.checkoutProToken == "" {
+		return "", nil, fmt.Errorf("%w: MP_ACCESS_TOKEN not set", ErrNotConfigured)
+	}
+
+	body, err := p.get("/merchant_orders/"+url.PathEscape(merchantOrderID), p.checkoutProToken)
+	if err != nil {
+		return "", nil, err
+	}
+	var mo struct {
+		Status            string `json:"status"`
+		ExternalReference string `json:"external_reference"`
+		Payments          []struct {
+			ID     int64   `json:"id"`
+			Status string  `json:"status"`
+			Amount float64 `json:"transaction_amount"`
+		} `json:"payments"`
+	}
+	if err := json.Unmarshal(body, &mo); err != nil {
+		return "", nil, err
+	}
+	log.Printf("MercadoPago: merchant_order %s status=%s external_reference=%s payments_count=%d", merchantOrderID, mo.Status, mo.ExternalReference, len(mo.Payments))
+
+	payments := make([]Payment, 0, len(mo.Payments))
+	for i, payment := range mo.Payments {
+		log.Printf("MercadoPago: merchant_order %s payment[%d] id=%d status=%s amount=%.2f", merchantOrderID, i, payment.ID, payment.Status, payment.Amount)
+		payments = append(payments, Payment{
+			ID:      fmt.Sprintf("%d", payment.ID),
+			OrderID: mo.ExternalReference,
+			Status:  payment.Status,
+			Amount:  payment.Amount,
+		})
+	}
+	return mo.ExternalReference, payments, nil
+}
+
+// get calls the MercadoPago API and returns the response body
+func (p *mercadoPagoProvider) get(path string, token string) ([]byte, error) {
+	req, err := http.NewRequest("GET", "https://api.mercadopago.com"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("MP API %d: %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
+
+// mpPayment is a payment as returned by the MercadoPago API
+type mpPayment struct {
+	ID                int64   `json:"id"`
+	Status            string  `json:"status"`
+	StatusDetail      string  `json:"status_detail"`
+	ExternalReference string  `json:"external_reference"`
+	TransactionAmount float64 `json:"transaction_amount"`
+}
+
+func (m mpPayment) toPayment() *Payment {
+	return &Payment{
+		ID:           fmt.Sprintf("%d", m.ID),
+		OrderID:      m.ExternalReference,
+		Status:       m.Status,
+		StatusDetail: m.StatusDetail,
+		Amount:       m.TransactionAmount,
+	}
+}
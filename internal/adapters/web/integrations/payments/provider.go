@@ -0,0 +1,157 @@
+package payments
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+var (
+	// ErrNotFound is returned when the gateway does not know the requested payment
+	ErrNotFound = errors.New("payment not found at the gateway")
+	// ErrNotConfigured is returned when the provider is missing its credentials
+	ErrNotConfigured = errors.New("payment provider not configured")
+	// ErrInvalidSignature is returned when a webhook notification fails verification
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrUnknownProvider is returned for a provider name nothing is registered under
+	ErrUnknownProvider = errors.New("unknown payment provider")
+)
+
+// Provider is a payment gateway orders can be paid through. Payments carry the order ID
+// as their reference, so the gateway can always tell which order a payment belongs to.
+type Provider interface {
+	// Name identifies the provider on orders, transactions and webhook events
+	Name() string
+	// HasPaymentMethod reports whether the user has a saved payment method to charge
+	HasPaymentMethod(userID string) (bool, error)
+	// Charge charges the user's saved payment method
+	Charge(req ChargeRequest) (*ChargeResponse, error)
+	// CreateCheckoutLink creates a hosted checkout page where the customer pays the order
+	CreateCheckoutLink(req CheckoutRequest) (*CheckoutLink, error)
+	// FetchPayment returns the current state of a payment, or ErrNotFound
+	FetchPayment(paymentID string) (*Payment, error)
+	// SearchPayments returns every payment made for an order
+	SearchPayments(orderID string) ([]Payment, error)
+	// Refund gives back part or all of a payment
+	Refund(paymentID string, amount float64, reason string) (*RefundResponse, error)
+	// ParseWebhook verifies a notification sent by the gateway and tells what it is about
+	ParseWebhook(req WebhookRequest) (*WebhookNotification, error)
+	// FetchNotified returns the order and the payments a notification refers to
+	FetchNotified(topic string, resourceID string) (string, []Payment, error)
+}
+
+// ChargeRequest represents a charge on a user's saved payment method
+type ChargeRequest struct {
+	UserID       string
+	OrderID      string
+	Amount       float64
+	Description  string
+	PayerEmail   string
+	CollectorID  string
+	SecurityCode string
+}
+
+// ChargeResponse represents the outcome of a charge
+type ChargeResponse struct {
+	// PaymentID is the ID of the payment at the payments API, when there is one
+	PaymentID        *int
+	GatewayPaymentID string
+	Status           string
+}
+
+// CheckoutRequest represents the order a checkout link is created for
+type CheckoutRequest struct {
+	OrderID         string
+	Items           []PreferenceItem
+	PayerEmail      string
+	ReturnURL       string
+	NotificationURL string
+}
+
+// CheckoutLink is a hosted checkout page
+type CheckoutLink struct {
+	ID         string
+	URL        string
+	SandboxURL string
+}
+
+// Payment is a payment as reported by the gateway
+type Payment struct {
+	ID           string
+	OrderID      string
+	Status       string
+	StatusDetail string
+	Amount       float64
+}
+
+// WebhookRequest is a notification as it reached us
+type WebhookRequest struct {
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// WebhookNotification is what a notification refers to
+type WebhookNotification struct {
+	Topic      string
+	ResourceID string
+	RequestID  string
+}
+
+// Registry holds the available providers by name. Orders that did not choose one are
+// paid through the default provider.
+type Registry struct {
+	providers   map[string]Provider
+	aliases     map[string]string
+	defaultName string
+}
+
+// NewRegistry creates an empty registry whose default provider is defaultName
+func NewRegistry(defaultName string) *Registry {
+	return &Registry{
+		providers:   make(map[string]Provider),
+		aliases:     make(map[string]string),
+		defaultName: defaultName,
+	}
+}
+
+// Register makes a provider available under its name and any aliases,
+// e.g. the path segment of a webhook URL registered with the gateway
+func (r *Registry) Register(provider Provider, aliases ...string) {
+	r.providers[provider.Name()] = provider
+	for _, alias := range aliases {
+		r.aliases[alias] = provider.Name()
+	}
+}
+
+// Get returns the provider registered under name, or the default provider when name is empty
+func (r *Registry) Get(name string) (Provider, error) {
+	if name == "" {
+		name = r.defaultName
+	}
+	if alias, ok := r.aliases[name]; ok {
+		name = alias
+	}
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, name)
+	}
+	return provider, nil
+}
+
+// Default returns the provider orders are paid through unless they chose another one
+func (r *Registry) Default() (Provider, error) {
+	return r.Get("")
+}
+
+// Names returns the names of the registered providers, sorted
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -14,10 +14,14 @@ import (
 // Context key for user data (stores the full payload as a map, similar to assistant-ia-api)
 const ContextKeyUser = "user"
 
+// RoleCourier is the JWT "role" claim of courier accounts. Other tokens carry no role.
+const RoleCourier = "courier"
+
 // CustomClaims matches the auth-api-be JWT structure
 type CustomClaims struct {
 	UserID       string `json:"user_id"`
 	TokenVersion uint   `json:"token_version"`
+	Role         string `json:"role,omitempty"`
 	jwt.StandardClaims
 }
 
@@ -92,6 +96,7 @@ func AuthMiddleware() gin.HandlerFunc {
 		userPayload := map[string]interface{}{
 			"user_id":       claims.UserID,
 			"token_version": claims.TokenVersion,
+			"role":          claims.Role,
 		}
 
 		c.Set(ContextKeyUser, userPayload)
@@ -100,6 +105,46 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// CourierMiddleware only lets through courier tokens. It must run after AuthMiddleware.
+func CourierMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == "OPTIONS" {
+			c.Next()
+			return
+		}
+
+		if !IsCourierFromContext(c) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":   "forbidden",
+				"message": "Courier role required",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// NoCourierMiddleware keeps courier tokens out of the admin routes. It must run after AuthMiddleware.
+func NoCourierMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == "OPTIONS" {
+			c.Next()
+			return
+		}
+
+		if IsCourierFromContext(c) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":   "forbidden",
+				"message": "Not available to couriers",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // OptionalAuthMiddleware tries to validate token but doesn't block if missing
 func OptionalAuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -128,6 +173,7 @@ func OptionalAuthMiddleware() gin.HandlerFunc {
 		userPayload := map[string]interface{}{
 			"user_id":       claims.UserID,
 			"token_version": claims.TokenVersion,
+			"role":          claims.Role,
 		}
 
 		c.Set(ContextKeyUser, userPayload)
@@ -155,3 +201,19 @@ func GetUserIDFromContext(c *gin.Context) (string, bool) {
 
 	return userID, true
 }
+
+// IsCourierFromContext reports whether the authenticated user is a courier
+func IsCourierFromContext(c *gin.Context) bool {
+	user, exists := c.Get(ContextKeyUser)
+	if !exists {
+		return false
+	}
+
+	userMap, ok := user.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	role, _ := userMap["role"].(string)
+	return strings.EqualFold(role, RoleCourier)
+}
@@ -10,8 +10,8 @@ func CORSMiddleware() gin.HandlerFunc {
 	return cors.New(cors.Config{
 		AllowAllOrigins:  true,
 		AllowMethods:     []string{"GET", "POST", "PATCH", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "If-Match", "Idempotency-Key"},
+		ExposeHeaders:    []string{"Content-Length", "ETag", "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           12 * 3600,
 	})
@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetETag writes the order version as the ETag response header
+func SetETag(c *gin.Context, version int) {
+	c.Header("ETag", `"`+strconv.Itoa(version)+`"`)
+}
+
+// GetIfMatchVersion parses the If-Match request header into an order version.
+// It returns nil when the header is missing or "*", meaning any version is accepted.
+func GetIfMatchVersion(c *gin.Context) (*int, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	value := strings.TrimPrefix(header, "W/")
+	value = strings.Trim(value, `"`)
+
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
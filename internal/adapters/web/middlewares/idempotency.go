@@ -0,0 +1,152 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// IdempotencyKeyHeader is the request header that makes a request safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxIdempotencyKeyLength matches the key column
+const maxIdempotencyKeyLength = 255
+
+// bodyRecorder passes the response through while keeping a copy to store
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware makes requests that carry an Idempotency-Key header safe to retry.
+// The first request with a key runs and its response is stored; retries with the same key
+// and body get that response back without running the handler again. Reusing a key for a
+// different request is rejected. Requests without the header are not affected.
+// On authenticated routes it must run after AuthMiddleware, since keys are scoped per user.
+func IdempotencyMiddleware(contextFactory appcontext.Factory) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := strings.TrimSpace(c.GetHeader(IdempotencyKeyHeader))
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			abortWithError(c, apperrors.NewApplicationError(mappings.IdempotencyKeyInvalidError, errors.New("key too long")))
+			return
+		}
+
+		// The body is buffered whole, so routes taking large uploads cap it first with BodyLimitMiddleware
+		body, err := io.ReadAll(c.Request.Body)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			abortWithError(c, apperrors.NewApplicationError(mappings.RequestBodyTooLargeError, err))
+			return
+		}
+		if err != nil {
+			abortWithError(c, apperrors.NewApplicationError(mappings.RequestBodyParsingError, err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		userID, _ := GetUserIDFromContext(c)
+		record := &domain.IdempotencyKey{
+			UserID:      userID,
+			Key:         key,
+			RequestHash: requestHash(c, body),
+		}
+
+		repo := contextFactory().Repositories.IdempotencyKey
+		existing, appErr := repo.Reserve(c, record)
+		if appErr != nil {
+			abortWithError(c, appErr)
+			return
+		}
+
+		if existing != nil {
+			switch {
+			case existing.RequestHash != record.RequestHash:
+				abortWithError(c, apperrors.NewApplicationError(mappings.IdempotencyKeyReusedError, errors.New("request hash mismatch")))
+			case !existing.IsCompleted():
+				abortWithError(c, apperrors.NewApplicationError(mappings.IdempotencyRequestInProgressError, errors.New("original request not finished")))
+			default:
+				contentType := "application/json; charset=utf-8"
+				if existing.ContentType != nil {
+					contentType = *existing.ContentType
+				}
+				c.Header("Idempotent-Replayed", "true")
+				c.Data(*existing.StatusCode, contentType, existing.ResponseBody)
+				c.Abort()
+			}
+			return
+		}
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		// The key is released unless its response gets stored, so a panic in the handler or
+		// a failed store leaves the request retryable instead of in progress until it expires
+		stored := false
+		defer func() {
+			if stored {
+				return
+			}
+			if err := repo.Delete(context.WithoutCancel(c), userID, key); err != nil {
+				log.Printf("Warning: failed to release idempotency key %q: %v", key, err)
+			}
+		}()
+
+		c.Next()
+
+		// Server errors are not stored so that the client can retry them
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+		if err := repo.Complete(c, userID, key, status, recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+			log.Printf("Warning: failed to store response for idempotency key %q, releasing it: %v", key, err)
+			return
+		}
+		stored = true
+	}
+}
+
+// requestHash identifies a request by its method, route and body. The multipart boundary
+// is left out, since clients pick a new one every time they resend the same upload.
+func requestHash(c *gin.Context, body []byte) string {
+	if _, params, err := mime.ParseMediaType(c.GetHeader("Content-Type")); err == nil && params["boundary"] != "" {
+		body = bytes.ReplaceAll(body, []byte(params["boundary"]), nil)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(c.Request.Method + " " + c.Request.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func abortWithError(c *gin.Context, appErr apperrors.ApplicationError) {
+	appErr.Log(c)
+	c.AbortWithStatusJSON(appErr.StatusCode(), appErr)
+}
@@ -7,36 +7,64 @@ import (
 	paymentHandler "yego/internal/adapters/web/handlers/payment"
 	profileHandler "yego/internal/adapters/web/handlers/profile"
 	settingsHandler "yego/internal/adapters/web/handlers/settings"
+	subscriptionHandler "yego/internal/adapters/web/handlers/subscription"
 	websocketHandler "yego/internal/adapters/web/handlers/websocket"
+	"yego/internal/adapters/web/integrations/payments"
 	"yego/internal/adapters/web/middlewares"
+	"yego/internal/domain"
 	"yego/internal/platform/config"
 	"yego/internal/usecases"
 )
 
 // RegisterRoutes registers all application routes
-func RegisterRoutes(app *gin.Engine, useCases *usecases.Usecases, wsHandler *websocketHandler.Handler, paymentCheckHandler *paymentHandler.Handler, cfg *config.ConfigurationService) {
+func RegisterRoutes(app *gin.Engine, useCases *usecases.Usecases, wsHandler *websocketHandler.Handler, paymentCheckHandler *paymentHandler.Handler, idempotency gin.HandlerFunc, paymentProviders *payments.Registry, cfg *config.ConfigurationService) {
 	api := app.Group("/api")
 
-	// Public order routes (tracking by UUID - no auth needed)
+	// Public order routes (tracking by signed token - no auth needed)
 	orders := api.Group("/orders")
 	{
-		orders.GET("/:id", orderHandler.NewGetHandler(useCases.Order.GetUsecase))
-		orders.POST("/create-with-link", orderHandler.NewCreateWithLinkHandler(useCases.Order.CreateWithLinkUsecase, cfg.FrontendURL))
+		orders.GET("/track/:token", orderHandler.NewTrackHandler(useCases.Order.TrackOrderUsecase))
+		orders.POST("/create-with-link", idempotency, orderHandler.NewCreateWithLinkHandler(useCases.Order.CreateWithLinkUsecase, cfg.FrontendURL))
 		orders.GET("/claim/:token/info", orderHandler.NewGetClaimInfoHandler(useCases.Order.GetClaimInfoUsecase))
-		// MercadoPago webhook — called by MP servers, no auth
-		orders.POST("/webhook/mp", orderHandler.NewPaymentWebhookHandler(useCases.Order.HandlePaymentWebhookUsecase))
+		// Payment provider webhooks — called by the gateways, no auth; MercadoPago uses /webhook/mp
+		orders.POST("/webhook/:provider", orderHandler.NewPaymentWebhookHandler(useCases.Webhook.RecordUsecase, paymentProviders))
 	}
 
 	// Protected order routes (require auth)
 	ordersAuth := api.Group("/orders")
 	ordersAuth.Use(middlewares.AuthMiddleware())
 	{
-		ordersAuth.POST("", orderHandler.NewCreateHandler(useCases.Order.CreateUsecase))
+		ordersAuth.POST("", idempotency, orderHandler.NewCreateHandler(useCases.Order.CreateUsecase))
+		ordersAuth.GET("/:id", orderHandler.NewGetHandler(useCases.Order.GetUsecase))
+		ordersAuth.POST("/:id/tracking-link", orderHandler.NewCreateTrackingLinkHandler(useCases.Order.CreateTrackingLinkUsecase, cfg.FrontendURL))
 		ordersAuth.PATCH("/:id/status", orderHandler.NewUpdateStatusHandler(useCases.Order.UpdateStatusUsecase))
 		ordersAuth.POST("/claim/:token", orderHandler.NewClaimHandler(useCases.Order.ClaimUsecase))
-		ordersAuth.POST("/:id/pay", orderHandler.NewPayForOrderHandler(useCases.Order.PayForOrderUsecase))
+		ordersAuth.POST("/:id/pay", idempotency, orderHandler.NewPayForOrderHandler(useCases.Order.PayForOrderUsecase))
 		ordersAuth.POST("/:id/payment-link", orderHandler.NewCreatePaymentLinkHandler(useCases.Order.CreatePaymentLinkUsecase, cfg.FrontendURL, cfg.BackendURL))
 		ordersAuth.GET("/my", orderHandler.NewListMyHandler(useCases.Order.ListMyOrdersUsecase))
+		ordersAuth.GET("/:id/timeline", orderHandler.NewGetTimelineHandler(useCases.Order.GetTimelineUsecase))
+		ordersAuth.POST("/:id/cancel", orderHandler.NewCancelHandler(useCases.Order.CancelUsecase))
+		ordersAuth.POST("/:id/modifications", orderHandler.NewRequestModificationHandler(useCases.Order.RequestModificationUsecase))
+		ordersAuth.GET("/:id/modifications", orderHandler.NewListModificationsHandler(useCases.Order.ListModificationsUsecase))
+		ordersAuth.GET("/:id/comments", orderHandler.NewListCommentsHandler(useCases.Order.ListCommentsUsecase))
+		ordersAuth.POST("/:id/comments", orderHandler.NewPostCommentHandler(useCases.Order.PostCommentUsecase))
+		ordersAuth.POST("/:id/comments/attachments/presign", orderHandler.NewPresignCommentAttachmentHandler(useCases.Order.PresignCommentAttachment))
+		ordersAuth.POST("/:id/reorder", orderHandler.NewReorderHandler(useCases.Order.ReorderUsecase))
+		ordersAuth.GET("/:id/receipt", orderHandler.NewGetReceiptHandler(useCases.Order.GetReceiptUsecase))
+		ordersAuth.GET("/:id/refunds", orderHandler.NewListRefundsHandler(useCases.Order.ListRefundsUsecase))
+	}
+
+	// Recurring order subscriptions (require auth)
+	subscriptions := api.Group("/subscriptions")
+	subscriptions.Use(middlewares.AuthMiddleware())
+	{
+		subscriptions.POST("", subscriptionHandler.NewCreateHandler(useCases.Subscription.CreateUsecase))
+		subscriptions.GET("", subscriptionHandler.NewListHandler(useCases.Subscription.ListUsecase))
+		subscriptions.GET("/:id", subscriptionHandler.NewGetHandler(useCases.Subscription.GetUsecase))
+		subscriptions.PUT("/:id", subscriptionHandler.NewUpdateHandler(useCases.Subscription.UpdateUsecase))
+		subscriptions.POST("/:id/pause", subscriptionHandler.NewSetStatusHandler(useCases.Subscription.SetStatusUsecase, domain.SubscriptionPaused))
+		subscriptions.POST("/:id/resume", subscriptionHandler.NewSetStatusHandler(useCases.Subscription.SetStatusUsecase, domain.SubscriptionActive))
+		subscriptions.DELETE("/:id", subscriptionHandler.NewSetStatusHandler(useCases.Subscription.SetStatusUsecase, domain.SubscriptionCancelled))
 	}
 
 	// Public profile routes (token-based access)
@@ -63,16 +91,46 @@ func RegisterRoutes(app *gin.Engine, useCases *usecases.Usecases, wsHandler *web
 		settings.GET("", settingsHandler.NewGetHandler(useCases.Settings.GetUsecase))
 		settings.PUT("", settingsHandler.NewUpdateHandler(useCases.Settings.UpdateUsecase))
 		settings.POST("/calculate-delivery", settingsHandler.NewCalculateDeliveryFeeHandler(useCases.Settings.CalculateDeliveryFeeUsecase))
+		settings.POST("/estimate-delivery-time", settingsHandler.NewEstimateDeliveryTimeHandler(useCases.Settings.EstimateDeliveryTimeUsecase))
+		settings.GET("/delivery-slots", settingsHandler.NewListDeliverySlotsHandler(useCases.Settings.ListDeliverySlotsUsecase))
+		settings.GET("/delivery-slots/availability", settingsHandler.NewGetSlotAvailabilityHandler(useCases.Settings.GetSlotAvailabilityUsecase))
+	}
+
+	// Courier routes (require a courier token)
+	courier := api.Group("/courier")
+	courier.Use(middlewares.AuthMiddleware(), middlewares.CourierMiddleware())
+	{
+		// Couriers confirm cash collected on delivery
+		courier.POST("/orders/:id/payment-receipt", adminHandler.NewReceivePaymentHandler(useCases.Order.ReceivePaymentUsecase))
 	}
 
-	// Admin routes (require auth)
+	// Admin routes (require auth; closed to couriers)
 	admin := api.Group("/admin")
-	admin.Use(middlewares.AuthMiddleware())
+	admin.Use(middlewares.AuthMiddleware(), middlewares.NoCourierMiddleware())
 	{
 		admin.GET("/profiles", adminHandler.NewListProfilesHandler(useCases.Admin.ListProfilesUsecase))
 		admin.GET("/orders", adminHandler.NewListOrdersHandler(useCases.Admin.ListOrdersUsecase))
+		admin.GET("/orders/:id", adminHandler.NewGetOrderHandler(useCases.Order.GetUsecase))
+		admin.POST("/orders/:id/tracking-link", adminHandler.NewCreateOrderTrackingLinkHandler(useCases.Order.CreateTrackingLinkUsecase, cfg.FrontendURL))
+		admin.POST("/orders/bulk", middlewares.BodyLimitMiddleware(adminHandler.BulkMaxRequestSize), idempotency, adminHandler.NewBulkCreateOrdersHandler(useCases.Order.BulkCreateWithLinkUsecase, cfg.FrontendURL))
 		admin.GET("/transactions", adminHandler.NewListTransactionsHandler(useCases.Admin.ListTransactionsUsecase))
+		admin.GET("/transactions/:id", adminHandler.NewGetTransactionHandler(useCases.Admin.GetTransactionUsecase))
+		admin.POST("/payments/reconcile", adminHandler.NewReconcilePaymentsHandler(useCases.Order.ReconcilePaymentsUsecase))
 		admin.PUT("/orders/:id", adminHandler.NewUpdateOrderHandler(useCases.Admin.UpdateOrderUsecase))
+		admin.GET("/orders/:id/next-statuses", adminHandler.NewGetNextStatusesHandler(useCases.Admin.GetNextStatusesUsecase))
+		admin.GET("/orders/:id/timeline", adminHandler.NewGetOrderTimelineHandler(useCases.Order.GetTimelineUsecase))
+		admin.GET("/orders/:id/receipt", adminHandler.NewGetOrderReceiptHandler(useCases.Order.GetReceiptUsecase))
+		admin.POST("/orders/:id/cancel", adminHandler.NewCancelOrderHandler(useCases.Order.CancelUsecase))
+		admin.GET("/orders/:id/refunds", adminHandler.NewListOrderRefundsHandler(useCases.Order.ListRefundsUsecase))
+		admin.POST("/orders/:id/refunds", idempotency, adminHandler.NewRefundOrderHandler(useCases.Order.RefundUsecase))
+		admin.POST("/orders/:id/payment-receipt", adminHandler.NewReceivePaymentHandler(useCases.Order.ReceivePaymentUsecase))
+		admin.PATCH("/orders/:id/items", adminHandler.NewFulfilItemsHandler(useCases.Order.FulfilItemsUsecase))
+		admin.GET("/orders/:id/modifications", adminHandler.NewListModificationsHandler(useCases.Order.ListModificationsUsecase))
+		admin.GET("/orders/:id/comments", adminHandler.NewListOrderCommentsHandler(useCases.Order.ListCommentsUsecase))
+		admin.POST("/orders/:id/comments", adminHandler.NewPostOrderCommentHandler(useCases.Order.PostCommentUsecase))
+		admin.GET("/modifications", adminHandler.NewListModificationsHandler(useCases.Order.ListModificationsUsecase))
+		admin.POST("/modifications/:id/approve", adminHandler.NewReviewModificationHandler(useCases.Order.ReviewModificationUsecase, true))
+		admin.POST("/modifications/:id/reject", adminHandler.NewReviewModificationHandler(useCases.Order.ReviewModificationUsecase, false))
 		admin.POST("/import", adminHandler.NewUploadImportHandler(useCases.Admin.UploadImport))
 		admin.GET("/imports", adminHandler.NewListImportsHandler(useCases.Admin.ListImports))
 		admin.POST("/imports", adminHandler.NewCreateImportHandler(useCases.Admin.CreateImport))
@@ -85,6 +143,16 @@ func RegisterRoutes(app *gin.Engine, useCases *usecases.Usecases, wsHandler *web
 		admin.POST("/coupons", adminHandler.NewCreateCouponHandler(useCases.Admin.CreateCoupon))
 		admin.PUT("/coupons/:id", adminHandler.NewUpdateCouponHandler(useCases.Admin.UpdateCoupon))
 		admin.DELETE("/coupons/:id", adminHandler.NewDeleteCouponHandler(useCases.Admin.DeleteCoupon))
+		admin.POST("/delivery-slots", settingsHandler.NewCreateDeliverySlotHandler(useCases.Settings.CreateDeliverySlotUsecase))
+		admin.PUT("/delivery-slots/:id", settingsHandler.NewUpdateDeliverySlotHandler(useCases.Settings.UpdateDeliverySlotUsecase))
+		admin.DELETE("/delivery-slots/:id", settingsHandler.NewDeleteDeliverySlotHandler(useCases.Settings.DeleteDeliverySlotUsecase))
+		admin.GET("/delivery-slots/bookings", adminHandler.NewListSlotBookingsHandler(useCases.Admin.ListSlotBookings))
+		admin.GET("/subscriptions", adminHandler.NewListSubscriptionsHandler(useCases.Subscription.ListUsecase))
+		admin.GET("/subscriptions/:id", adminHandler.NewGetSubscriptionHandler(useCases.Subscription.GetUsecase))
+		admin.POST("/subscriptions/run", adminHandler.NewRunDueSubscriptionsHandler(useCases.Order.RunDueSubscriptionsUsecase))
+		admin.GET("/webhook-events", adminHandler.NewListWebhookEventsHandler(useCases.Webhook.ListUsecase))
+		admin.GET("/webhook-events/:id", adminHandler.NewGetWebhookEventHandler(useCases.Webhook.GetUsecase))
+		admin.POST("/webhook-events/:id/replay", adminHandler.NewReplayWebhookEventHandler(useCases.Webhook.ReplayUsecase))
 	}
 
 	// Payment routes (require auth)
@@ -14,6 +14,14 @@ const (
 	OrderClaimedNotification NotificationType = "order_claimed"
 	OrderCreatedNotification NotificationType = "order_created"
 	OrderUpdatedNotification NotificationType = "order_updated"
+
+	OrderModificationRequestedNotification NotificationType = "order_modification_requested"
+	OrderCommentPostedNotification         NotificationType = "order_comment_posted"
+	OrderCommentRepliedNotification        NotificationType = "order_comment_replied"
+	SubscriptionRunFailedNotification      NotificationType = "subscription_run_failed"
+	OrderItemsSubstitutedNotification      NotificationType = "order_items_substituted"
+	OrdersExpiredNotification              NotificationType = "orders_expired"
+	OrderPaymentFlaggedNotification        NotificationType = "order_payment_flagged"
 )
 
 type Notification struct {
@@ -44,16 +52,86 @@ type OrderUpdatedPayload struct {
 	ETA     string `json:"eta"`
 }
 
+type OrderModificationRequestedPayload struct {
+	RequestID   string `json:"request_id"`
+	OrderID     string `json:"order_id"`
+	UserID      string `json:"user_id"`
+	ChangeCount int    `json:"change_count"`
+	Note        string `json:"note,omitempty"`
+	RequestedAt string `json:"requested_at"`
+}
+
+type OrderCommentPostedPayload struct {
+	CommentID string `json:"comment_id"`
+	OrderID   string `json:"order_id"`
+}
+
+type OrderCommentRepliedPayload struct {
+	CommentID     string `json:"comment_id"`
+	OrderID       string `json:"order_id"`
+	UserID        string `json:"user_id"`
+	Body          string `json:"body"`
+	AttachmentKey string `json:"attachment_key,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type SubscriptionRunFailedPayload struct {
+	SubscriptionID string `json:"subscription_id"`
+	UserID         string `json:"user_id"`
+	OrderID        string `json:"order_id,omitempty"`
+	Status         string `json:"status"`
+	FailedAt       string `json:"failed_at"`
+}
+
+type OrdersExpiredPayload struct {
+	OrderIDs      []string `json:"order_ids"`
+	TokensExpired int64    `json:"tokens_expired"`
+	ExpiredAt     string   `json:"expired_at"`
+}
+
+type OrderPaymentFlaggedPayload struct {
+	OrderID          string  `json:"order_id"`
+	Flag             string  `json:"flag"`
+	GatewayPaymentID string  `json:"gateway_payment_id"`
+	Amount           float64 `json:"amount"`
+	FlaggedAt        string  `json:"flagged_at"`
+}
+
+type ItemSubstitutionPayload struct {
+	Name               string  `json:"name"`
+	Quantity           int     `json:"quantity"`
+	SubstituteName     string  `json:"substitute_name"`
+	SubstituteQuantity int     `json:"substitute_quantity"`
+	SubstitutePrice    float64 `json:"substitute_price"`
+}
+
+type OrderItemsSubstitutedPayload struct {
+	OrderID       string                    `json:"order_id"`
+	UserID        string                    `json:"user_id"`
+	Substitutions []ItemSubstitutionPayload `json:"substitutions"`
+	Unavailable   []string                  `json:"unavailable"`
+	ItemsTotal    float64                   `json:"items_total"`
+	UpdatedAt     string                    `json:"updated_at"`
+}
+
 type Client struct {
 	Hub       *Hub
 	Conn      *websocket.Conn
 	Send      chan []byte
 	IsManager bool
+	UserID    string
+}
+
+// directMessage is a message addressed to the connections of a single user
+type directMessage struct {
+	userID string
+	data   []byte
 }
 
 type Hub struct {
 	clients    map[*Client]bool
 	broadcast  chan []byte
+	direct     chan directMessage
 	Register   chan *Client
 	Unregister chan *Client
 	mu         sync.RWMutex
@@ -63,6 +141,7 @@ func NewHub() *Hub {
 	return &Hub{
 		clients:    make(map[*Client]bool),
 		broadcast:  make(chan []byte),
+		direct:     make(chan directMessage),
 		Register:   make(chan *Client),
 		Unregister: make(chan *Client),
 	}
@@ -87,7 +166,8 @@ func (h *Hub) Run() {
 			log.Printf("WebSocket client unregistered. Total clients: %d", len(h.clients))
 
 		case message := <-h.broadcast:
-			h.mu.RLock()
+			// Slow clients are evicted here, so this needs the write lock
+			h.mu.Lock()
 			for client := range h.clients {
 				if client.IsManager {
 					select {
@@ -98,7 +178,21 @@ func (h *Hub) Run() {
 					}
 				}
 			}
-			h.mu.RUnlock()
+			h.mu.Unlock()
+
+		case message := <-h.direct:
+			h.mu.Lock()
+			for client := range h.clients {
+				if client.UserID == message.userID {
+					select {
+					case client.Send <- message.data:
+					default:
+						close(client.Send)
+						delete(h.clients, client)
+					}
+				}
+			}
+			h.mu.Unlock()
 		}
 	}
 }
@@ -112,6 +206,16 @@ func (h *Hub) BroadcastNotification(notification Notification) error {
 	return nil
 }
 
+// SendToUser delivers a notification only to the connections of the given user
+func (h *Hub) SendToUser(userID string, notification Notification) error {
+	data, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	h.direct <- directMessage{userID: userID, data: data}
+	return nil
+}
+
 func (h *Hub) NotifyOrderClaimed(payload OrderClaimedPayload) error {
 	return h.BroadcastNotification(Notification{Type: OrderClaimedNotification, Payload: payload})
 }
@@ -124,6 +228,34 @@ func (h *Hub) NotifyOrderUpdated(payload OrderUpdatedPayload) error {
 	return h.BroadcastNotification(Notification{Type: OrderUpdatedNotification, Payload: payload})
 }
 
+func (h *Hub) NotifyOrderModificationRequested(payload OrderModificationRequestedPayload) error {
+	return h.BroadcastNotification(Notification{Type: OrderModificationRequestedNotification, Payload: payload})
+}
+
+func (h *Hub) NotifyOrderCommentPosted(payload OrderCommentPostedPayload) error {
+	return h.BroadcastNotification(Notification{Type: OrderCommentPostedNotification, Payload: payload})
+}
+
+func (h *Hub) NotifyOrderCommentReplied(payload OrderCommentRepliedPayload) error {
+	return h.SendToUser(payload.UserID, Notification{Type: OrderCommentRepliedNotification, Payload: payload})
+}
+
+func (h *Hub) NotifySubscriptionRunFailed(payload SubscriptionRunFailedPayload) error {
+	return h.SendToUser(payload.UserID, Notification{Type: SubscriptionRunFailedNotification, Payload: payload})
+}
+
+func (h *Hub) NotifyOrderItemsSubstituted(payload OrderItemsSubstitutedPayload) error {
+	return h.SendToUser(payload.UserID, Notification{Type: OrderItemsSubstitutedNotification, Payload: payload})
+}
+
+func (h *Hub) NotifyOrdersExpired(payload OrdersExpiredPayload) error {
+	return h.BroadcastNotification(Notification{Type: OrdersExpiredNotification, Payload: payload})
+}
+
+func (h *Hub) NotifyOrderPaymentFlagged(payload OrderPaymentFlaggedPayload) error {
+	return h.BroadcastNotification(Notification{Type: OrderPaymentFlaggedNotification, Payload: payload})
+}
+
 func (h *Hub) GetClientCount() int {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
@@ -41,4 +41,82 @@ func (n *Notifier) NotifyOrderUpdated(payload notification.OrderUpdatedPayload)
 	})
 }
 
+func (n *Notifier) NotifyOrderModificationRequested(payload notification.OrderModificationRequestedPayload) error {
+	return n.hub.NotifyOrderModificationRequested(OrderModificationRequestedPayload{
+		RequestID:   payload.RequestID,
+		OrderID:     payload.OrderID,
+		UserID:      payload.UserID,
+		ChangeCount: payload.ChangeCount,
+		Note:        payload.Note,
+		RequestedAt: payload.RequestedAt,
+	})
+}
+
+func (n *Notifier) NotifyOrderCommentPosted(payload notification.OrderCommentPostedPayload) error {
+	return n.hub.NotifyOrderCommentPosted(OrderCommentPostedPayload{
+		CommentID: payload.CommentID,
+		OrderID:   payload.OrderID,
+	})
+}
+
+func (n *Notifier) NotifyOrderCommentReplied(payload notification.OrderCommentRepliedPayload) error {
+	return n.hub.NotifyOrderCommentReplied(OrderCommentRepliedPayload{
+		CommentID:     payload.CommentID,
+		OrderID:       payload.OrderID,
+		UserID:        payload.UserID,
+		Body:          payload.Body,
+		AttachmentKey: payload.AttachmentKey,
+		CreatedAt:     payload.CreatedAt,
+	})
+}
+
+func (n *Notifier) NotifySubscriptionRunFailed(payload notification.SubscriptionRunFailedPayload) error {
+	return n.hub.NotifySubscriptionRunFailed(SubscriptionRunFailedPayload{
+		SubscriptionID: payload.SubscriptionID,
+		UserID:         payload.UserID,
+		OrderID:        payload.OrderID,
+		Status:         payload.Status,
+		FailedAt:       payload.FailedAt,
+	})
+}
+
+func (n *Notifier) NotifyOrderItemsSubstituted(payload notification.OrderItemsSubstitutedPayload) error {
+	substitutions := make([]ItemSubstitutionPayload, len(payload.Substitutions))
+	for i, s := range payload.Substitutions {
+		substitutions[i] = ItemSubstitutionPayload{
+			Name:               s.Name,
+			Quantity:           s.Quantity,
+			SubstituteName:     s.SubstituteName,
+			SubstituteQuantity: s.SubstituteQuantity,
+			SubstitutePrice:    s.SubstitutePrice,
+		}
+	}
+	return n.hub.NotifyOrderItemsSubstituted(OrderItemsSubstitutedPayload{
+		OrderID:       payload.OrderID,
+		UserID:        payload.UserID,
+		Substitutions: substitutions,
+		Unavailable:   payload.Unavailable,
+		ItemsTotal:    payload.ItemsTotal,
+		UpdatedAt:     payload.UpdatedAt,
+	})
+}
+
 var _ notification.Service = (*Notifier)(nil)
+
+func (n *Notifier) NotifyOrdersExpired(payload notification.OrdersExpiredPayload) error {
+	return n.hub.NotifyOrdersExpired(OrdersExpiredPayload{
+		OrderIDs:      payload.OrderIDs,
+		TokensExpired: payload.TokensExpired,
+		ExpiredAt:     payload.ExpiredAt,
+	})
+}
+
+func (n *Notifier) NotifyOrderPaymentFlagged(payload notification.OrderPaymentFlaggedPayload) error {
+	return n.hub.NotifyOrderPaymentFlagged(OrderPaymentFlaggedPayload{
+		OrderID:          payload.OrderID,
+		Flag:             payload.Flag,
+		GatewayPaymentID: payload.GatewayPaymentID,
+		Amount:           payload.Amount,
+		FlaggedAt:        payload.FlaggedAt,
+	})
+}
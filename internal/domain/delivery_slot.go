@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// SlotTimeLayout is the format of delivery slot start and end times
+const SlotTimeLayout = "15:04"
+
+// DeliveryDateLayout is the format of the calendar day a slot is booked for
+const DeliveryDateLayout = "2006-01-02"
+
+// BusinessLocation is the time zone delivery days, slots and ETAs are expressed in.
+// It defaults to the server zone until SetBusinessLocation is called at startup.
+var BusinessLocation = time.Local
+
+// SetBusinessLocation loads the named IANA time zone as the business location
+func SetBusinessLocation(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return err
+	}
+	BusinessLocation = loc
+	return nil
+}
+
+// BusinessToday returns midnight of the current day in the business location
+func BusinessToday() time.Time {
+	now := time.Now().In(BusinessLocation)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, BusinessLocation)
+}
+
+// DeliverySlot is a recurring weekly delivery window with a maximum number of orders
+type DeliverySlot struct {
+	ID        string       `json:"id"`
+	Weekday   time.Weekday `json:"weekday"`    // 0 = Sunday
+	StartTime string       `json:"start_time"` // "15:04", business local time
+	EndTime   string       `json:"end_time"`
+	Capacity  int          `json:"capacity"`
+	Active    bool         `json:"active"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// Validate checks the weekday, times and capacity of the slot
+func (s *DeliverySlot) Validate() error {
+	if s.Weekday < time.Sunday || s.Weekday > time.Saturday {
+		return errors.New("weekday must be between 0 (Sunday) and 6 (Saturday)")
+	}
+	start, err := time.Parse(SlotTimeLayout, s.StartTime)
+	if err != nil {
+		return errors.New("start_time must be HH:MM")
+	}
+	end, err := time.Parse(SlotTimeLayout, s.EndTime)
+	if err != nil {
+		return errors.New("end_time must be HH:MM")
+	}
+	if !end.After(start) {
+		return errors.New("end_time must be after start_time")
+	}
+	if s.Capacity < 0 {
+		return errors.New("capacity cannot be negative")
+	}
+	return nil
+}
+
+// StartsAt returns the start of the slot on the given day, in the day's location
+func (s *DeliverySlot) StartsAt(date time.Time) time.Time {
+	return atClock(date, s.StartTime)
+}
+
+// EndsAt returns the end of the slot on the given day, in the day's location
+func (s *DeliverySlot) EndsAt(date time.Time) time.Time {
+	return atClock(date, s.EndTime)
+}
+
+// CanBeBookedOn reports whether an order can be booked into the slot on the given day
+func (s *DeliverySlot) CanBeBookedOn(date time.Time, now time.Time) error {
+	if !s.Active {
+		return errors.New("delivery slot is not active")
+	}
+	if date.Weekday() != s.Weekday {
+		return errors.New("delivery slot does not run on that day")
+	}
+	if !s.EndsAt(date).After(now) {
+		return errors.New("delivery slot has already ended")
+	}
+	return nil
+}
+
+// atClock combines the calendar day of date with an "HH:MM" clock time
+func atClock(date time.Time, clock string) time.Time {
+	t, _ := time.Parse(SlotTimeLayout, clock)
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, date.Location())
+}
+
+// ParseDeliveryDate parses a YYYY-MM-DD day in the business local time zone
+func ParseDeliveryDate(s string) (time.Time, error) {
+	return time.ParseInLocation(DeliveryDateLayout, s, BusinessLocation)
+}
+
+// DeliverySlotAvailability is the booking state of a slot on a specific day
+type DeliverySlotAvailability struct {
+	Slot      *DeliverySlot
+	Date      time.Time
+	Booked    int
+	Remaining int
+}
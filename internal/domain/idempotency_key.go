@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// IdempotencyKeyTTL is how long a stored response is replayed; after that the key may be reused
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// IdempotencyKey is a request made with an Idempotency-Key header and, once it
+// finished, the response that is replayed when the request is retried.
+// Keys are scoped to the user that sent them; anonymous requests share the empty scope.
+type IdempotencyKey struct {
+	UserID       string
+	Key          string
+	RequestHash  string
+	StatusCode   *int
+	ContentType  *string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	CompletedAt  *time.Time
+}
+
+// IsCompleted reports whether the original request finished and its response was stored
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.StatusCode != nil
+}
@@ -48,6 +48,10 @@ type OrderItem struct {
 	Price    float64 `json:"price"`
 	Quantity int     `json:"quantity"`
 	Weight   *int    `json:"weight,omitempty"` // weight in grams, optional
+
+	// Fulfilment and Substitute are set by managers while preparing the order
+	Fulfilment ItemFulfilment  `json:"fulfilment,omitempty"`
+	Substitute *ItemSubstitute `json:"substitute,omitempty"`
 }
 
 // OrderData represents the data/items in an order
@@ -57,15 +61,38 @@ type OrderData struct {
 
 // Order represents a customer order in the system
 type Order struct {
-	ID            string      `json:"id"`
-	ProfileID     *string     `json:"profile_id,omitempty"`
-	UserID        *string     `json:"user_id,omitempty"`
-	Status        OrderStatus `json:"status"`
-	StatusMessage *string     `json:"status_message,omitempty"`
-	ETA           string      `json:"eta"`
-	Data          *OrderData  `json:"data,omitempty"`
-	CreatedAt     time.Time   `json:"created_at"`
-	UpdatedAt     time.Time   `json:"updated_at"`
+	ID                         string              `json:"id"`
+	ProfileID                  *string             `json:"profile_id,omitempty"`
+	UserID                     *string             `json:"user_id,omitempty"`
+	Status                     OrderStatus         `json:"status"`
+	StatusMessage              *string             `json:"status_message,omitempty"`
+	ETA                        string              `json:"eta"` // display text, derived from EstimatedDeliveryAt when set
+	EstimatedDeliveryAt        *time.Time          `json:"estimated_delivery_at,omitempty"`
+	EstimatedDeliveryWindowEnd *time.Time          `json:"estimated_delivery_window_end,omitempty"`
+	Data                       *OrderData          `json:"data,omitempty"`
+	Version                    int                 `json:"version"`
+	CancellationReason         *CancellationReason `json:"cancellation_reason,omitempty"`
+	DeliverySlotID             *string             `json:"delivery_slot_id,omitempty"`
+	DeliveryDate               *time.Time          `json:"delivery_date,omitempty"` // calendar day of the booked slot
+	PriceBreakdown             *PriceBreakdown     `json:"price_breakdown,omitempty"`
+	PaymentFlag                *PaymentFlag        `json:"payment_flag,omitempty"`
+	PaymentFlaggedAt           *time.Time          `json:"payment_flagged_at,omitempty"`
+	PaymentProvider            *string             `json:"payment_provider,omitempty"`    // nil follows the configured default
+	PaymentMethod              *PaymentMethod      `json:"payment_method,omitempty"`      // nil is paid online
+	PaymentReceivedAt          *time.Time          `json:"payment_received_at,omitempty"` // cash or transfer confirmed by staff
+	CreatedAt                  time.Time           `json:"created_at"`
+	UpdatedAt                  time.Time           `json:"updated_at"`
+}
+
+// SetEstimatedDelivery stores the estimated delivery time and optional window end,
+// and derives the display ETA from them ("15:04" or "15:04 - 15:30") in the business location
+func (o *Order) SetEstimatedDelivery(at time.Time, windowEnd *time.Time) {
+	o.EstimatedDeliveryAt = &at
+	o.EstimatedDeliveryWindowEnd = windowEnd
+	o.ETA = at.In(BusinessLocation).Format("15:04")
+	if windowEnd != nil && windowEnd.After(at) {
+		o.ETA += " - " + windowEnd.In(BusinessLocation).Format("15:04")
+	}
 }
 
 // DataJSON returns the Data field as JSON bytes for database storage
@@ -99,6 +126,7 @@ type OrderToken struct {
 	ClaimedAt       *time.Time `json:"claimed_at,omitempty"`
 	ClaimedByUserID *string    `json:"claimed_by_user_id,omitempty"`
 	ExpiresAt       time.Time  `json:"expires_at"`
+	ExpiredAt       *time.Time `json:"expired_at,omitempty"` // set once the token can no longer be claimed
 	CreatedAt       time.Time  `json:"created_at"`
 }
 
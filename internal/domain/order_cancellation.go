@@ -0,0 +1,55 @@
+package domain
+
+// CancellationReason describes why an order was cancelled
+type CancellationReason string
+
+const (
+	CancellationReasonCustomerRequest CancellationReason = "CUSTOMER_REQUEST"
+	CancellationReasonOutOfStock      CancellationReason = "OUT_OF_STOCK"
+	CancellationReasonPaymentIssue    CancellationReason = "PAYMENT_ISSUE"
+	CancellationReasonDeliveryIssue   CancellationReason = "DELIVERY_ISSUE"
+	CancellationReasonDuplicate       CancellationReason = "DUPLICATE"
+	CancellationReasonOther           CancellationReason = "OTHER"
+	// CancellationReasonUnpaidTimeout is set by the expiry job on orders nobody paid in time
+	CancellationReasonUnpaidTimeout CancellationReason = "UNPAID_TIMEOUT"
+)
+
+// ValidCancellationReasons contains all valid cancellation reasons
+var ValidCancellationReasons = []CancellationReason{
+	CancellationReasonCustomerRequest,
+	CancellationReasonOutOfStock,
+	CancellationReasonPaymentIssue,
+	CancellationReasonDeliveryIssue,
+	CancellationReasonDuplicate,
+	CancellationReasonOther,
+	CancellationReasonUnpaidTimeout,
+}
+
+// IsValidCancellationReason checks if a cancellation reason string is valid
+func IsValidCancellationReason(s string) bool {
+	for _, reason := range ValidCancellationReasons {
+		if string(reason) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomerCancellableStatuses lists the statuses in which a customer may still
+// cancel their own order. Once it is being prepared only a manager can cancel it.
+var CustomerCancellableStatuses = []OrderStatus{
+	StatusCreated,
+	StatusConfirmed,
+	StatusPaused,
+	StatusModificationRequested,
+}
+
+// CanBeCancelledByCustomer reports whether the customer may cancel the order in its current status
+func (o *Order) CanBeCancelledByCustomer() bool {
+	for _, s := range CustomerCancellableStatuses {
+		if s == o.Status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// CommentVisibility controls who can read an order comment
+type CommentVisibility string
+
+const (
+	// CommentVisibilityInternal comments are notes between managers and never shown to the customer
+	CommentVisibilityInternal CommentVisibility = "internal"
+	// CommentVisibilityCustomer comments are part of the conversation with the customer
+	CommentVisibilityCustomer CommentVisibility = "customer"
+)
+
+// CommentAuthorRole identifies which side of the conversation wrote a comment
+type CommentAuthorRole string
+
+const (
+	CommentAuthorCustomer CommentAuthorRole = "customer"
+	CommentAuthorManager  CommentAuthorRole = "manager"
+)
+
+// MaxCommentBodyLength is the longest comment body accepted
+const MaxCommentBodyLength = 2000
+
+// CommentAttachmentPrefix is the S3 prefix under which attachments for the comments of an
+// order are uploaded
+func CommentAttachmentPrefix(orderID string) string {
+	return "orders/" + orderID + "/comments/"
+}
+
+// OrderComment is a message or internal note attached to an order
+type OrderComment struct {
+	ID            string            `json:"id"`
+	OrderID       string            `json:"order_id"`
+	AuthorUserID  string            `json:"author_user_id"`
+	AuthorRole    CommentAuthorRole `json:"author_role"`
+	Visibility    CommentVisibility `json:"visibility"`
+	Body          string            `json:"body"`
+	AttachmentKey *string           `json:"attachment_key,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+// Validate checks the comment body and attachment key
+func (c *OrderComment) Validate() error {
+	body := strings.TrimSpace(c.Body)
+	if body == "" && c.AttachmentKey == nil {
+		return errors.New("comment needs a body or an attachment")
+	}
+	if len(body) > MaxCommentBodyLength {
+		return errors.New("comment body is too long")
+	}
+	if c.AttachmentKey != nil {
+		key := *c.AttachmentKey
+		if key == "" || len(key) > 512 || strings.HasPrefix(key, "/") || strings.Contains(key, "..") {
+			return errors.New("invalid attachment key")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package domain
+
+import "time"
+
+// OrderSort defines the ordering of an order listing
+type OrderSort string
+
+const (
+	OrderSortCreatedAtDesc OrderSort = "created_at_desc"
+	OrderSortCreatedAtAsc  OrderSort = "created_at_asc"
+	OrderSortUpdatedAtDesc OrderSort = "updated_at_desc"
+	OrderSortUpdatedAtAsc  OrderSort = "updated_at_asc"
+	OrderSortAmountDesc    OrderSort = "amount_desc"
+	OrderSortAmountAsc     OrderSort = "amount_asc"
+)
+
+// ValidOrderSorts contains all supported sort options
+var ValidOrderSorts = []OrderSort{
+	OrderSortCreatedAtDesc,
+	OrderSortCreatedAtAsc,
+	OrderSortUpdatedAtDesc,
+	OrderSortUpdatedAtAsc,
+	OrderSortAmountDesc,
+	OrderSortAmountAsc,
+}
+
+// IsValidOrderSort checks if a sort option string is supported
+func IsValidOrderSort(s string) bool {
+	for _, sort := range ValidOrderSorts {
+		if string(sort) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderFilter narrows down an order listing. Zero values mean "no filter".
+// Amounts refer to the items subtotal (price × quantity).
+type OrderFilter struct {
+	Statuses    []OrderStatus
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	ProfileID   string
+	UserID      string
+	Phone       string // partial match on the profile or claim-link phone number
+	MinAmount   *float64
+	MaxAmount   *float64
+	Search      string // partial match on item names and codes
+	Flagged     bool   // only orders with a payment flag
+	Sort        OrderSort
+	Cursor      string // opaque cursor returned as NextCursor by the previous page
+	Limit       int
+}
+
+// OrderPage is a page of an order listing
+type OrderPage struct {
+	Orders     []*Order
+	Total      int
+	NextCursor *string
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ItemFulfilment is the picking state of a single order item
+type ItemFulfilment string
+
+const (
+	ItemPending     ItemFulfilment = "pending"
+	ItemPicked      ItemFulfilment = "picked"
+	ItemSubstituted ItemFulfilment = "substituted"
+	ItemUnavailable ItemFulfilment = "unavailable"
+)
+
+// IsValidItemFulfilment checks if a fulfilment string is valid
+func IsValidItemFulfilment(s string) bool {
+	switch ItemFulfilment(s) {
+	case ItemPending, ItemPicked, ItemSubstituted, ItemUnavailable:
+		return true
+	}
+	return false
+}
+
+// ItemSubstitute is the product delivered in place of an out-of-stock item
+type ItemSubstitute struct {
+	Code     string  `json:"code,omitempty"`
+	Name     string  `json:"name"`
+	Price    float64 `json:"price"`
+	Quantity int     `json:"quantity"`
+	Weight   *int    `json:"weight,omitempty"` // weight in grams, optional
+}
+
+// Validate checks that the substitute can be billed
+func (s *ItemSubstitute) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return errors.New("substitute name is required")
+	}
+	if s.Price <= 0 {
+		return errors.New("substitute price must be positive")
+	}
+	if s.Quantity <= 0 {
+		return errors.New("substitute quantity must be positive")
+	}
+	return nil
+}
+
+// FulfilmentStatus returns the item's picking state; items never marked are pending
+func (i OrderItem) FulfilmentStatus() ItemFulfilment {
+	if i.Fulfilment == "" {
+		return ItemPending
+	}
+	return i.Fulfilment
+}
+
+// SetFulfilment marks the item. A substitute is required when substituting and
+// is cleared for every other state.
+func (i *OrderItem) SetFulfilment(status ItemFulfilment, substitute *ItemSubstitute) error {
+	if !IsValidItemFulfilment(string(status)) {
+		return fmt.Errorf("invalid fulfilment %q", status)
+	}
+	if status == ItemSubstituted {
+		if substitute == nil {
+			return errors.New("a substitute is required")
+		}
+		if err := substitute.Validate(); err != nil {
+			return err
+		}
+	} else if substitute != nil {
+		return fmt.Errorf("a substitute is only allowed for %s items", ItemSubstituted)
+	}
+
+	i.Fulfilment = status
+	i.Substitute = substitute
+	return nil
+}
+
+// ResetFulfilment clears any picking state, e.g. when the item is copied into a new order
+func (i *OrderItem) ResetFulfilment() {
+	i.Fulfilment = ""
+	i.Substitute = nil
+}
+
+// Billed returns the item as it is charged and delivered: a substituted item is
+// replaced by its substitute and an unavailable item is dropped (ok is false)
+func (i OrderItem) Billed() (OrderItem, bool) {
+	switch i.FulfilmentStatus() {
+	case ItemUnavailable:
+		return OrderItem{}, false
+	case ItemSubstituted:
+		if i.Substitute != nil {
+			return OrderItem{
+				Code:     i.Substitute.Code,
+				Name:     i.Substitute.Name,
+				Price:    i.Substitute.Price,
+				Quantity: i.Substitute.Quantity,
+				Weight:   i.Substitute.Weight,
+			}, true
+		}
+	}
+	return i, true
+}
+
+// BilledItems returns the items that are charged and delivered after fulfilment
+func (d *OrderData) BilledItems() []OrderItem {
+	if d == nil {
+		return nil
+	}
+	items := make([]OrderItem, 0, len(d.Items))
+	for _, item := range d.Items {
+		if billed, ok := item.Billed(); ok {
+			items = append(items, billed)
+		}
+	}
+	return items
+}
+
+// ItemsTotal returns the sum of price times quantity over the billed items
+func (d *OrderData) ItemsTotal() float64 {
+	var total float64
+	for _, item := range d.BilledItems() {
+		total += item.Price * float64(item.Quantity)
+	}
+	return total
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ModificationRequestStatus represents the review state of a modification request
+type ModificationRequestStatus string
+
+const (
+	ModificationRequestPending  ModificationRequestStatus = "PENDING"
+	ModificationRequestApproved ModificationRequestStatus = "APPROVED"
+	ModificationRequestRejected ModificationRequestStatus = "REJECTED"
+)
+
+// ItemChangeAction represents the kind of change proposed for an order item
+type ItemChangeAction string
+
+const (
+	ItemChangeAdd         ItemChangeAction = "add"
+	ItemChangeRemove      ItemChangeAction = "remove"
+	ItemChangeSetQuantity ItemChangeAction = "quantity"
+)
+
+// OrderItemChange is a single change proposed by a customer.
+// Existing items are matched by code, or by name when no code is given.
+type OrderItemChange struct {
+	Action   ItemChangeAction `json:"action"`
+	Code     string           `json:"code,omitempty"`
+	Name     string           `json:"name,omitempty"`
+	Price    float64          `json:"price,omitempty"`
+	Quantity int              `json:"quantity,omitempty"`
+	Weight   *int             `json:"weight,omitempty"`
+}
+
+// OrderModificationRequest is a pending revision of an order's items proposed by its customer
+type OrderModificationRequest struct {
+	ID                string                    `json:"id"`
+	OrderID           string                    `json:"order_id"`
+	RequestedByUserID string                    `json:"requested_by_user_id"`
+	Status            ModificationRequestStatus `json:"status"`
+	PreviousStatus    OrderStatus               `json:"previous_status"` // order status to restore once reviewed
+	Changes           []OrderItemChange         `json:"changes"`
+	ProposedData      *OrderData                `json:"proposed_data"`
+	Note              *string                   `json:"note,omitempty"`
+	ReviewedByUserID  *string                   `json:"reviewed_by_user_id,omitempty"`
+	ReviewNote        *string                   `json:"review_note,omitempty"`
+	ReviewedAt        *time.Time                `json:"reviewed_at,omitempty"`
+	CreatedAt         time.Time                 `json:"created_at"`
+	UpdatedAt         time.Time                 `json:"updated_at"`
+}
+
+// ApplyItemChanges returns a copy of the order data with the changes applied in order.
+// Removing an item or setting its quantity to zero drops it; adding an existing item
+// increases its quantity.
+func ApplyItemChanges(data *OrderData, changes []OrderItemChange) (*OrderData, error) {
+	var items []OrderItem
+	if data != nil {
+		items = append(items, data.Items...)
+	}
+
+	for i, change := range changes {
+		if change.Code == "" && change.Name == "" {
+			return nil, fmt.Errorf("change %d: code or name is required", i)
+		}
+		idx := findOrderItem(items, change)
+
+		switch change.Action {
+		case ItemChangeAdd:
+			if change.Quantity <= 0 {
+				return nil, fmt.Errorf("change %d: quantity must be positive", i)
+			}
+			if idx >= 0 {
+				items[idx].Quantity += change.Quantity
+				continue
+			}
+			items = append(items, OrderItem{
+				Code:     change.Code,
+				Name:     change.Name,
+				Price:    change.Price,
+				Quantity: change.Quantity,
+				Weight:   change.Weight,
+			})
+		case ItemChangeRemove:
+			if idx < 0 {
+				return nil, fmt.Errorf("change %d: item not found in order", i)
+			}
+			items = append(items[:idx], items[idx+1:]...)
+		case ItemChangeSetQuantity:
+			if idx < 0 {
+				return nil, fmt.Errorf("change %d: item not found in order", i)
+			}
+			if change.Quantity < 0 {
+				return nil, fmt.Errorf("change %d: quantity cannot be negative", i)
+			}
+			if change.Quantity == 0 {
+				items = append(items[:idx], items[idx+1:]...)
+				continue
+			}
+			items[idx].Quantity = change.Quantity
+		default:
+			return nil, fmt.Errorf("change %d: unknown action %q", i, change.Action)
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, fmt.Errorf("order must keep at least one item")
+	}
+
+	return &OrderData{Items: items}, nil
+}
+
+// ContainsOrderItem reports whether the items already include the given item, matched
+// the same way changes are
+func ContainsOrderItem(items []OrderItem, item OrderItem) bool {
+	return findOrderItem(items, OrderItemChange{Code: item.Code, Name: item.Name}) >= 0
+}
+
+// findOrderItem returns the index of the item targeted by a change, or -1
+func findOrderItem(items []OrderItem, change OrderItemChange) int {
+	for i, item := range items {
+		if change.Code != "" && item.Code != "" {
+			if strings.EqualFold(item.Code, change.Code) {
+				return i
+			}
+			continue
+		}
+		if change.Name != "" && strings.EqualFold(strings.TrimSpace(item.Name), strings.TrimSpace(change.Name)) {
+			return i
+		}
+	}
+	return -1
+}
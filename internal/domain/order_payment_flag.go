@@ -0,0 +1,24 @@
+package domain
+
+// PaymentFlag marks an order whose payment needs attention from an admin
+type PaymentFlag string
+
+const (
+	// PaymentFlagChargedBack is set when the customer disputed a payment of the order with their card issuer
+	PaymentFlagChargedBack PaymentFlag = "CHARGED_BACK"
+	// PaymentFlagRefundFailed is set when cancelling an order refunded only part of its payments;
+	// the order keeps its status and staff retry the cancellation, which refunds the rest
+	PaymentFlagRefundFailed PaymentFlag = "REFUND_FAILED"
+	// PaymentFlagRefundedNotCancelled is set when the payments of an order were refunded for a
+	// cancellation that could then not be saved; staff cancel the order
+	PaymentFlagRefundedNotCancelled PaymentFlag = "REFUNDED_NOT_CANCELLED"
+	// PaymentFlagOverpaid is set when items of a paid order were marked unavailable and the
+	// customer paid more than the new total; staff refund the difference
+	PaymentFlagOverpaid PaymentFlag = "OVERPAID"
+	// PaymentFlagRefundUnrecorded is set when the gateway issued a refund that could not be
+	// stored as a transaction; staff reconcile it against the gateway before refunding again
+	PaymentFlagRefundUnrecorded PaymentFlag = "REFUND_UNRECORDED"
+	// PaymentFlagRefundDue is set when an order was charged while it left CREATED, e.g. the
+	// expiry job cancelled it during the payment; staff refund the charge
+	PaymentFlagRefundDue PaymentFlag = "REFUND_DUE"
+)
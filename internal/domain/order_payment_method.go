@@ -0,0 +1,57 @@
+package domain
+
+import "errors"
+
+// PaymentMethod is how the customer pays an order
+type PaymentMethod string
+
+const (
+	// PaymentMethodCard charges the customer's saved card
+	PaymentMethodCard PaymentMethod = "CARD"
+	// PaymentMethodCheckoutLink sends the customer to the provider's hosted checkout
+	PaymentMethodCheckoutLink PaymentMethod = "CHECKOUT_LINK"
+	// PaymentMethodCash is paid to the courier on delivery
+	PaymentMethodCash PaymentMethod = "CASH"
+	// PaymentMethodTransfer is a bank transfer the customer sends proof of
+	PaymentMethodTransfer PaymentMethod = "TRANSFER"
+)
+
+// ValidPaymentMethods contains all valid payment methods
+var ValidPaymentMethods = []PaymentMethod{
+	PaymentMethodCard,
+	PaymentMethodCheckoutLink,
+	PaymentMethodCash,
+	PaymentMethodTransfer,
+}
+
+// IsValidPaymentMethod checks if a payment method string is valid
+func IsValidPaymentMethod(s string) bool {
+	for _, method := range ValidPaymentMethods {
+		if string(method) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOffline reports whether the payment is received outside the payment providers,
+// so a courier or manager has to confirm it
+func (m PaymentMethod) IsOffline() bool {
+	return m == PaymentMethodCash || m == PaymentMethodTransfer
+}
+
+// ErrPaymentNotReceived blocks delivering a cash order before the courier confirmed the payment
+var ErrPaymentNotReceived = errors.New("cash payment has not been confirmed as received")
+
+// PaidOffline reports whether the order is paid in cash or by transfer
+func (o *Order) PaidOffline() bool {
+	return o.PaymentMethod != nil && o.PaymentMethod.IsOffline()
+}
+
+// requireCashReceived keeps a cash order from being delivered until its payment is received
+func requireCashReceived(o *Order, _ OrderStatus) error {
+	if o.PaymentMethod != nil && *o.PaymentMethod == PaymentMethodCash && o.PaymentReceivedAt == nil {
+		return ErrPaymentNotReceived
+	}
+	return nil
+}
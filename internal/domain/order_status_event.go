@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// OrderStatusEvent records a single status change of an order
+type OrderStatusEvent struct {
+	ID            string       `json:"id"`
+	OrderID       string       `json:"order_id"`
+	FromStatus    *OrderStatus `json:"from_status,omitempty"`
+	ToStatus      OrderStatus  `json:"to_status"`
+	ActorUserID   *string      `json:"actor_user_id,omitempty"`
+	StatusMessage *string      `json:"status_message,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
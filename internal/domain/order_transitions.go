@@ -0,0 +1,95 @@
+package domain
+
+import "fmt"
+
+// OrderTransitions defines which statuses an order may move to from each status.
+// Terminal statuses (DELIVERED, CANCELLED) have no outgoing transitions.
+var OrderTransitions = map[OrderStatus][]OrderStatus{
+	StatusCreated: {
+		StatusConfirmed,
+		StatusPaused,
+		StatusCancelled,
+		StatusModificationRequested,
+	},
+	StatusConfirmed: {
+		StatusPreparing,
+		StatusPaused,
+		StatusCancelled,
+		StatusModificationRequested,
+	},
+	StatusPreparing: {
+		StatusOnTheWay,
+		StatusPaused,
+		StatusCancelled,
+		StatusModificationRequested,
+	},
+	StatusOnTheWay: {
+		StatusDelivered,
+		StatusPaused,
+	},
+	StatusDelivered: {},
+	StatusPaused: {
+		StatusCreated,
+		StatusConfirmed,
+		StatusPreparing,
+		StatusOnTheWay,
+		StatusCancelled,
+	},
+	StatusCancelled: {},
+	StatusModificationRequested: {
+		StatusCreated,
+		StatusConfirmed,
+		StatusPreparing,
+		StatusCancelled,
+	},
+}
+
+// TransitionGuard inspects an order before it moves to a target status and
+// returns an error when the move must be blocked
+type TransitionGuard func(order *Order, to OrderStatus) error
+
+// transitionGuards lists the guards that run whenever an order moves to a status, on top
+// of the transition table
+var transitionGuards = map[OrderStatus][]TransitionGuard{
+	StatusDelivered: {
+		requireCashReceived,
+	},
+}
+
+// CanTransition reports whether the transition table allows moving from one status to another
+func CanTransition(from, to OrderStatus) bool {
+	for _, s := range OrderTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTransition checks the transition table and the guards for
+// moving the order to the given status. Keeping the current status is always allowed.
+func (o *Order) ValidateTransition(to OrderStatus) error {
+	if o.Status == to {
+		return nil
+	}
+	if !CanTransition(o.Status, to) {
+		return fmt.Errorf("cannot move order from %s to %s", o.Status, to)
+	}
+	for _, guard := range transitionGuards[to] {
+		if err := guard(o, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NextStatuses returns the statuses the order can currently move to, guards included
+func (o *Order) NextStatuses() []OrderStatus {
+	next := make([]OrderStatus, 0, len(OrderTransitions[o.Status]))
+	for _, s := range OrderTransitions[o.Status] {
+		if o.ValidateTransition(s) == nil {
+			next = append(next, s)
+		}
+	}
+	return next
+}
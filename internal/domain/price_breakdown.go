@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+)
+
+// DeliveryFeeBreakdown holds the components of the delivery fee at pricing time
+type DeliveryFeeBreakdown struct {
+	BasePrice     float64 `json:"base_price"`
+	DistanceKm    float64 `json:"distance_km"`
+	DistancePrice float64 `json:"distance_price"`
+	TotalWeightG  int     `json:"total_weight_g"`
+	WeightPrice   float64 `json:"weight_price"`
+	Total         float64 `json:"total"`
+}
+
+// PriceDiscount is a single discount applied to an order
+type PriceDiscount struct {
+	Code        string  `json:"code,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Amount      float64 `json:"amount"`
+}
+
+// PriceBreakdown is the price of an order as it was shown and charged.
+// It is stored on the order so later changes to settings or the customer's
+// location do not change what the customer pays.
+type PriceBreakdown struct {
+	Subtotal      float64              `json:"subtotal"`
+	DeliveryFee   DeliveryFeeBreakdown `json:"delivery_fee"`
+	Discounts     []PriceDiscount      `json:"discounts"`
+	DiscountTotal float64              `json:"discount_total"`
+	Total         float64              `json:"total"`
+	Currency      string               `json:"currency"`
+	PricedAt      time.Time            `json:"priced_at"`
+}
+
+// NewPriceBreakdown builds a breakdown from its parts and derives the totals.
+// Discounts never take the total below zero.
+func NewPriceBreakdown(subtotal float64, deliveryFee DeliveryFeeBreakdown, discounts []PriceDiscount, pricedAt time.Time) *PriceBreakdown {
+	if discounts == nil {
+		discounts = []PriceDiscount{}
+	}
+
+	b := &PriceBreakdown{
+		Subtotal:    roundMoney(subtotal),
+		DeliveryFee: deliveryFee,
+		Discounts:   discounts,
+		Currency:    "ARS",
+		PricedAt:    pricedAt,
+	}
+
+	for _, d := range discounts {
+		b.DiscountTotal += d.Amount
+	}
+	gross := b.Subtotal + b.DeliveryFee.Total
+	b.DiscountTotal = roundMoney(math.Min(b.DiscountTotal, gross))
+	b.Total = roundMoney(gross - b.DiscountTotal)
+
+	return b
+}
+
+// PriceBreakdownJSON returns the PriceBreakdown field as JSON bytes for database storage
+func (o *Order) PriceBreakdownJSON() ([]byte, error) {
+	if o.PriceBreakdown == nil {
+		return nil, nil
+	}
+	return json.Marshal(o.PriceBreakdown)
+}
+
+// SetPriceBreakdownFromJSON sets the PriceBreakdown field from JSON bytes
+func (o *Order) SetPriceBreakdownFromJSON(data []byte) error {
+	if data == nil {
+		o.PriceBreakdown = nil
+		return nil
+	}
+	var breakdown PriceBreakdown
+	if err := json.Unmarshal(data, &breakdown); err != nil {
+		return err
+	}
+	o.PriceBreakdown = &breakdown
+	return nil
+}
+
+// roundMoney rounds an amount to cents
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
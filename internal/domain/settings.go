@@ -4,18 +4,23 @@ import "time"
 
 // Settings represents the application configuration
 type Settings struct {
-	ID                  string    `json:"id"`
-	BusinessName        string    `json:"business_name"`
-	BusinessLatitude    float64   `json:"business_latitude"`
-	BusinessLongitude   float64   `json:"business_longitude"`
-	DefaultMapLatitude  float64   `json:"default_map_latitude"`
-	DefaultMapLongitude float64   `json:"default_map_longitude"`
-	DefaultMapZoom      int       `json:"default_map_zoom"`
-	DefaultItemWeight   int       `json:"default_item_weight"` // in grams
-	DeliveryBasePrice   float64   `json:"delivery_base_price"`
-	DeliveryPricePerKm  float64   `json:"delivery_price_per_km"`
-	DeliveryPricePerKg  float64   `json:"delivery_price_per_kg"`
-	ManagerCollectorID  *string   `json:"manager_collector_id,omitempty"` // MercadoPago collector ID for manager account
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
+	ID                  string  `json:"id"`
+	BusinessName        string  `json:"business_name"`
+	BusinessLatitude    float64 `json:"business_latitude"`
+	BusinessLongitude   float64 `json:"business_longitude"`
+	DefaultMapLatitude  float64 `json:"default_map_latitude"`
+	DefaultMapLongitude float64 `json:"default_map_longitude"`
+	DefaultMapZoom      int     `json:"default_map_zoom"`
+	DefaultItemWeight   int     `json:"default_item_weight"` // in grams
+	DeliveryBasePrice   float64 `json:"delivery_base_price"`
+	DeliveryPricePerKm  float64 `json:"delivery_price_per_km"`
+	DeliveryPricePerKg  float64 `json:"delivery_price_per_kg"`
+	ManagerCollectorID  *string `json:"manager_collector_id,omitempty"` // MercadoPago collector ID for manager account
+	// Delivery time estimation
+	PreparationTimeMinutes int       `json:"preparation_time_minutes"`
+	QueueMinutesPerOrder   int       `json:"queue_minutes_per_order"` // extra wait per order already in PREPARING/ON_THE_WAY
+	DeliveryMinutesPerKm   float64   `json:"delivery_minutes_per_km"`
+	ETAWindowMinutes       int       `json:"eta_window_minutes"` // width of the displayed delivery window, 0 for none
+	CreatedAt              time.Time `json:"created_at"`
+	UpdatedAt              time.Time `json:"updated_at"`
 }
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SubscriptionFrequency is how often a subscription places an order
+type SubscriptionFrequency string
+
+const (
+	FrequencyWeekly   SubscriptionFrequency = "weekly"
+	FrequencyBiweekly SubscriptionFrequency = "biweekly"
+	FrequencyMonthly  SubscriptionFrequency = "monthly"
+)
+
+// IsValidSubscriptionFrequency checks if a frequency string is valid
+func IsValidSubscriptionFrequency(s string) bool {
+	switch SubscriptionFrequency(s) {
+	case FrequencyWeekly, FrequencyBiweekly, FrequencyMonthly:
+		return true
+	}
+	return false
+}
+
+// After returns the run that follows t. Monthly runs land on anchorDay of the next month,
+// or on its last day when the month is shorter, so a subscription anchored on the 31st runs
+// on Feb 28 and then on Mar 31 again. An anchorDay of 0 anchors on the day of t.
+func (f SubscriptionFrequency) After(t time.Time, anchorDay int) time.Time {
+	switch f {
+	case FrequencyBiweekly:
+		return t.AddDate(0, 0, 14)
+	case FrequencyMonthly:
+		return addMonthOnDay(t, anchorDay)
+	default:
+		return t.AddDate(0, 0, 7)
+	}
+}
+
+// SubscriptionAnchorDay returns the day of the month a monthly subscription starting at t
+// keeps coming back to, in the business timezone
+func SubscriptionAnchorDay(t time.Time) int {
+	return t.In(BusinessLocation).Day()
+}
+
+// addMonthOnDay moves t to day of the following month in the business timezone, clamped to
+// the last day of that month. Unlike AddDate it never overflows into the month after.
+func addMonthOnDay(t time.Time, day int) time.Time {
+	local := t.In(BusinessLocation)
+	if day <= 0 {
+		day = local.Day()
+	}
+	year, month, _ := local.Date()
+	first := time.Date(year, month+1, 1, local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), BusinessLocation)
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// SubscriptionStatus represents whether a subscription is placing orders
+type SubscriptionStatus string
+
+const (
+	SubscriptionActive    SubscriptionStatus = "ACTIVE"
+	SubscriptionPaused    SubscriptionStatus = "PAUSED"
+	SubscriptionCancelled SubscriptionStatus = "CANCELLED"
+)
+
+// Subscription places an order with the same items on a recurring schedule
+type Subscription struct {
+	ID          string                `json:"id"`
+	UserID      string                `json:"user_id"`
+	ProfileID   *string               `json:"profile_id,omitempty"`
+	Items       []OrderItem           `json:"items"`
+	Frequency   SubscriptionFrequency `json:"frequency"`
+	Status      SubscriptionStatus    `json:"status"`
+	NextRunAt   time.Time             `json:"next_run_at"`
+	AnchorDay   int                   `json:"anchor_day"`  // day of the month monthly runs return to
+	AutoCharge  bool                  `json:"auto_charge"` // charge the saved payment method when the order is placed
+	LastRunAt   *time.Time            `json:"last_run_at,omitempty"`
+	LastOrderID *string               `json:"last_order_id,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+}
+
+// Validate checks the template items and frequency
+func (s *Subscription) Validate() error {
+	if !IsValidSubscriptionFrequency(string(s.Frequency)) {
+		return fmt.Errorf("unknown frequency %q", s.Frequency)
+	}
+	if len(s.Items) == 0 {
+		return errors.New("at least one item is required")
+	}
+	for _, item := range s.Items {
+		if item.Name == "" && item.Code == "" {
+			return errors.New("items need a code or a name")
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("item %q needs a positive quantity", item.Name)
+		}
+	}
+	return nil
+}
+
+// NextRunAfter returns the first scheduled run strictly after now,
+// skipping the runs missed while the subscription was paused or the scheduler was down
+func (s *Subscription) NextRunAfter(now time.Time) time.Time {
+	next := s.NextRunAt
+	for !next.After(now) {
+		next = s.Frequency.After(next, s.AnchorDay)
+	}
+	return next
+}
+
+// SubscriptionRunStatus is the outcome of a scheduler run
+type SubscriptionRunStatus string
+
+const (
+	SubscriptionRunOrderCreated SubscriptionRunStatus = "ORDER_CREATED"
+	SubscriptionRunCharged      SubscriptionRunStatus = "CHARGED"
+	SubscriptionRunChargeFailed SubscriptionRunStatus = "CHARGE_FAILED"
+	SubscriptionRunFailed       SubscriptionRunStatus = "FAILED"
+)
+
+// SubscriptionRun records one attempt to place the order of a subscription
+type SubscriptionRun struct {
+	ID             string                `json:"id"`
+	SubscriptionID string                `json:"subscription_id"`
+	OrderID        *string               `json:"order_id,omitempty"`
+	ScheduledFor   time.Time             `json:"scheduled_for"`
+	Status         SubscriptionRunStatus `json:"status"`
+	Error          *string               `json:"error,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
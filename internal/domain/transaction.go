@@ -2,19 +2,89 @@ package domain
 
 import "time"
 
+// TransactionType distinguishes money captured from money given back
+type TransactionType string
+
+const (
+	TransactionTypePayment TransactionType = "payment"
+	TransactionTypeRefund  TransactionType = "refund"
+)
+
+// Transaction statuses, as reported by the payment gateway
+const (
+	TransactionStatusPending     = "pending"
+	TransactionStatusInProcess   = "in_process"
+	TransactionStatusInMediation = "in_mediation"
+	TransactionStatusApproved    = "approved"
+	TransactionStatusRejected    = "rejected"
+	TransactionStatusCancelled   = "cancelled"
+	TransactionStatusRefunded    = "refunded"
+	TransactionStatusChargedBack = "charged_back"
+)
+
+// transactionStatusStages orders the gateway lifecycle: a payment starts pending,
+// is settled as approved, rejected or cancelled, may be disputed, and an approved
+// payment may end refunded or charged back. Unknown statuses rank as pending.
+var transactionStatusStages = map[string]int{
+	TransactionStatusPending:     0,
+	TransactionStatusInProcess:   0,
+	TransactionStatusApproved:    1,
+	TransactionStatusRejected:    1,
+	TransactionStatusCancelled:   1,
+	TransactionStatusInMediation: 2,
+	TransactionStatusRefunded:    3,
+	TransactionStatusChargedBack: 3,
+}
+
+// CanMoveTransactionStatus reports whether a status update may replace the current
+// status. Gateway notifications can arrive out of order, so a transaction never moves
+// back to an earlier stage of the lifecycle, e.g. from approved to pending.
+func CanMoveTransactionStatus(from, to string) bool {
+	if from == to || to == "" {
+		return false
+	}
+	return transactionStatusStages[to] >= transactionStatusStages[from]
+}
+
+// Sources of a transaction status change
+const (
+	TransactionSourceCharge         = "charge"
+	TransactionSourceWebhook        = "webhook"
+	TransactionSourceRefund         = "refund"
+	TransactionSourceReconciliation = "reconciliation"
+	TransactionSourceReceipt        = "receipt"
+)
+
 // Transaction represents a payment transaction in the system
 type Transaction struct {
-	ID                string    `json:"id"`
-	OrderID           string    `json:"order_id"`
-	UserID            string    `json:"user_id"`
-	ProfileID         *string   `json:"profile_id,omitempty"`
-	Amount            float64   `json:"amount"`
-	Currency          string    `json:"currency"`
-	Status            string    `json:"status"`
-	PaymentID         *int      `json:"payment_id,omitempty"`
-	GatewayPaymentID  *string   `json:"gateway_payment_id,omitempty"`
-	CollectorID       *string   `json:"collector_id,omitempty"`
-	Description       *string   `json:"description,omitempty"`
-	CreatedAt         time.Time `json:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at"`
+	ID                  string          `json:"id"`
+	OrderID             string          `json:"order_id"`
+	UserID              string          `json:"user_id"`
+	ProfileID           *string         `json:"profile_id,omitempty"`
+	Type                TransactionType `json:"type"`
+	Provider            string          `json:"provider"`                        // payment provider the gateway payment belongs to
+	ParentTransactionID *string         `json:"parent_transaction_id,omitempty"` // original payment of a refund
+	Amount              float64         `json:"amount"`
+	Currency            string          `json:"currency"`
+	Status              string          `json:"status"`
+	StatusDetail        *string         `json:"status_detail,omitempty"` // gateway reason, e.g. cc_rejected_insufficient_amount
+	PaymentID           *int            `json:"payment_id,omitempty"`
+	GatewayPaymentID    *string         `json:"gateway_payment_id,omitempty"`
+	CollectorID         *string         `json:"collector_id,omitempty"`
+	Description         *string         `json:"description,omitempty"`
+	ConfirmedByUserID   *string         `json:"confirmed_by_user_id,omitempty"` // staff member who received a cash or transfer payment
+	ProofKey            *string         `json:"proof_key,omitempty"`            // S3 key of the transfer proof
+	CreatedAt           time.Time       `json:"created_at"`
+	UpdatedAt           time.Time       `json:"updated_at"`
+}
+
+// TransactionStatusEvent records a single status change of a transaction
+type TransactionStatusEvent struct {
+	ID            string    `json:"id"`
+	TransactionID string    `json:"transaction_id"`
+	FromStatus    *string   `json:"from_status,omitempty"`
+	ToStatus      string    `json:"to_status"`
+	StatusDetail  *string   `json:"status_detail,omitempty"`
+	Source        string    `json:"source"`
+	CreatedAt     time.Time `json:"created_at"`
 }
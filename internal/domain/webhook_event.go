@@ -0,0 +1,95 @@
+package domain
+
+import "time"
+
+// WebhookEventStatus is where a received notification is in its processing
+type WebhookEventStatus string
+
+const (
+	// WebhookEventPending events wait for their next attempt
+	WebhookEventPending WebhookEventStatus = "PENDING"
+	// WebhookEventProcessing events are being handled by a worker
+	WebhookEventProcessing WebhookEventStatus = "PROCESSING"
+	WebhookEventProcessed  WebhookEventStatus = "PROCESSED"
+	// WebhookEventFailed events ran out of attempts and wait for an admin replay
+	WebhookEventFailed WebhookEventStatus = "FAILED"
+	// WebhookEventDuplicate events arrived while another event for the same resource was still open
+	WebhookEventDuplicate WebhookEventStatus = "DUPLICATE"
+	// WebhookEventIgnored events have a topic we do not handle or no resource ID
+	WebhookEventIgnored WebhookEventStatus = "IGNORED"
+)
+
+// ValidWebhookEventStatuses contains all webhook event statuses
+var ValidWebhookEventStatuses = []WebhookEventStatus{
+	WebhookEventPending,
+	WebhookEventProcessing,
+	WebhookEventProcessed,
+	WebhookEventFailed,
+	WebhookEventDuplicate,
+	WebhookEventIgnored,
+}
+
+// IsValidWebhookEventStatus checks if a webhook event status string is valid
+func IsValidWebhookEventStatus(s string) bool {
+	for _, status := range ValidWebhookEventStatuses {
+		if string(status) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook processing retries with exponential backoff up to MaxWebhookAttempts
+const (
+	MaxWebhookAttempts    = 8
+	webhookRetryBaseDelay = 30 * time.Second
+	webhookRetryMaxDelay  = 2 * time.Hour
+)
+
+// WebhookEvent is a notification received from a payment gateway
+type WebhookEvent struct {
+	ID            string             `json:"id"`
+	Provider      string             `json:"provider"`
+	Topic         string             `json:"topic"`
+	ResourceID    string             `json:"resource_id"`
+	RequestID     *string            `json:"request_id,omitempty"`
+	Query         string             `json:"query,omitempty"`
+	RawBody       string             `json:"raw_body,omitempty"`
+	Status        WebhookEventStatus `json:"status"`
+	Attempts      int                `json:"attempts"`
+	LastError     *string            `json:"last_error,omitempty"`
+	NextAttemptAt time.Time          `json:"next_attempt_at"`
+	ProcessedAt   *time.Time         `json:"processed_at,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+// CanBeReplayed reports whether an admin may queue the event again
+func (e *WebhookEvent) CanBeReplayed() bool {
+	return e.Status != WebhookEventPending && e.Status != WebhookEventProcessing
+}
+
+// NextRetryAt returns when a failed attempt should be retried, or nil when the
+// event has used all its attempts. The delay doubles with every attempt.
+func (e *WebhookEvent) NextRetryAt(now time.Time) *time.Time {
+	if e.Attempts >= MaxWebhookAttempts {
+		return nil
+	}
+	delay := webhookRetryBaseDelay
+	for i := 1; i < e.Attempts && delay < webhookRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > webhookRetryMaxDelay {
+		delay = webhookRetryMaxDelay
+	}
+	retryAt := now.Add(delay)
+	return &retryAt
+}
+
+// WebhookEventFilter narrows the webhook events listed for admins
+type WebhookEventFilter struct {
+	Status     WebhookEventStatus
+	Topic      string
+	ResourceID string
+	Limit      int
+}
@@ -19,10 +19,44 @@ type ConfigurationService struct {
 	AuthAPIURL              string
 	MPAccessToken            string
 	MPCheckoutProAccessToken string
+	// MPWebhookSecret verifies the x-signature of MercadoPago webhooks; required in release mode,
+	// unset disables verification elsewhere
+	MPWebhookSecret string
+	// MPWebhookTolerance is how far a webhook signature timestamp may be from now
+	MPWebhookTolerance string
+	// PaymentProvider is the provider orders are paid through unless they choose another one
+	PaymentProvider string
+	// PaymentFakeProviderEnabled registers the in-memory fake provider when "true"; never in production
+	PaymentFakeProviderEnabled string
 	S3Region                string
 	S3Bucket                string
 	S3AccessKeyID           string
 	S3SecretAccessKey       string
+	// StoreReceiptsInS3 uploads every generated PDF receipt to the bucket when "true"
+	StoreReceiptsInS3 string
+	// TrackingTokenSecret signs public tracking links; JWTSecret is used when empty
+	TrackingTokenSecret string
+	// TrackingLinkTTL is how long a public tracking link stays valid
+	TrackingLinkTTL string
+	// BusinessTimezone is the IANA zone delivery slots and ETAs are expressed in
+	BusinessTimezone string
+
+	// SubscriptionSchedulerInterval is how often due subscriptions are turned into orders
+	SubscriptionSchedulerInterval string
+	// OrderExpiryInterval is how often unpaid orders and stale claim tokens are expired
+	OrderExpiryInterval string
+	// UnpaidOrderMaxAge is how long an order may stay CREATED before it is cancelled
+	UnpaidOrderMaxAge string
+	// WebhookProcessorInterval is how often stored payment notifications are processed
+	WebhookProcessorInterval string
+	// PaymentReconciliationInterval is how often recent orders are reconciled with the payment gateway
+	PaymentReconciliationInterval string
+	// PaymentReconciliationLookback is how far back each reconciliation run looks
+	PaymentReconciliationLookback string
+	// PaymentReconciliationAutoFix lets the reconciliation job apply the safe fixes ("true")
+	PaymentReconciliationAutoFix string
+	// IdempotencyKeyCleanupInterval is how often expired idempotency keys are deleted
+	IdempotencyKeyCleanupInterval string
 }
 
 var instance *ConfigurationService
@@ -44,10 +78,27 @@ func GetInstance() *ConfigurationService {
 			AuthAPIURL:               getEnvOrDefault("AUTH_API_URL", "http://localhost:8082"),
 			MPAccessToken:            getEnvOrDefault("MP_ACCESS_TOKEN", ""),
 			MPCheckoutProAccessToken: getEnvOrDefault("MP_CHECKOUT_PRO_ACCESS_TOKEN", ""),
+			MPWebhookSecret:          getEnvOrDefault("MP_WEBHOOK_SECRET", ""),
+			MPWebhookTolerance:       getEnvOrDefault("MP_WEBHOOK_TOLERANCE", "5m"),
+			PaymentProvider:          getEnvOrDefault("PAYMENT_PROVIDER", "mercadopago"),
+			PaymentFakeProviderEnabled: getEnvOrDefault("PAYMENT_FAKE_PROVIDER_ENABLED", "false"),
 			S3Region:                 getEnvOrDefault("AWS_REGION", ""),
 			S3Bucket:                 getEnvOrDefault("AWS_BUCKET", ""),
 			S3AccessKeyID:            getEnvOrDefault("AWS_ACCESS_KEY_ID", ""),
 			S3SecretAccessKey:        getEnvOrDefault("AWS_SECRET_ACCESS_KEY", ""),
+			StoreReceiptsInS3:        getEnvOrDefault("STORE_RECEIPTS_IN_S3", "false"),
+			TrackingTokenSecret:      getEnvOrDefault("TRACKING_TOKEN_SECRET", ""),
+			TrackingLinkTTL:          getEnvOrDefault("TRACKING_LINK_TTL", "168h"),
+			BusinessTimezone:         getEnvOrDefault("BUSINESS_TIMEZONE", "America/Argentina/Buenos_Aires"),
+
+			SubscriptionSchedulerInterval: getEnvOrDefault("SUBSCRIPTION_SCHEDULER_INTERVAL", "1m"),
+			OrderExpiryInterval:           getEnvOrDefault("ORDER_EXPIRY_INTERVAL", "5m"),
+			UnpaidOrderMaxAge:             getEnvOrDefault("UNPAID_ORDER_MAX_AGE", "48h"),
+			WebhookProcessorInterval:      getEnvOrDefault("WEBHOOK_PROCESSOR_INTERVAL", "5s"),
+			PaymentReconciliationInterval: getEnvOrDefault("PAYMENT_RECONCILIATION_INTERVAL", "1h"),
+			PaymentReconciliationLookback: getEnvOrDefault("PAYMENT_RECONCILIATION_LOOKBACK", "72h"),
+			PaymentReconciliationAutoFix:  getEnvOrDefault("PAYMENT_RECONCILIATION_AUTO_FIX", "false"),
+			IdempotencyKeyCleanupInterval: getEnvOrDefault("IDEMPOTENCY_KEY_CLEANUP_INTERVAL", "1h"),
 		}
 	}
 	return instance
@@ -10,6 +10,12 @@ var (
 		Message:    "invalid request body",
 	}
 
+	RequestBodyTooLargeError = ErrorDetails{
+		Code:       "common:request-body-too-large",
+		StatusCode: http.StatusRequestEntityTooLarge,
+		Message:    "request body too large",
+	}
+
 	InternalServerError = ErrorDetails{
 		Code:       "common:internal-server-error",
 		StatusCode: http.StatusInternalServerError,
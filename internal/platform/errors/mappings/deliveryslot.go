@@ -0,0 +1,59 @@
+package mappings
+
+import "net/http"
+
+var (
+	DeliverySlotNotFoundError = ErrorDetails{
+		Code:       "delivery-slot:not-found",
+		StatusCode: http.StatusNotFound,
+		Message:    "delivery slot not found",
+	}
+
+	DeliverySlotCreateError = ErrorDetails{
+		Code:       "delivery-slot:create-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to create delivery slot",
+	}
+
+	DeliverySlotUpdateError = ErrorDetails{
+		Code:       "delivery-slot:update-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to update delivery slot",
+	}
+
+	DeliverySlotDeleteError = ErrorDetails{
+		Code:       "delivery-slot:delete-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to delete delivery slot",
+	}
+
+	DeliverySlotListError = ErrorDetails{
+		Code:       "delivery-slot:list-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to list delivery slots",
+	}
+
+	DeliverySlotInvalidError = ErrorDetails{
+		Code:       "delivery-slot:invalid",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid delivery slot",
+	}
+
+	DeliverySlotUnavailableError = ErrorDetails{
+		Code:       "delivery-slot:unavailable",
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "delivery slot cannot be booked for that day",
+	}
+
+	DeliverySlotFullError = ErrorDetails{
+		Code:       "delivery-slot:full",
+		StatusCode: http.StatusConflict,
+		Message:    "delivery slot is full",
+	}
+
+	DeliverySlotReservationError = ErrorDetails{
+		Code:       "delivery-slot:reservation-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to reserve delivery slot",
+	}
+)
@@ -0,0 +1,23 @@
+package mappings
+
+import "net/http"
+
+var (
+	IdempotencyKeyInvalidError = ErrorDetails{
+		Code:       "idempotency:key-invalid",
+		StatusCode: http.StatusBadRequest,
+		Message:    "Idempotency-Key must be between 1 and 255 characters",
+	}
+
+	IdempotencyKeyReusedError = ErrorDetails{
+		Code:       "idempotency:key-reused",
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "Idempotency-Key was already used for a different request",
+	}
+
+	IdempotencyRequestInProgressError = ErrorDetails{
+		Code:       "idempotency:in-progress",
+		StatusCode: http.StatusConflict,
+		Message:    "a request with this Idempotency-Key is still being processed",
+	}
+)
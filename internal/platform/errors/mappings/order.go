@@ -28,6 +28,84 @@ var (
 		Message:    "invalid order status",
 	}
 
+	OrderInvalidTransitionError = ErrorDetails{
+		Code:       "order:invalid-transition",
+		StatusCode: http.StatusConflict,
+		Message:    "order status transition not allowed",
+	}
+
+	OrderVersionConflictError = ErrorDetails{
+		Code:       "order:version-conflict",
+		StatusCode: http.StatusConflict,
+		Message:    "order was modified by another request",
+	}
+
+	OrderInvalidIfMatchError = ErrorDetails{
+		Code:       "order:invalid-if-match",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid If-Match header",
+	}
+
+	OrderCancelNotAllowedError = ErrorDetails{
+		Code:       "order:cancel-not-allowed",
+		StatusCode: http.StatusConflict,
+		Message:    "order can no longer be cancelled",
+	}
+
+	OrderCancelRequiresEndpointError = ErrorDetails{
+		Code:       "order:cancel-requires-endpoint",
+		StatusCode: http.StatusBadRequest,
+		Message:    "orders must be cancelled through the cancel endpoint",
+	}
+
+	OrderInvalidCancellationReasonError = ErrorDetails{
+		Code:       "order:invalid-cancellation-reason",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid cancellation reason",
+	}
+
+	OrderCancelRefundFailedError = ErrorDetails{
+		Code:       "order:cancel-refund-failed",
+		StatusCode: http.StatusBadGateway,
+		Message:    "refunding the order payments failed, so the order was not cancelled",
+	}
+
+	OrderChangedWhilePayingError = ErrorDetails{
+		Code:       "order:changed-while-paying",
+		StatusCode: http.StatusConflict,
+		Message:    "the order changed while it was being paid, so it was not confirmed; the payment was flagged for a refund",
+	}
+
+	OrderPricingError = ErrorDetails{
+		Code:       "order:pricing-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to price the order items",
+	}
+
+	OrderRefundFailedError = ErrorDetails{
+		Code:       "order:refund-failed",
+		StatusCode: http.StatusBadGateway,
+		Message:    "failed to refund order payment",
+	}
+
+	OrderRefundUnrecordedError = ErrorDetails{
+		Code:       "order:refund-unrecorded",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "the payment was refunded but the refund could not be recorded; the order was flagged for reconciliation",
+	}
+
+	OrderInvalidFilterError = ErrorDetails{
+		Code:       "order:invalid-filter",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid order filter",
+	}
+
+	OrderReorderEmptyError = ErrorDetails{
+		Code:       "order:reorder-empty",
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "order has no items to reorder",
+	}
+
 	OrderInvalidIDError = ErrorDetails{
 		Code:       "order:invalid-id",
 		StatusCode: http.StatusBadRequest,
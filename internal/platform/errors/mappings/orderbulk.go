@@ -0,0 +1,35 @@
+package mappings
+
+import "net/http"
+
+var (
+	OrderBulkFileParseError = ErrorDetails{
+		Code:       "order:bulk:file-parse-error",
+		StatusCode: http.StatusBadRequest,
+		Message:    "failed to parse bulk order file",
+	}
+
+	OrderBulkMissingColumnsError = ErrorDetails{
+		Code:       "order:bulk:missing-columns",
+		StatusCode: http.StatusBadRequest,
+		Message:    "bulk order file needs phone number, item code and quantity columns",
+	}
+
+	OrderBulkEmptyError = ErrorDetails{
+		Code:       "order:bulk:empty",
+		StatusCode: http.StatusBadRequest,
+		Message:    "bulk order file has no order lines",
+	}
+
+	OrderBulkSheetError = ErrorDetails{
+		Code:       "order:bulk:sheet-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to build the claim links sheet",
+	}
+
+	OrderBulkTooLargeError = ErrorDetails{
+		Code:       "order:bulk:too-large",
+		StatusCode: http.StatusRequestEntityTooLarge,
+		Message:    "the file exceeds the upload size or row limit",
+	}
+)
@@ -0,0 +1,29 @@
+package mappings
+
+import "net/http"
+
+var (
+	OrderCommentCreateError = ErrorDetails{
+		Code:       "order:comment:create-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to create order comment",
+	}
+
+	OrderCommentListError = ErrorDetails{
+		Code:       "order:comment:list-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to list order comments",
+	}
+
+	OrderCommentInvalidError = ErrorDetails{
+		Code:       "order:comment:invalid",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid order comment",
+	}
+
+	OrderCommentAttachmentUnavailableError = ErrorDetails{
+		Code:       "order:comment:attachment-unavailable",
+		StatusCode: http.StatusServiceUnavailable,
+		Message:    "comment attachments are not available",
+	}
+)
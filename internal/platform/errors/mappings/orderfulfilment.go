@@ -0,0 +1,23 @@
+package mappings
+
+import "net/http"
+
+var (
+	OrderItemFulfilmentInvalidError = ErrorDetails{
+		Code:       "order:item-fulfilment:invalid",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid item fulfilment",
+	}
+
+	OrderItemFulfilmentNotAllowedError = ErrorDetails{
+		Code:       "order:item-fulfilment:not-allowed",
+		StatusCode: http.StatusConflict,
+		Message:    "items can only be marked while the order is being prepared",
+	}
+
+	OrderItemNotFoundError = ErrorDetails{
+		Code:       "order:item:not-found",
+		StatusCode: http.StatusNotFound,
+		Message:    "order item not found",
+	}
+)
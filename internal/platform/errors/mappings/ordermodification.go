@@ -0,0 +1,59 @@
+package mappings
+
+import "net/http"
+
+var (
+	OrderModificationCreateError = ErrorDetails{
+		Code:       "order:modification:create-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to create modification request",
+	}
+
+	OrderModificationGetError = ErrorDetails{
+		Code:       "order:modification:get-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to get modification request",
+	}
+
+	OrderModificationListError = ErrorDetails{
+		Code:       "order:modification:list-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to list modification requests",
+	}
+
+	OrderModificationUpdateError = ErrorDetails{
+		Code:       "order:modification:update-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to update modification request",
+	}
+
+	OrderModificationNotFoundError = ErrorDetails{
+		Code:       "order:modification:not-found",
+		StatusCode: http.StatusNotFound,
+		Message:    "modification request not found",
+	}
+
+	OrderModificationInvalidError = ErrorDetails{
+		Code:       "order:modification:invalid",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid modification request",
+	}
+
+	OrderModificationPendingError = ErrorDetails{
+		Code:       "order:modification:already-pending",
+		StatusCode: http.StatusConflict,
+		Message:    "order already has a pending modification request",
+	}
+
+	OrderModificationNotPendingError = ErrorDetails{
+		Code:       "order:modification:not-pending",
+		StatusCode: http.StatusConflict,
+		Message:    "modification request has already been reviewed",
+	}
+
+	OrderModificationUnpricedItemError = ErrorDetails{
+		Code:       "order:modification:unpriced-item",
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "the request adds items that are not in the price list",
+	}
+)
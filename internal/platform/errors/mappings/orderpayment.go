@@ -0,0 +1,53 @@
+package mappings
+
+import "net/http"
+
+var (
+	OrderPaymentMethodInvalidError = ErrorDetails{
+		Code:       "order:payment:invalid-method",
+		StatusCode: http.StatusBadRequest,
+		Message:    "payment method must be CARD, CHECKOUT_LINK, CASH or TRANSFER",
+	}
+
+	OrderPaymentOfflineError = ErrorDetails{
+		Code:       "order:payment:offline",
+		StatusCode: http.StatusConflict,
+		Message:    "this order is paid in cash or by transfer",
+	}
+
+	OrderPaymentNotOfflineError = ErrorDetails{
+		Code:       "order:payment:not-offline",
+		StatusCode: http.StatusConflict,
+		Message:    "only cash and transfer payments are confirmed by hand",
+	}
+
+	OrderPaymentProofRequiredError = ErrorDetails{
+		Code:       "order:payment:proof-required",
+		StatusCode: http.StatusBadRequest,
+		Message:    "a transfer needs the key of its uploaded proof",
+	}
+
+	OrderPaymentInvalidAmountError = ErrorDetails{
+		Code:       "order:payment:invalid-amount",
+		StatusCode: http.StatusBadRequest,
+		Message:    "received amount must be greater than zero",
+	}
+
+	OrderPaymentAlreadyReceivedError = ErrorDetails{
+		Code:       "order:payment:already-received",
+		StatusCode: http.StatusConflict,
+		Message:    "the payment of this order was already received",
+	}
+
+	OrderPaymentNotReceivedError = ErrorDetails{
+		Code:       "order:payment:not-received",
+		StatusCode: http.StatusConflict,
+		Message:    "a cash order cannot be delivered before its payment is received",
+	}
+
+	OrderPaymentMethodLockedError = ErrorDetails{
+		Code:       "order:payment:method-locked",
+		StatusCode: http.StatusConflict,
+		Message:    "the payment method cannot change once the order is paid",
+	}
+)
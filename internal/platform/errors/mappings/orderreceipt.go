@@ -0,0 +1,17 @@
+package mappings
+
+import "net/http"
+
+var (
+	OrderReceiptNotAvailableError = ErrorDetails{
+		Code:       "order:receipt:not-available",
+		StatusCode: http.StatusConflict,
+		Message:    "the order has no approved payment to issue a receipt for",
+	}
+
+	OrderReceiptError = ErrorDetails{
+		Code:       "order:receipt:error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to generate receipt",
+	}
+)
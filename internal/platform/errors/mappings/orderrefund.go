@@ -0,0 +1,29 @@
+package mappings
+
+import "net/http"
+
+var (
+	OrderRefundInvalidAmountError = ErrorDetails{
+		Code:       "order:refund:invalid-amount",
+		StatusCode: http.StatusBadRequest,
+		Message:    "refund amount must be greater than zero",
+	}
+
+	OrderRefundReasonRequiredError = ErrorDetails{
+		Code:       "order:refund:reason-required",
+		StatusCode: http.StatusBadRequest,
+		Message:    "a refund reason is required",
+	}
+
+	OrderRefundNothingToRefundError = ErrorDetails{
+		Code:       "order:refund:nothing-to-refund",
+		StatusCode: http.StatusConflict,
+		Message:    "the order has no captured payment left to refund",
+	}
+
+	OrderRefundExceedsCapturedError = ErrorDetails{
+		Code:       "order:refund:exceeds-captured",
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "refund amount exceeds what is left of the captured payments",
+	}
+)
@@ -0,0 +1,17 @@
+package mappings
+
+import "net/http"
+
+var (
+	OrderStatusEventCreateError = ErrorDetails{
+		Code:       "order:status-event:create-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to record order status change",
+	}
+
+	OrderStatusEventListError = ErrorDetails{
+		Code:       "order:status-event:list-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to list order status changes",
+	}
+)
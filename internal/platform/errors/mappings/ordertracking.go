@@ -0,0 +1,17 @@
+package mappings
+
+import "net/http"
+
+var (
+	OrderTrackingTokenInvalidError = ErrorDetails{
+		Code:       "order:tracking-token:invalid",
+		StatusCode: http.StatusNotFound,
+		Message:    "tracking link not found",
+	}
+
+	OrderTrackingTokenExpiredError = ErrorDetails{
+		Code:       "order:tracking-token:expired",
+		StatusCode: http.StatusGone,
+		Message:    "tracking link has expired",
+	}
+)
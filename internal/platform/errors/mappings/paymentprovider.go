@@ -0,0 +1,23 @@
+package mappings
+
+import "net/http"
+
+var (
+	PaymentProviderNotFoundError = ErrorDetails{
+		Code:       "payment:provider:not-found",
+		StatusCode: http.StatusNotFound,
+		Message:    "payment provider not found",
+	}
+
+	PaymentProviderInvalidError = ErrorDetails{
+		Code:       "payment:provider:invalid",
+		StatusCode: http.StatusBadRequest,
+		Message:    "unknown payment provider",
+	}
+
+	PaymentProviderUnavailableError = ErrorDetails{
+		Code:       "payment:provider:unavailable",
+		StatusCode: http.StatusServiceUnavailable,
+		Message:    "the payment provider of this order is not available",
+	}
+)
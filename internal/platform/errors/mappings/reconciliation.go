@@ -0,0 +1,11 @@
+package mappings
+
+import "net/http"
+
+var (
+	PaymentReconciliationInvalidInputError = ErrorDetails{
+		Code:       "payment:reconciliation:invalid-input",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid reconciliation parameters",
+	}
+)
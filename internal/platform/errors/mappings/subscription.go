@@ -0,0 +1,53 @@
+package mappings
+
+import "net/http"
+
+var (
+	SubscriptionNotFoundError = ErrorDetails{
+		Code:       "subscription:not-found",
+		StatusCode: http.StatusNotFound,
+		Message:    "subscription not found",
+	}
+
+	SubscriptionCreateError = ErrorDetails{
+		Code:       "subscription:create-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to create subscription",
+	}
+
+	SubscriptionUpdateError = ErrorDetails{
+		Code:       "subscription:update-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to update subscription",
+	}
+
+	SubscriptionListError = ErrorDetails{
+		Code:       "subscription:list-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to list subscriptions",
+	}
+
+	SubscriptionInvalidError = ErrorDetails{
+		Code:       "subscription:invalid",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid subscription",
+	}
+
+	SubscriptionInvalidIDError = ErrorDetails{
+		Code:       "subscription:invalid-id",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid subscription ID format",
+	}
+
+	SubscriptionStatusConflictError = ErrorDetails{
+		Code:       "subscription:status-conflict",
+		StatusCode: http.StatusConflict,
+		Message:    "subscription cannot change to that status",
+	}
+
+	SubscriptionRunCreateError = ErrorDetails{
+		Code:       "subscription:run:create-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to record subscription run",
+	}
+)
@@ -26,4 +26,10 @@ var (
 		StatusCode: http.StatusInternalServerError,
 		Message:    "failed to list transactions",
 	}
+
+	TransactionUpdateError = ErrorDetails{
+		Code:       "transaction:update-error",
+		StatusCode: http.StatusInternalServerError,
+		Message:    "failed to update transaction",
+	}
 )
@@ -0,0 +1,35 @@
+package mappings
+
+import "net/http"
+
+var (
+	WebhookSignatureInvalidError = ErrorDetails{
+		Code:       "webhook:signature-invalid",
+		StatusCode: http.StatusUnauthorized,
+		Message:    "invalid webhook signature",
+	}
+
+	WebhookGatewayError = ErrorDetails{
+		Code:       "webhook:gateway-error",
+		StatusCode: http.StatusBadGateway,
+		Message:    "could not fetch the notified resource from the payment gateway",
+	}
+
+	WebhookEventNotFoundError = ErrorDetails{
+		Code:       "webhook:event-not-found",
+		StatusCode: http.StatusNotFound,
+		Message:    "webhook event not found",
+	}
+
+	WebhookEventInvalidFilterError = ErrorDetails{
+		Code:       "webhook:invalid-filter",
+		StatusCode: http.StatusBadRequest,
+		Message:    "invalid webhook event filter",
+	}
+
+	WebhookEventReplayNotAllowedError = ErrorDetails{
+		Code:       "webhook:replay-not-allowed",
+		StatusCode: http.StatusConflict,
+		Message:    "webhook event is already queued for processing",
+	}
+)
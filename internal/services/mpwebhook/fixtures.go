@@ -0,0 +1,26 @@
+package mpwebhook
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sign returns an x-signature header value for a notification, signed the way
+// MercadoPago signs it. It lets tests and local tools build valid webhook requests.
+func Sign(secret, dataID, requestID string, signedAt time.Time) string {
+	ts := strconv.FormatInt(signedAt.UnixMilli(), 10)
+	hash := hex.EncodeToString(computeHMAC(secret, Manifest(dataID, requestID, ts)))
+	return "ts=" + ts + ",v1=" + hash
+}
+
+// SignRequest adds a fresh x-request-id and a matching x-signature to a fixture
+// webhook request. dataID must be the data.id the request notifies about.
+func SignRequest(req *http.Request, secret, dataID string) {
+	requestID := uuid.New().String()
+	req.Header.Set(RequestIDHeader, requestID)
+	req.Header.Set(SignatureHeader, Sign(secret, dataID, requestID, time.Now()))
+}
@@ -0,0 +1,123 @@
+package mpwebhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MercadoPago signs webhook notifications with these headers
+const (
+	SignatureHeader = "x-signature"
+	RequestIDHeader = "x-request-id"
+)
+
+var (
+	ErrMissingSignature    = errors.New("missing x-signature header")
+	ErrMalformedSignature  = errors.New("malformed x-signature header")
+	ErrTimestampOutOfRange = errors.New("signature timestamp outside tolerance")
+	ErrSignatureMismatch   = errors.New("signature does not match")
+)
+
+// Verifier checks MercadoPago's webhook signature: an HMAC-SHA256 of a manifest built
+// from the notified resource ID, the x-request-id header and the signing timestamp,
+// keyed with the secret shown for the application's webhooks.
+type Verifier struct {
+	secret    string
+	tolerance time.Duration
+	now       func() time.Time
+}
+
+// NewVerifier creates a verifier that accepts signatures made within tolerance of now
+func NewVerifier(secret string, tolerance time.Duration) *Verifier {
+	return &Verifier{secret: secret, tolerance: tolerance, now: time.Now}
+}
+
+// Verify checks the x-signature header value against the request ID and the
+// notified resource ID (the data.id query parameter)
+func (v *Verifier) Verify(signature, requestID, dataID string) error {
+	if strings.TrimSpace(signature) == "" {
+		return ErrMissingSignature
+	}
+
+	ts, hash, err := parseSignature(signature)
+	if err != nil {
+		return err
+	}
+
+	signedAt, err := parseTimestamp(ts)
+	if err != nil {
+		return err
+	}
+	if age := v.now().Sub(signedAt); age > v.tolerance || age < -v.tolerance {
+		return fmt.Errorf("%w: signed at %s", ErrTimestampOutOfRange, signedAt.UTC().Format(time.RFC3339))
+	}
+
+	expected := computeHMAC(v.secret, Manifest(dataID, requestID, ts))
+	given, err := hex.DecodeString(hash)
+	if err != nil || !hmac.Equal(given, expected) {
+		return ErrSignatureMismatch
+	}
+
+	return nil
+}
+
+// Manifest builds the string MercadoPago signs. Parts that are not present in the
+// notification are left out, and alphanumeric resource IDs are lowercased.
+func Manifest(dataID, requestID, ts string) string {
+	var b strings.Builder
+	if dataID != "" {
+		b.WriteString("id:" + strings.ToLower(dataID) + ";")
+	}
+	if requestID != "" {
+		b.WriteString("request-id:" + requestID + ";")
+	}
+	if ts != "" {
+		b.WriteString("ts:" + ts + ";")
+	}
+	return b.String()
+}
+
+// parseSignature reads "ts=...,v1=..." in any order
+func parseSignature(signature string) (ts, hash string, err error) {
+	for _, part := range strings.Split(signature, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "ts":
+			ts = value
+		case "v1":
+			hash = value
+		}
+	}
+	if ts == "" || hash == "" {
+		return "", "", ErrMalformedSignature
+	}
+	return ts, hash, nil
+}
+
+// parseTimestamp accepts the timestamp in seconds or, as MercadoPago sends it
+// for newer applications, in milliseconds
+func parseTimestamp(ts string) (time.Time, error) {
+	value, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return time.Time{}, ErrMalformedSignature
+	}
+	if value > 1e12 {
+		return time.UnixMilli(value), nil
+	}
+	return time.Unix(value, 0), nil
+}
+
+func computeHMAC(secret, manifest string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(manifest))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,135 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Page size in points (A4)
+const (
+	PageWidth  = 595.0
+	PageHeight = 842.0
+)
+
+// Font selects one of the standard PDF fonts, which need no embedding
+type Font string
+
+const (
+	Helvetica     Font = "F1"
+	HelveticaBold Font = "F2"
+	// Courier is monospaced, which makes right-aligned columns exact
+	Courier Font = "F3"
+)
+
+var fontNames = map[Font]string{
+	Helvetica:     "Helvetica",
+	HelveticaBold: "Helvetica-Bold",
+	Courier:       "Courier",
+}
+
+// courierAdvance is the width of every Courier glyph as a fraction of the font size
+const courierAdvance = 0.6
+
+// Document is a minimal text-and-lines PDF writer. Coordinates are in points
+// from the top-left corner of the page.
+type Document struct {
+	pages []*bytes.Buffer
+}
+
+// New creates a document with one empty page
+func New() *Document {
+	d := &Document{}
+	d.AddPage()
+	return d
+}
+
+// AddPage starts a new page; later drawing goes to it
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+func (d *Document) current() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// Text draws a single line of text with its baseline at y
+func (d *Document) Text(x, y float64, font Font, size float64, text string) {
+	fmt.Fprintf(d.current(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, PageHeight-y, escape(text))
+}
+
+// TextRight draws Courier text so that it ends at x
+func (d *Document) TextRight(x, y float64, size float64, text string) {
+	width := float64(len([]rune(text))) * size * courierAdvance
+	d.Text(x-width, y, Courier, size, text)
+}
+
+// Line draws a thin horizontal or diagonal rule
+func (d *Document) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(d.current(), "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+// Bytes renders the document as a PDF file
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	writeObject := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// Objects 1-3 are the fonts, 4 is the catalog, 5 the page tree, then a page and its content per page
+	fonts := []Font{Helvetica, HelveticaBold, Courier}
+	for _, f := range fonts {
+		writeObject(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", fontNames[f]))
+	}
+	writeObject("<< /Type /Catalog /Pages 5 0 R >>")
+
+	firstPage := 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+i*2)
+	}
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+
+	resources := "<< /Font << /F1 1 0 R /F2 2 0 R /F3 3 0 R >> >>"
+	for i, content := range d.pages {
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 5 0 R /MediaBox [0 0 %.0f %.0f] /Resources %s /Contents %d 0 R >>",
+			PageWidth, PageHeight, resources, firstPage+i*2+1))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 4 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// escape encodes text as a WinAnsi PDF string literal. Latin-1 characters such as
+// accented vowels and ñ map directly; anything else becomes '?'.
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '€':
+			b.WriteString(`\200`)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, `\%03o`, r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -1,6 +1,7 @@
 package s3
 
 import (
+	"bytes"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -134,6 +135,98 @@ func (c *Client) DeleteObject(key string) error {
 	return nil
 }
 
+// ObjectExists reports whether an object is stored under key.
+func (c *Client) ObjectExists(key string) (bool, error) {
+	now := time.Now().UTC()
+	datetime := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	host := fmt.Sprintf("%s.s3.%s.amazonaws.com", c.bucket, c.region)
+	encodedKey := "/" + pathEscape(key)
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, c.region)
+	payloadHash := sha256Hex("")
+
+	canonicalHeaders := "host:" + host + "\nx-amz-content-sha256:" + payloadHash + "\nx-amz-date:" + datetime + "\n"
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{"HEAD", encodedKey, "", canonicalHeaders, signedHeaders, payloadHash}, "\n")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", datetime, scope, sha256Hex(canonicalRequest)}, "\n")
+
+	signingKey := hmacSHA256(hmacSHA256(hmacSHA256(hmacSHA256([]byte("AWS4"+c.secretKey), date), c.region), "s3"), "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+	auth := fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", c.accessKey, scope, signedHeaders, signature)
+
+	req, err := http.NewRequest("HEAD", fmt.Sprintf("https://%s%s", host, encodedKey), nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Host", host)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+	req.Header.Set("x-amz-date", datetime)
+	req.Header.Set("Authorization", auth)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("s3 head failed: %d", resp.StatusCode)
+	}
+}
+
+// PublicURL returns the public URL of the object stored under key.
+func (c *Client) PublicURL(key string) string {
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", c.bucket, c.region, pathEscape(key))
+}
+
+// PutObject uploads content to S3 under key and returns its public URL.
+func (c *Client) PutObject(key, contentType string, body []byte) (string, error) {
+	now := time.Now().UTC()
+	datetime := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	host := fmt.Sprintf("%s.s3.%s.amazonaws.com", c.bucket, c.region)
+	encodedKey := "/" + pathEscape(key)
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, c.region)
+	payloadHash := sha256Hex(string(body))
+
+	canonicalHeaders := "content-type:" + contentType + "\nhost:" + host + "\nx-amz-content-sha256:" + payloadHash + "\nx-amz-date:" + datetime + "\n"
+	signedHeaders := "content-type;host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{"PUT", encodedKey, "", canonicalHeaders, signedHeaders, payloadHash}, "\n")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", datetime, scope, sha256Hex(canonicalRequest)}, "\n")
+
+	signingKey := hmacSHA256(hmacSHA256(hmacSHA256(hmacSHA256([]byte("AWS4"+c.secretKey), date), c.region), "s3"), "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+	auth := fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", c.accessKey, scope, signedHeaders, signature)
+
+	publicURL := fmt.Sprintf("https://%s%s", host, encodedKey)
+	req, err := http.NewRequest("PUT", publicURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Host", host)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+	req.Header.Set("x-amz-date", datetime)
+	req.Header.Set("Authorization", auth)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("s3 put failed: %d %s", resp.StatusCode, string(respBody))
+	}
+	return publicURL, nil
+}
+
 func hmacSHA256(key []byte, data string) []byte {
 	h := hmac.New(sha256.New, key)
 	h.Write([]byte(data))
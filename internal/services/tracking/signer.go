@@ -0,0 +1,85 @@
+package tracking
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidToken is returned for tokens that are malformed or were not signed by this signer
+	ErrInvalidToken = errors.New("invalid tracking token")
+	// ErrExpiredToken is returned for correctly signed tokens past their expiry
+	ErrExpiredToken = errors.New("tracking token expired")
+)
+
+// Signer issues and verifies stateless tracking tokens. A token carries the order ID
+// and its expiry, signed with HMAC-SHA256, so nothing has to be stored per link.
+type Signer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewSigner creates a signer whose tokens are valid for ttl. With an empty secret a
+// random one is generated, which means links stop working when the process restarts
+// and are only valid on the replica that issued them.
+func NewSigner(secret string, ttl time.Duration) *Signer {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		_, _ = rand.Read(key)
+	}
+	return &Signer{secret: key, ttl: ttl}
+}
+
+// Sign returns a token for the order that expires ttl after now
+func (s *Signer) Sign(orderID string, now time.Time) (string, time.Time) {
+	expiresAt := now.Add(s.ttl).Truncate(time.Second)
+	payload := orderID + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	signature := base64.RawURLEncoding.EncodeToString(s.sign(payload))
+	return encoded + "." + signature, expiresAt
+}
+
+// Verify checks the token's signature and expiry and returns the order ID it was issued for
+func (s *Signer) Verify(token string, now time.Time) (string, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, s.sign(string(payload))) {
+		return "", ErrInvalidToken
+	}
+
+	orderID, expiry, ok := strings.Cut(string(payload), ".")
+	if !ok {
+		return "", ErrInvalidToken
+	}
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	if now.Unix() > expiresAt {
+		return "", ErrExpiredToken
+	}
+
+	return orderID, nil
+}
+
+func (s *Signer) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,58 @@
+package admin
+
+import (
+	"context"
+
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// GetNextStatusesOutput represents the statuses an order can move to
+type GetNextStatusesOutput struct {
+	OrderID      string   `json:"order_id"`
+	Status       string   `json:"status"`
+	NextStatuses []string `json:"next_statuses"`
+}
+
+// GetNextStatusesUsecase defines the interface for getting the allowed next statuses of an order
+type GetNextStatusesUsecase interface {
+	Execute(ctx context.Context, id string) (*GetNextStatusesOutput, apperrors.ApplicationError)
+}
+
+type getNextStatusesUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewGetNextStatusesUsecase creates a new instance of GetNextStatusesUsecase
+func NewGetNextStatusesUsecase(contextFactory appcontext.Factory) GetNextStatusesUsecase {
+	return &getNextStatusesUsecase{contextFactory: contextFactory}
+}
+
+// Execute returns the current status of an order and the statuses it can move to
+func (u *getNextStatusesUsecase) Execute(ctx context.Context, id string) (*GetNextStatusesOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	next := order.NextStatuses()
+	output := &GetNextStatusesOutput{
+		OrderID:      order.ID,
+		Status:       string(order.Status),
+		NextStatuses: make([]string, len(next)),
+	}
+	for i, s := range next {
+		output.NextStatuses[i] = string(s)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"context"
+
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+)
+
+// GetTransactionOutput represents a transaction with its status history
+type GetTransactionOutput struct {
+	Transaction TransactionOutput              `json:"transaction"`
+	History     []TransactionStatusEventOutput `json:"history"`
+}
+
+// GetTransactionUsecase defines the interface for inspecting a transaction
+type GetTransactionUsecase interface {
+	Execute(ctx context.Context, transactionID string) (*GetTransactionOutput, apperrors.ApplicationError)
+}
+
+type getTransactionUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewGetTransactionUsecase creates a new instance of GetTransactionUsecase
+func NewGetTransactionUsecase(contextFactory appcontext.Factory) GetTransactionUsecase {
+	return &getTransactionUsecase{contextFactory: contextFactory}
+}
+
+// Execute returns a transaction and every status it went through, oldest first
+func (u *getTransactionUsecase) Execute(ctx context.Context, transactionID string) (*GetTransactionOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	transaction, err := app.Repositories.Transaction.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := app.Repositories.Transaction.ListStatusEvents(ctx, transaction.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &GetTransactionOutput{
+		Transaction: toTransactionOutput(transaction),
+		History:     make([]TransactionStatusEventOutput, 0, len(events)),
+	}
+	for _, e := range events {
+		output.History = append(output.History, toTransactionStatusEventOutput(e))
+	}
+
+	return output, nil
+}
@@ -2,20 +2,47 @@ package admin
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
 	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
 )
 
+// ListOrdersInput represents the filters for listing orders, as received in the query string.
+// Dates accept RFC 3339 or YYYY-MM-DD; a date-only "to" includes that whole day.
+type ListOrdersInput struct {
+	Statuses  []string
+	From      string
+	To        string
+	ProfileID string
+	UserID    string
+	Phone     string
+	MinAmount string
+	MaxAmount string
+	Search    string
+	Flagged   bool
+	Sort      string
+	Cursor    string
+	Limit     int
+}
+
 // ListOrdersOutput represents the output for listing orders
 type ListOrdersOutput struct {
-	Orders []OrderOutput `json:"orders"`
-	Total  int           `json:"total"`
+	Orders     []OrderOutput `json:"orders"`
+	Total      int           `json:"total"`
+	NextCursor *string       `json:"next_cursor,omitempty"`
 }
 
 // ListOrdersUsecase defines the interface for listing orders
 type ListOrdersUsecase interface {
-	Execute(ctx context.Context) (*ListOrdersOutput, apperrors.ApplicationError)
+	Execute(ctx context.Context, input ListOrdersInput) (*ListOrdersOutput, apperrors.ApplicationError)
 }
 
 type listOrdersUsecase struct {
@@ -27,23 +54,113 @@ func NewListOrdersUsecase(contextFactory appcontext.Factory) ListOrdersUsecase {
 	return &listOrdersUsecase{contextFactory: contextFactory}
 }
 
-// Execute lists all orders
-func (u *listOrdersUsecase) Execute(ctx context.Context) (*ListOrdersOutput, apperrors.ApplicationError) {
+// Execute lists a page of orders matching the filters
+func (u *listOrdersUsecase) Execute(ctx context.Context, input ListOrdersInput) (*ListOrdersOutput, apperrors.ApplicationError) {
 	app := u.contextFactory()
 
-	orders, err := app.Repositories.Order.GetAll(ctx)
+	filter, filterErr := buildOrderFilter(input)
+	if filterErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidFilterError, filterErr)
+	}
+
+	page, err := app.Repositories.Order.List(ctx, *filter)
 	if err != nil {
 		return nil, err
 	}
 
 	output := &ListOrdersOutput{
-		Orders: make([]OrderOutput, 0, len(orders)),
-		Total:  len(orders),
+		Orders:     make([]OrderOutput, 0, len(page.Orders)),
+		Total:      page.Total,
+		NextCursor: page.NextCursor,
 	}
 
-	for _, o := range orders {
+	for _, o := range page.Orders {
 		output.Orders = append(output.Orders, toOrderOutput(o))
 	}
 
 	return output, nil
 }
+
+// buildOrderFilter validates the raw input and converts it into a domain filter
+func buildOrderFilter(input ListOrdersInput) (*domain.OrderFilter, error) {
+	filter := &domain.OrderFilter{
+		ProfileID: strings.TrimSpace(input.ProfileID),
+		UserID:    strings.TrimSpace(input.UserID),
+		Phone:     strings.TrimSpace(input.Phone),
+		Search:    strings.TrimSpace(input.Search),
+		Flagged:   input.Flagged,
+		Cursor:    input.Cursor,
+		Limit:     input.Limit,
+	}
+
+	if filter.ProfileID != "" {
+		if _, err := uuid.Parse(filter.ProfileID); err != nil {
+			return nil, fmt.Errorf("invalid profile_id: %w", err)
+		}
+	}
+
+	for _, s := range input.Statuses {
+		s = strings.ToUpper(strings.TrimSpace(s))
+		if s == "" {
+			continue
+		}
+		if !domain.IsValidStatus(s) {
+			return nil, fmt.Errorf("unknown status %q", s)
+		}
+		filter.Statuses = append(filter.Statuses, domain.OrderStatus(s))
+	}
+
+	if input.From != "" {
+		from, _, err := parseFilterDate(input.From)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from date: %w", err)
+		}
+		filter.CreatedFrom = &from
+	}
+	if input.To != "" {
+		to, dateOnly, err := parseFilterDate(input.To)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to date: %w", err)
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.CreatedTo = &to
+	}
+
+	if input.MinAmount != "" {
+		amount, err := strconv.ParseFloat(input.MinAmount, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid min_amount: %w", err)
+		}
+		filter.MinAmount = &amount
+	}
+	if input.MaxAmount != "" {
+		amount, err := strconv.ParseFloat(input.MaxAmount, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_amount: %w", err)
+		}
+		filter.MaxAmount = &amount
+	}
+
+	if input.Sort != "" {
+		if !domain.IsValidOrderSort(input.Sort) {
+			return nil, fmt.Errorf("unknown sort %q", input.Sort)
+		}
+		filter.Sort = domain.OrderSort(input.Sort)
+	}
+
+	return filter, nil
+}
+
+// parseFilterDate parses an RFC 3339 timestamp or a YYYY-MM-DD date and reports which one it was
+func parseFilterDate(s string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, false, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return t, true, nil
+}
@@ -0,0 +1,108 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// ListSlotBookingsInput represents the day to show slot bookings for
+type ListSlotBookingsInput struct {
+	Date string // YYYY-MM-DD, defaults to today
+}
+
+// SlotBookingsOutput represents a delivery slot and the orders booked into it on a day
+type SlotBookingsOutput struct {
+	Slot      *domain.DeliverySlot `json:"slot"`
+	StartsAt  time.Time            `json:"starts_at"`
+	EndsAt    time.Time            `json:"ends_at"`
+	Capacity  int                  `json:"capacity"`
+	Booked    int                  `json:"booked"`
+	Remaining int                  `json:"remaining"`
+	Orders    []OrderOutput        `json:"orders"`
+}
+
+// ListSlotBookingsOutput represents the bookings of every slot running on a day
+type ListSlotBookingsOutput struct {
+	Date  string               `json:"date"`
+	Slots []SlotBookingsOutput `json:"slots"`
+}
+
+// ListSlotBookingsUsecase defines the interface for the per-slot view of booked orders
+type ListSlotBookingsUsecase interface {
+	Execute(ctx context.Context, input ListSlotBookingsInput) (*ListSlotBookingsOutput, apperrors.ApplicationError)
+}
+
+type listSlotBookingsUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewListSlotBookingsUsecase creates a new instance of ListSlotBookingsUsecase
+func NewListSlotBookingsUsecase(contextFactory appcontext.Factory) ListSlotBookingsUsecase {
+	return &listSlotBookingsUsecase{contextFactory: contextFactory}
+}
+
+// Execute lists every slot that runs on the day, including inactive ones that still hold bookings,
+// with the orders booked into it
+func (u *listSlotBookingsUsecase) Execute(ctx context.Context, input ListSlotBookingsInput) (*ListSlotBookingsOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	day := domain.BusinessToday()
+	if input.Date != "" {
+		parsed, parseErr := domain.ParseDeliveryDate(input.Date)
+		if parseErr != nil {
+			return nil, apperrors.NewApplicationError(mappings.DeliverySlotInvalidError, parseErr)
+		}
+		day = parsed
+	}
+
+	slots, err := app.Repositories.DeliverySlot.List(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	bookings, err := app.Repositories.DeliverySlot.CountBookings(ctx, day, day)
+	if err != nil {
+		return nil, err
+	}
+
+	dateKey := day.Format(domain.DeliveryDateLayout)
+	output := &ListSlotBookingsOutput{Date: dateKey, Slots: []SlotBookingsOutput{}}
+	for _, slot := range slots {
+		if slot.Weekday != day.Weekday() {
+			continue
+		}
+		booked := bookings[slot.ID][dateKey]
+		if !slot.Active && booked == 0 {
+			continue
+		}
+
+		orders, err := app.Repositories.Order.ListByDeliverySlot(ctx, slot.ID, day)
+		if err != nil {
+			return nil, err
+		}
+
+		remaining := slot.Capacity - booked
+		if remaining < 0 {
+			remaining = 0
+		}
+		entry := SlotBookingsOutput{
+			Slot:      slot,
+			StartsAt:  slot.StartsAt(day),
+			EndsAt:    slot.EndsAt(day),
+			Capacity:  slot.Capacity,
+			Booked:    booked,
+			Remaining: remaining,
+			Orders:    make([]OrderOutput, 0, len(orders)),
+		}
+		for _, o := range orders {
+			entry.Orders = append(entry.Orders, toOrderOutput(o))
+		}
+		output.Slots = append(output.Slots, entry)
+	}
+
+	return output, nil
+}
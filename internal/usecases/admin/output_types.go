@@ -1,6 +1,8 @@
 package admin
 
 import (
+	"time"
+
 	"yego/internal/domain"
 )
 
@@ -54,17 +56,29 @@ func toCouponOutput(c *domain.Coupon) *CouponOutput {
 
 // OrderOutput represents an order in the admin list
 type OrderOutput struct {
-	ID            string            `json:"id"`
-	ProfileID     *string           `json:"profile_id,omitempty"`
-	UserID        *string           `json:"user_id,omitempty"`
-	Status        string            `json:"status"`
-	StatusMessage *string           `json:"status_message,omitempty"`
-	StatusIndex   int               `json:"status_index"`
-	ETA           string            `json:"eta"`
-	Data          *domain.OrderData `json:"data,omitempty"`
-	CreatedAt     string            `json:"created_at"`
-	UpdatedAt     string            `json:"updated_at"`
-	AllStatuses   []string          `json:"all_statuses"`
+	ID                         string                     `json:"id"`
+	ProfileID                  *string                    `json:"profile_id,omitempty"`
+	UserID                     *string                    `json:"user_id,omitempty"`
+	Status                     string                     `json:"status"`
+	StatusMessage              *string                    `json:"status_message,omitempty"`
+	StatusIndex                int                        `json:"status_index"`
+	ETA                        string                     `json:"eta"`
+	EstimatedDeliveryAt        *time.Time                 `json:"estimated_delivery_at,omitempty"`
+	EstimatedDeliveryWindowEnd *time.Time                 `json:"estimated_delivery_window_end,omitempty"`
+	Data                       *domain.OrderData          `json:"data,omitempty"`
+	Version                    int                        `json:"version"`
+	CancellationReason         *domain.CancellationReason `json:"cancellation_reason,omitempty"`
+	DeliverySlotID             *string                    `json:"delivery_slot_id,omitempty"`
+	DeliveryDate               *string                    `json:"delivery_date,omitempty"`
+	PriceBreakdown             *domain.PriceBreakdown     `json:"price_breakdown,omitempty"`
+	PaymentFlag                *domain.PaymentFlag        `json:"payment_flag,omitempty"`
+	PaymentFlaggedAt           *time.Time                 `json:"payment_flagged_at,omitempty"`
+	PaymentProvider            *string                    `json:"payment_provider,omitempty"`
+	PaymentMethod              *domain.PaymentMethod      `json:"payment_method,omitempty"`
+	PaymentReceivedAt          *time.Time                 `json:"payment_received_at,omitempty"`
+	CreatedAt                  string                     `json:"created_at"`
+	UpdatedAt                  string                     `json:"updated_at"`
+	AllStatuses                []string                   `json:"all_statuses"`
 }
 
 // ProfileOutput represents a profile in the admin list
@@ -87,19 +101,34 @@ type LocationOutput struct {
 
 // TransactionOutput represents a transaction in the admin list
 type TransactionOutput struct {
-	ID               string  `json:"id"`
-	OrderID          string  `json:"order_id"`
-	UserID           string  `json:"user_id"`
-	ProfileID        *string `json:"profile_id,omitempty"`
-	Amount           float64 `json:"amount"`
-	Currency         string  `json:"currency"`
-	Status           string  `json:"status"`
-	PaymentID        *int    `json:"payment_id,omitempty"`
-	GatewayPaymentID *string `json:"gateway_payment_id,omitempty"`
-	CollectorID      *string `json:"collector_id,omitempty"`
-	Description      *string `json:"description,omitempty"`
-	CreatedAt        string  `json:"created_at"`
-	UpdatedAt        string  `json:"updated_at"`
+	ID                  string  `json:"id"`
+	OrderID             string  `json:"order_id"`
+	UserID              string  `json:"user_id"`
+	ProfileID           *string `json:"profile_id,omitempty"`
+	Type                string  `json:"type"`
+	Provider            string  `json:"provider"`
+	ParentTransactionID *string `json:"parent_transaction_id,omitempty"`
+	Amount              float64 `json:"amount"`
+	Currency            string  `json:"currency"`
+	Status              string  `json:"status"`
+	StatusDetail        *string `json:"status_detail,omitempty"`
+	PaymentID           *int    `json:"payment_id,omitempty"`
+	GatewayPaymentID    *string `json:"gateway_payment_id,omitempty"`
+	CollectorID         *string `json:"collector_id,omitempty"`
+	Description         *string `json:"description,omitempty"`
+	ConfirmedByUserID   *string `json:"confirmed_by_user_id,omitempty"`
+	ProofKey            *string `json:"proof_key,omitempty"`
+	CreatedAt           string  `json:"created_at"`
+	UpdatedAt           string  `json:"updated_at"`
+}
+
+// TransactionStatusEventOutput represents a single status change of a transaction
+type TransactionStatusEventOutput struct {
+	FromStatus   *string `json:"from_status,omitempty"`
+	ToStatus     string  `json:"to_status"`
+	StatusDetail *string `json:"status_detail,omitempty"`
+	Source       string  `json:"source"`
+	CreatedAt    string  `json:"created_at"`
 }
 
 // toOrderOutput converts a domain order to output
@@ -109,36 +138,70 @@ func toOrderOutput(order *domain.Order) OrderOutput {
 		allStatuses[i] = string(s)
 	}
 
-	return OrderOutput{
-		ID:            order.ID,
-		ProfileID:     order.ProfileID,
-		UserID:        order.UserID,
-		Status:        string(order.Status),
-		StatusMessage: order.StatusMessage,
-		StatusIndex:   order.StatusIndex(),
-		ETA:           order.ETA,
-		Data:          order.Data,
-		CreatedAt:     order.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt:     order.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-		AllStatuses:   allStatuses,
+	output := OrderOutput{
+		ID:                         order.ID,
+		ProfileID:                  order.ProfileID,
+		UserID:                     order.UserID,
+		Status:                     string(order.Status),
+		StatusMessage:              order.StatusMessage,
+		StatusIndex:                order.StatusIndex(),
+		ETA:                        order.ETA,
+		Data:                       order.Data,
+		Version:                    order.Version,
+		EstimatedDeliveryAt:        order.EstimatedDeliveryAt,
+		EstimatedDeliveryWindowEnd: order.EstimatedDeliveryWindowEnd,
+		CancellationReason:         order.CancellationReason,
+		CreatedAt:                  order.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:                  order.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		AllStatuses:                allStatuses,
+		DeliverySlotID:             order.DeliverySlotID,
+		PriceBreakdown:             order.PriceBreakdown,
+		PaymentFlag:                order.PaymentFlag,
+		PaymentFlaggedAt:           order.PaymentFlaggedAt,
+		PaymentProvider:            order.PaymentProvider,
+		PaymentMethod:              order.PaymentMethod,
+		PaymentReceivedAt:          order.PaymentReceivedAt,
+	}
+	if order.DeliveryDate != nil {
+		deliveryDate := order.DeliveryDate.Format(domain.DeliveryDateLayout)
+		output.DeliveryDate = &deliveryDate
 	}
+
+	return output
 }
 
 // toTransactionOutput converts a domain transaction to output
 func toTransactionOutput(transaction *domain.Transaction) TransactionOutput {
 	return TransactionOutput{
-		ID:               transaction.ID,
-		OrderID:          transaction.OrderID,
-		UserID:           transaction.UserID,
-		ProfileID:        transaction.ProfileID,
-		Amount:           transaction.Amount,
-		Currency:         transaction.Currency,
-		Status:           transaction.Status,
-		PaymentID:        transaction.PaymentID,
-		GatewayPaymentID: transaction.GatewayPaymentID,
-		CollectorID:      transaction.CollectorID,
-		Description:      transaction.Description,
-		CreatedAt:        transaction.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt:        transaction.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		ID:                  transaction.ID,
+		OrderID:             transaction.OrderID,
+		UserID:              transaction.UserID,
+		ProfileID:           transaction.ProfileID,
+		Type:                string(transaction.Type),
+		Provider:            transaction.Provider,
+		ParentTransactionID: transaction.ParentTransactionID,
+		Amount:              transaction.Amount,
+		Currency:            transaction.Currency,
+		Status:              transaction.Status,
+		StatusDetail:        transaction.StatusDetail,
+		PaymentID:           transaction.PaymentID,
+		GatewayPaymentID:    transaction.GatewayPaymentID,
+		CollectorID:         transaction.CollectorID,
+		Description:         transaction.Description,
+		ConfirmedByUserID:   transaction.ConfirmedByUserID,
+		ProofKey:            transaction.ProofKey,
+		CreatedAt:           transaction.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:           transaction.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// toTransactionStatusEventOutput converts a domain transaction status event to output
+func toTransactionStatusEventOutput(event *domain.TransactionStatusEvent) TransactionStatusEventOutput {
+	return TransactionStatusEventOutput{
+		FromStatus:   event.FromStatus,
+		ToStatus:     event.ToStatus,
+		StatusDetail: event.StatusDetail,
+		Source:       event.Source,
+		CreatedAt:    event.CreatedAt.Format("2006-01-02T15:04:05Z"),
 	}
 }
@@ -2,11 +2,16 @@ package admin
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
 
 	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
+	orderUsecase "yego/internal/usecases/order"
 	settingsUsecase "yego/internal/usecases/settings"
 
 	"github.com/google/uuid"
@@ -14,14 +19,21 @@ import (
 
 // UpdateOrderInput represents the input for updating an order
 type UpdateOrderInput struct {
-	Status        *string           `json:"status,omitempty"`
-	StatusMessage *string           `json:"status_message,omitempty"`
-	ETA           *string           `json:"eta,omitempty"`
-	Data          *domain.OrderData `json:"data,omitempty"`
-	Token         string            `json:"-"`
+	Status        *string `json:"status,omitempty"`
+	StatusMessage *string `json:"status_message,omitempty"`
+	ETA           *string `json:"eta,omitempty"`
+	// EstimatedDeliveryAt overrides the computed estimate; the display ETA is derived from it
+	EstimatedDeliveryAt        *time.Time        `json:"estimated_delivery_at,omitempty"`
+	EstimatedDeliveryWindowEnd *time.Time        `json:"estimated_delivery_window_end,omitempty"`
+	Data                       *domain.OrderData `json:"data,omitempty"`
+	Token                      string            `json:"-"`
+	UserID                     string            `json:"-"`
+	// ExpectedVersion is the order version the client last saw (If-Match); nil accepts any version
+	ExpectedVersion *int `json:"-"`
 }
 
-// UpdateOrderUsecase defines the interface for updating orders
+// UpdateOrderUsecase defines the interface for updating orders.
+// On a version conflict it returns the current state of the order along with the error.
 type UpdateOrderUsecase interface {
 	Execute(ctx context.Context, id string, input UpdateOrderInput) (*OrderOutput, apperrors.ApplicationError)
 }
@@ -55,35 +67,78 @@ func (u *updateOrderUsecase) Execute(ctx context.Context, id string, input Updat
 	}
 	previousStatus := order.Status
 
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != order.Version {
+		current := toOrderOutput(order)
+		return &current, apperrors.NewApplicationError(mappings.OrderVersionConflictError, fmt.Errorf("expected version %d, current version %d", *input.ExpectedVersion, order.Version))
+	}
+
 	// Update fields if provided
 	if input.Status != nil {
 		if !domain.IsValidStatus(*input.Status) {
 			return nil, apperrors.NewApplicationError(mappings.OrderInvalidStatusError, nil)
 		}
-		order.Status = domain.OrderStatus(*input.Status)
+		newStatus := domain.OrderStatus(*input.Status)
+		if newStatus == domain.StatusCancelled && order.Status != domain.StatusCancelled {
+			return nil, apperrors.NewApplicationError(mappings.OrderCancelRequiresEndpointError, nil)
+		}
+		if transitionErr := order.ValidateTransition(newStatus); transitionErr != nil {
+			if errors.Is(transitionErr, domain.ErrPaymentNotReceived) {
+				return nil, apperrors.NewApplicationError(mappings.OrderPaymentNotReceivedError, transitionErr)
+			}
+			return nil, apperrors.NewApplicationError(mappings.OrderInvalidTransitionError, transitionErr)
+		}
+		order.Status = newStatus
 	}
 
 	if input.StatusMessage != nil {
 		order.StatusMessage = input.StatusMessage
 	}
 
-	if input.ETA != nil {
+	if input.EstimatedDeliveryAt != nil {
+		order.SetEstimatedDelivery(*input.EstimatedDeliveryAt, input.EstimatedDeliveryWindowEnd)
+	} else if input.ETA != nil {
+		// A free-form ETA replaces any structured estimate
 		order.ETA = *input.ETA
+		order.EstimatedDeliveryAt = nil
+		order.EstimatedDeliveryWindowEnd = nil
 	}
 
 	if input.Data != nil {
 		order.Data = input.Data
+		// Saving new items over the old price would charge the stale total
+		if priceErr := orderUsecase.PriceOrder(ctx, app, order, u.calculateDeliveryFeeUse); priceErr != nil {
+			return nil, apperrors.NewApplicationError(mappings.OrderPricingError, priceErr)
+		}
 	}
 
 	// Save changes
 	updatedOrder, err := app.Repositories.Order.Update(ctx, order)
 	if err != nil {
+		if err.Code() == mappings.OrderVersionConflictError.Code {
+			if latest, getErr := app.Repositories.Order.GetByID(ctx, id); getErr == nil {
+				current := toOrderOutput(latest)
+				return &current, err
+			}
+		}
 		return nil, err
 	}
 
-	// Payment processing removed - payments are now processed at order creation
-	// Keeping this comment for reference
-	_ = previousStatus // Keep variable to avoid compilation error
+	if updatedOrder.Status != previousStatus {
+		var actorUserID *string
+		if input.UserID != "" {
+			actorUserID = &input.UserID
+		}
+		event := &domain.OrderStatusEvent{
+			OrderID:       updatedOrder.ID,
+			FromStatus:    &previousStatus,
+			ToStatus:      updatedOrder.Status,
+			ActorUserID:   actorUserID,
+			StatusMessage: updatedOrder.StatusMessage,
+		}
+		if _, eventErr := app.Repositories.OrderStatusEvent.Create(ctx, event); eventErr != nil {
+			log.Printf("Warning: failed to record status change for order %s: %v", updatedOrder.ID, eventErr)
+		}
+	}
 
 	output := toOrderOutput(updatedOrder)
 	return &output, nil
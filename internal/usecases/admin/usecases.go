@@ -11,7 +11,9 @@ type Usecases struct {
 	ListProfiles     ListProfilesUsecase
 	ListOrders       ListOrdersUsecase
 	ListTransactions ListTransactionsUsecase
+	GetTransaction   GetTransactionUsecase
 	UpdateOrder      UpdateOrderUsecase
+	GetNextStatuses  GetNextStatusesUsecase
 	UploadImport     UploadImportUsecase
 	ListImports      ListImportsUsecase
 	CreateImport     CreateImportUsecase
@@ -24,6 +26,7 @@ type Usecases struct {
 	CreateCoupon     CreateCouponUsecase
 	UpdateCoupon     UpdateCouponUsecase
 	DeleteCoupon     DeleteCouponUsecase
+	ListSlotBookings ListSlotBookingsUsecase
 }
 
 // NewUsecases creates all admin use cases
@@ -32,7 +35,9 @@ func NewUsecases(contextFactory appcontext.Factory, calculateDeliveryFeeUse sett
 		ListProfiles:     NewListProfilesUsecase(contextFactory),
 		ListOrders:       NewListOrdersUsecase(contextFactory),
 		ListTransactions: NewListTransactionsUsecase(contextFactory),
+		GetTransaction:   NewGetTransactionUsecase(contextFactory),
 		UpdateOrder:      NewUpdateOrderUsecase(contextFactory, calculateDeliveryFeeUse),
+		GetNextStatuses:  NewGetNextStatusesUsecase(contextFactory),
 		UploadImport:     NewUploadImportUsecase(contextFactory),
 		ListImports:      NewListImportsUsecase(contextFactory),
 		CreateImport:     NewCreateImportUsecase(contextFactory),
@@ -45,5 +50,6 @@ func NewUsecases(contextFactory appcontext.Factory, calculateDeliveryFeeUse sett
 		CreateCoupon:     NewCreateCouponUsecase(contextFactory),
 		UpdateCoupon:     NewUpdateCouponUsecase(contextFactory),
 		DeleteCoupon:     NewDeleteCouponUsecase(contextFactory),
+		ListSlotBookings: NewListSlotBookingsUsecase(contextFactory),
 	}
 }
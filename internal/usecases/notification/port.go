@@ -26,6 +26,78 @@ type OrderUpdatedPayload struct {
 	ETA     string `json:"eta"`
 }
 
+// OrderModificationRequestedPayload contains data about a customer's proposed item change
+type OrderModificationRequestedPayload struct {
+	RequestID   string `json:"request_id"`
+	OrderID     string `json:"order_id"`
+	UserID      string `json:"user_id"`
+	ChangeCount int    `json:"change_count"`
+	Note        string `json:"note,omitempty"`
+	RequestedAt string `json:"requested_at"`
+}
+
+// OrderCommentPostedPayload tells managers a comment was added to an order; the comment
+// itself, which may be an internal note, is read through the API
+type OrderCommentPostedPayload struct {
+	CommentID string `json:"comment_id"`
+	OrderID   string `json:"order_id"`
+}
+
+// OrderCommentRepliedPayload delivers a manager reply to the customer who owns the order
+type OrderCommentRepliedPayload struct {
+	CommentID     string `json:"comment_id"`
+	OrderID       string `json:"order_id"`
+	UserID        string `json:"user_id"`
+	Body          string `json:"body"`
+	AttachmentKey string `json:"attachment_key,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// SubscriptionRunFailedPayload tells a subscriber their recurring order could not be placed or
+// charged; the error itself stays on the stored run
+type SubscriptionRunFailedPayload struct {
+	SubscriptionID string `json:"subscription_id"`
+	UserID         string `json:"user_id"`
+	OrderID        string `json:"order_id,omitempty"`
+	Status         string `json:"status"`
+	FailedAt       string `json:"failed_at"`
+}
+
+// ItemSubstitutionPayload describes one item replaced by a substitute
+type ItemSubstitutionPayload struct {
+	Name               string  `json:"name"`
+	Quantity           int     `json:"quantity"`
+	SubstituteName     string  `json:"substitute_name"`
+	SubstituteQuantity int     `json:"substitute_quantity"`
+	SubstitutePrice    float64 `json:"substitute_price"`
+}
+
+// OrderItemsSubstitutedPayload tells a customer which items of their order were substituted or are unavailable
+type OrderItemsSubstitutedPayload struct {
+	OrderID       string                    `json:"order_id"`
+	UserID        string                    `json:"user_id"`
+	Substitutions []ItemSubstitutionPayload `json:"substitutions"`
+	Unavailable   []string                  `json:"unavailable"`
+	ItemsTotal    float64                   `json:"items_total"`
+	UpdatedAt     string                    `json:"updated_at"`
+}
+
+// OrdersExpiredPayload tells managers which unpaid orders the expiry job cancelled
+type OrdersExpiredPayload struct {
+	OrderIDs      []string `json:"order_ids"`
+	TokensExpired int64    `json:"tokens_expired"`
+	ExpiredAt     string   `json:"expired_at"`
+}
+
+// OrderPaymentFlaggedPayload tells managers that a payment of an order needs attention, e.g. a chargeback
+type OrderPaymentFlaggedPayload struct {
+	OrderID          string  `json:"order_id"`
+	Flag             string  `json:"flag"`
+	GatewayPaymentID string  `json:"gateway_payment_id"`
+	Amount           float64 `json:"amount"`
+	FlaggedAt        string  `json:"flagged_at"`
+}
+
 // Service defines the interface for sending notifications to clients
 // This is a driven port (output port) in hexagonal architecture
 type Service interface {
@@ -35,4 +107,18 @@ type Service interface {
 	NotifyOrderCreated(payload OrderCreatedPayload) error
 	// NotifyOrderUpdated sends a notification when an order's status changes
 	NotifyOrderUpdated(payload OrderUpdatedPayload) error
+	// NotifyOrderModificationRequested sends a notification when a customer proposes an item change
+	NotifyOrderModificationRequested(payload OrderModificationRequestedPayload) error
+	// NotifyOrderCommentPosted sends a notification when a comment or internal note is added to an order
+	NotifyOrderCommentPosted(payload OrderCommentPostedPayload) error
+	// NotifyOrderCommentReplied sends a manager reply to the order's customer only
+	NotifyOrderCommentReplied(payload OrderCommentRepliedPayload) error
+	// NotifySubscriptionRunFailed tells the subscriber that a recurring order could not be placed or charged
+	NotifySubscriptionRunFailed(payload SubscriptionRunFailedPayload) error
+	// NotifyOrderItemsSubstituted tells the order's customer that items were substituted or are unavailable
+	NotifyOrderItemsSubstituted(payload OrderItemsSubstitutedPayload) error
+	// NotifyOrdersExpired tells managers that unpaid orders were cancelled by the expiry job
+	NotifyOrdersExpired(payload OrdersExpiredPayload) error
+	// NotifyOrderPaymentFlagged tells managers that an order's payment was flagged for review
+	NotifyOrderPaymentFlagged(payload OrderPaymentFlaggedPayload) error
 }
@@ -0,0 +1,375 @@
+package order
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// Column header patterns of a bulk order file, matched accent- and case-insensitively
+var (
+	bulkPhoneColumns    = []string{"telefono", "celular", "phone", "whatsapp"}
+	bulkCodeColumns     = []string{"codigo", "code", "sku"}
+	bulkQuantityColumns = []string{"cantidad", "quantity", "qty"}
+	bulkETAColumns      = []string{"eta", "entrega", "horario"}
+)
+
+// Limits of a bulk order upload, checked before the file is parsed
+const (
+	// BulkMaxFileSize is the largest accepted upload, in bytes
+	BulkMaxFileSize = 5 << 20
+	// bulkMaxRows is the most rows read from the file, header included
+	bulkMaxRows = 5000
+	// bulkMaxUnzipSize bounds how much an Excel file may expand to when unzipped
+	bulkMaxUnzipSize = 50 << 20
+)
+
+// errBulkTooLarge is returned when the upload exceeds the size or row limit
+var errBulkTooLarge = errors.New("file too large")
+
+// BulkCreateWithLinkInput is an uploaded spreadsheet with one row per order line
+type BulkCreateWithLinkInput struct {
+	File io.Reader
+	// Filename decides the format: .csv files are read as CSV, anything else as Excel
+	Filename string
+}
+
+// BulkOrderResult is one order of the upload: the rows it was built from and either
+// its claim link or why it was not created
+type BulkOrderResult struct {
+	Rows        []int   `json:"rows"`
+	PhoneNumber string  `json:"phone_number"`
+	ETA         string  `json:"eta"`
+	OrderID     string  `json:"order_id,omitempty"`
+	ClaimURL    string  `json:"claim_url,omitempty"`
+	ExpiresAt   string  `json:"expires_at,omitempty"`
+	ItemsTotal  float64 `json:"items_total"`
+	Error       string  `json:"error,omitempty"`
+}
+
+// BulkCreateWithLinkOutput summarises a bulk upload and carries the results sheet
+type BulkCreateWithLinkOutput struct {
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Orders  []BulkOrderResult `json:"orders"`
+	// Sheet is an Excel file with one row per order and its claim_url
+	Sheet []byte `json:"-"`
+}
+
+// BulkCreateWithLinkUsecase defines the interface for creating many orders with claim links from a file
+type BulkCreateWithLinkUsecase interface {
+	Execute(ctx context.Context, input BulkCreateWithLinkInput, baseURL string) (*BulkCreateWithLinkOutput, apperrors.ApplicationError)
+}
+
+type bulkCreateWithLinkUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewBulkCreateWithLinkUsecase creates a new instance of BulkCreateWithLinkUsecase
+func NewBulkCreateWithLinkUsecase(contextFactory appcontext.Factory) BulkCreateWithLinkUsecase {
+	return &bulkCreateWithLinkUsecase{contextFactory: contextFactory}
+}
+
+// bulkOrder collects the lines of one order while the file is read
+type bulkOrder struct {
+	result BulkOrderResult
+	items  []domain.OrderItem
+}
+
+// Execute groups the file's rows into orders by phone number and ETA, prices every
+// line against the import records and creates each order with its claim link.
+// An order with any invalid or unknown line is reported and not created; the
+// rest of the file still goes through.
+func (u *bulkCreateWithLinkUsecase) Execute(ctx context.Context, input BulkCreateWithLinkInput, baseURL string) (*BulkCreateWithLinkOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	rows, parseErr := readBulkRows(input.File, input.Filename)
+	if errors.Is(parseErr, errBulkTooLarge) {
+		return nil, apperrors.NewApplicationError(mappings.OrderBulkTooLargeError, parseErr)
+	}
+	if parseErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderBulkFileParseError, parseErr)
+	}
+
+	// The header is the first row with any content
+	headerIdx := -1
+	for i, row := range rows {
+		if !isBlankRow(row) {
+			headerIdx = i
+			break
+		}
+	}
+	if headerIdx < 0 {
+		return nil, apperrors.NewApplicationError(mappings.OrderBulkEmptyError, fmt.Errorf("file is empty"))
+	}
+
+	headers := rows[headerIdx]
+	phoneCol := findColumn(headers, bulkPhoneColumns)
+	codeCol := findColumn(headers, bulkCodeColumns)
+	quantityCol := findColumn(headers, bulkQuantityColumns)
+	etaCol := findColumn(headers, bulkETAColumns)
+	if phoneCol < 0 || codeCol < 0 || quantityCol < 0 {
+		return nil, apperrors.NewApplicationError(mappings.OrderBulkMissingColumnsError,
+			fmt.Errorf("headers found: %s", strings.Join(headers, ", ")))
+	}
+
+	importRecords, err := app.Repositories.ImportRecord.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []*bulkOrder
+	byKey := make(map[string]*bulkOrder)
+	for i, row := range rows[headerIdx+1:] {
+		if isBlankRow(row) {
+			continue
+		}
+		rowNumber := headerIdx + i + 2 // 1-based, as shown by spreadsheet programs
+
+		phone := cell(row, phoneCol)
+		eta := cell(row, etaCol)
+		key := phone + "\x00" + eta
+		order, ok := byKey[key]
+		if !ok {
+			order = &bulkOrder{result: BulkOrderResult{PhoneNumber: phone, ETA: eta}}
+			byKey[key] = order
+			orders = append(orders, order)
+		}
+		order.result.Rows = append(order.result.Rows, rowNumber)
+
+		if lineErr := order.addLine(row, codeCol, quantityCol, importRecords); lineErr != "" && order.result.Error == "" {
+			order.result.Error = fmt.Sprintf("fila %d: %s", rowNumber, lineErr)
+		}
+	}
+	if len(orders) == 0 {
+		return nil, apperrors.NewApplicationError(mappings.OrderBulkEmptyError, fmt.Errorf("file has a header but no rows"))
+	}
+
+	output := &BulkCreateWithLinkOutput{Orders: make([]BulkOrderResult, 0, len(orders))}
+	for _, order := range orders {
+		if order.result.Error == "" {
+			u.createOrder(ctx, app, order, baseURL)
+		}
+		if order.result.Error == "" {
+			output.Created++
+		} else {
+			output.Failed++
+		}
+		output.Orders = append(output.Orders, order.result)
+	}
+
+	sheet, sheetErr := buildClaimLinksSheet(output.Orders)
+	if sheetErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderBulkSheetError, sheetErr)
+	}
+	output.Sheet = sheet
+
+	return output, nil
+}
+
+// addLine validates a row and adds it to the order priced from the import records.
+// It returns a description of the problem, in Spanish, when the row is invalid.
+func (o *bulkOrder) addLine(row []string, codeCol, quantityCol int, importRecords []*domain.ImportRecord) string {
+	if o.result.PhoneNumber == "" {
+		return "falta el teléfono"
+	}
+
+	code := cell(row, codeCol)
+	if code == "" {
+		return "falta el código"
+	}
+
+	quantity, err := strconv.Atoi(cell(row, quantityCol))
+	if err != nil || quantity <= 0 {
+		return fmt.Sprintf("cantidad inválida %q", cell(row, quantityCol))
+	}
+
+	priced, checks := checkItemPrices([]domain.OrderItem{{Code: code, Quantity: quantity}}, importRecords)
+	if !checks[0].Matched {
+		return fmt.Sprintf("el código %s no está en la lista de precios", code)
+	}
+	if priced[0].Price <= 0 {
+		return fmt.Sprintf("el código %s no tiene precio", code)
+	}
+
+	o.items = append(o.items, priced[0])
+	o.result.ItemsTotal = math.Round((o.result.ItemsTotal+priced[0].Price*float64(quantity))*100) / 100
+	return ""
+}
+
+// createOrder creates the order and its claim link, recording the outcome on the result
+func (u *bulkCreateWithLinkUsecase) createOrder(ctx context.Context, app *appcontext.Context, order *bulkOrder, baseURL string) {
+	created, err := app.Repositories.Order.Create(ctx, &domain.Order{
+		ETA:  order.result.ETA,
+		Data: &domain.OrderData{Items: order.items},
+	})
+	if err != nil {
+		order.result.Error = "no se pudo crear el pedido"
+		return
+	}
+	order.result.OrderID = created.ID
+
+	token, claimURL, err := issueClaimLink(ctx, app, created.ID, order.result.PhoneNumber, baseURL)
+	if err != nil {
+		order.result.Error = "no se pudo generar el link"
+		return
+	}
+	order.result.ClaimURL = claimURL
+	order.result.ExpiresAt = token.ExpiresAt.Format("2006-01-02T15:04:05Z")
+}
+
+// readBulkRows reads every row of the uploaded file's first sheet, up to the size and row limits
+func readBulkRows(file io.Reader, filename string) ([][]string, error) {
+	data, err := io.ReadAll(io.LimitReader(file, BulkMaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > BulkMaxFileSize {
+		return nil, fmt.Errorf("%w: more than %d bytes", errBulkTooLarge, BulkMaxFileSize)
+	}
+
+	if strings.HasSuffix(strings.ToLower(filename), ".csv") {
+		data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // Excel writes a BOM
+
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.FieldsPerRecord = -1
+		// Spreadsheets in Spanish locales export CSV separated by semicolons
+		firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+		if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+			reader.Comma = ';'
+		}
+		var rows [][]string
+		for {
+			record, readErr := reader.Read()
+			if readErr == io.EOF {
+				return rows, nil
+			}
+			if readErr != nil {
+				return nil, readErr
+			}
+			if len(rows) == bulkMaxRows {
+				return nil, fmt.Errorf("%w: more than %d rows", errBulkTooLarge, bulkMaxRows)
+			}
+			rows = append(rows, record)
+		}
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(data), excelize.Options{
+		UnzipSizeLimit:    bulkMaxUnzipSize,
+		UnzipXMLSizeLimit: bulkMaxUnzipSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, nil
+	}
+	sheetRows, err := f.Rows(sheets[0])
+	if err != nil {
+		return nil, err
+	}
+	defer sheetRows.Close()
+
+	var rows [][]string
+	for sheetRows.Next() {
+		if len(rows) == bulkMaxRows {
+			return nil, fmt.Errorf("%w: more than %d rows", errBulkTooLarge, bulkMaxRows)
+		}
+		columns, colErr := sheetRows.Columns()
+		if colErr != nil {
+			return nil, colErr
+		}
+		rows = append(rows, columns)
+	}
+	return rows, sheetRows.Error()
+}
+
+// buildClaimLinksSheet writes the results as an Excel file, one order per row
+func buildClaimLinksSheet(results []BulkOrderResult) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheet := f.GetSheetName(0)
+	headers := []any{"phone_number", "eta", "rows", "order_id", "items_total", "claim_url", "expires_at", "error"}
+	if err := f.SetSheetRow(sheet, "A1", &headers); err != nil {
+		return nil, err
+	}
+
+	for i, r := range results {
+		rowNumbers := make([]string, len(r.Rows))
+		for j, n := range r.Rows {
+			rowNumbers[j] = strconv.Itoa(n)
+		}
+		values := []any{r.PhoneNumber, r.ETA, strings.Join(rowNumbers, ", "), r.OrderID, r.ItemsTotal, r.ClaimURL, r.ExpiresAt, r.Error}
+		axis, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return nil, err
+		}
+		if err := f.SetSheetRow(sheet, axis, &values); err != nil {
+			return nil, err
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// findColumn returns the index of the header matching any of the patterns, or -1.
+// Exact matches win so that short patterns like "eta" do not pick up "detalle".
+func findColumn(headers []string, patterns []string) int {
+	normalized := make([]string, len(headers))
+	for i, h := range headers {
+		normalized[i] = normalizeKey(strings.TrimSpace(h))
+	}
+
+	for i, h := range normalized {
+		for _, p := range patterns {
+			if h == p {
+				return i
+			}
+		}
+	}
+	for i, h := range normalized {
+		for _, p := range patterns {
+			if len(p) > 3 && strings.Contains(h, p) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// cell returns the trimmed value at index i, or "" when the row is shorter
+func cell(row []string, i int) string {
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+func isBlankRow(row []string) bool {
+	for _, c := range row {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
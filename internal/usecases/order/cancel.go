@@ -0,0 +1,288 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
+
+	"github.com/google/uuid"
+)
+
+// CancelInput represents the input for cancelling an order
+type CancelInput struct {
+	OrderID string
+	UserID  string
+	Reason  string
+	Note    string
+	// AsAdmin skips the ownership check and allows cancelling in any non-terminal status
+	AsAdmin bool
+	// ExpectedVersion is the order version the client last saw (If-Match); nil accepts any version
+	ExpectedVersion *int
+}
+
+// CancelOutput represents the output after cancelling an order.
+// On a version conflict it carries the current state of the order.
+type CancelOutput struct {
+	Data    OrderOutputData    `json:"data"`
+	Refunds []RefundOutputData `json:"refunds,omitempty"`
+}
+
+// RefundOutputData represents a refund issued while cancelling an order
+type RefundOutputData struct {
+	TransactionID       string  `json:"transaction_id"`
+	ParentTransactionID string  `json:"parent_transaction_id"`
+	Amount              float64 `json:"amount"`
+	Status              string  `json:"status"`
+}
+
+// CancelUsecase defines the interface for cancelling an order
+type CancelUsecase interface {
+	Execute(ctx context.Context, input CancelInput) (*CancelOutput, apperrors.ApplicationError)
+}
+
+type cancelUsecase struct {
+	contextFactory  appcontext.Factory
+	notificationSvc notification.Service
+}
+
+// NewCancelUsecase creates a new instance of CancelUsecase
+func NewCancelUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service) CancelUsecase {
+	return &cancelUsecase{
+		contextFactory:  contextFactory,
+		notificationSvc: notificationSvc,
+	}
+}
+
+// Execute refunds every approved payment of the order and then cancels it, so an order only
+// ends up CANCELLED once its refunds were accepted or queued by the gateway. The order is
+// locked while this happens, so a concurrent refund cannot pay the same money back twice.
+// When a refund fails the order keeps its status; if some payments were already refunded it
+// is flagged so staff retry the cancellation, which refunds the rest.
+func (u *cancelUsecase) Execute(ctx context.Context, input CancelInput) (*CancelOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	if !domain.IsValidCancellationReason(input.Reason) {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidCancellationReasonError, fmt.Errorf("unknown reason %q", input.Reason))
+	}
+
+	release, lockErr := app.Repositories.LockOrder(ctx, input.OrderID)
+	if lockErr != nil {
+		return nil, lockErr
+	}
+	defer release()
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !input.AsAdmin && (order.UserID == nil || *order.UserID != input.UserID) {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != order.Version {
+		return &CancelOutput{Data: toOrderOutputData(order, false)},
+			apperrors.NewApplicationError(mappings.OrderVersionConflictError, fmt.Errorf("expected version %d, current version %d", *input.ExpectedVersion, order.Version))
+	}
+
+	if !input.AsAdmin && !order.CanBeCancelledByCustomer() {
+		return nil, apperrors.NewApplicationError(mappings.OrderCancelNotAllowedError, fmt.Errorf("order is %s", order.Status))
+	}
+	if order.Status == domain.StatusCancelled {
+		return nil, apperrors.NewApplicationError(mappings.OrderCancelNotAllowedError, errors.New("order is already cancelled"))
+	}
+	if transitionErr := order.ValidateTransition(domain.StatusCancelled); transitionErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderCancelNotAllowedError, transitionErr)
+	}
+
+	refunds, refundErr := refundOrderPayments(ctx, app, order, nil, input.Reason, fmt.Sprintf("Reembolso por cancelación de pedido %s", order.ID))
+	if refundErr != nil {
+		log.Printf("Order %s: not cancelled, refunding failed after %d refunds: %v", order.ID, len(refunds), refundErr)
+		if refundErr.Code() == mappings.OrderRefundUnrecordedError.Code {
+			return nil, refundErr
+		}
+		if len(refunds) > 0 {
+			if flagErr := app.Repositories.Order.SetPaymentFlag(ctx, order.ID, domain.PaymentFlagRefundFailed); flagErr != nil {
+				log.Printf("Warning: failed to flag order %s for a manual refund: %v", order.ID, flagErr)
+			}
+		}
+		return nil, apperrors.NewApplicationError(mappings.OrderCancelRefundFailedError, refundErr)
+	}
+
+	previousStatus := order.Status
+	reason := domain.CancellationReason(input.Reason)
+	order.Status = domain.StatusCancelled
+	order.CancellationReason = &reason
+	if input.Note != "" {
+		note := input.Note
+		order.StatusMessage = &note
+	}
+
+	updated, err := app.Repositories.Order.Update(ctx, order)
+	if err != nil {
+		if len(refunds) > 0 {
+			log.Printf("Order %s: payments refunded but the cancellation could not be saved: %v", order.ID, err)
+			if flagErr := app.Repositories.Order.SetPaymentFlag(ctx, order.ID, domain.PaymentFlagRefundedNotCancelled); flagErr != nil {
+				log.Printf("Warning: failed to flag order %s as refunded but not cancelled: %v", order.ID, flagErr)
+			}
+		}
+		if err.Code() == mappings.OrderVersionConflictError.Code {
+			if latest, getErr := app.Repositories.Order.GetByID(ctx, order.ID); getErr == nil {
+				return &CancelOutput{Data: toOrderOutputData(latest, false)}, err
+			}
+		}
+		return nil, err
+	}
+
+	var actorUserID *string
+	if input.UserID != "" {
+		actorUserID = &input.UserID
+	}
+	recordStatusChange(ctx, app, updated.ID, previousStatus, updated.Status, actorUserID, updated.StatusMessage)
+
+	if updated.DeliverySlotID != nil && updated.DeliveryDate != nil {
+		releaseDeliverySlot(ctx, app, *updated.DeliverySlotID, *updated.DeliveryDate)
+	}
+
+	if u.notificationSvc != nil {
+		payload := notification.OrderUpdatedPayload{
+			OrderID: updated.ID,
+			Status:  string(updated.Status),
+			ETA:     updated.ETA,
+		}
+		go func() {
+			if notifyErr := u.notificationSvc.NotifyOrderUpdated(payload); notifyErr != nil {
+				log.Printf("Warning: failed to notify order cancelled %s: %v", updated.ID, notifyErr)
+			}
+		}()
+	}
+
+	return &CancelOutput{
+		Data:    toOrderOutputData(updated, false),
+		Refunds: refunds,
+	}, nil
+}
+
+// refundablePayment is an approved gateway payment and how much of it can still be refunded
+type refundablePayment struct {
+	payment   *domain.Transaction
+	remaining float64
+}
+
+// refundablePayments returns the approved gateway payments of an order that are not
+// fully refunded yet, oldest first. Refunds count unless the gateway rejected them.
+func refundablePayments(transactions []*domain.Transaction) []refundablePayment {
+	refunded := make(map[string]float64)
+	for _, t := range transactions {
+		if t.Type == domain.TransactionTypeRefund && t.ParentTransactionID != nil && t.Status != domain.TransactionStatusRejected {
+			refunded[*t.ParentTransactionID] += t.Amount
+		}
+	}
+
+	var payments []refundablePayment
+	for _, t := range transactions {
+		if t.Type != domain.TransactionTypePayment || t.Status != domain.TransactionStatusApproved || t.GatewayPaymentID == nil {
+			continue
+		}
+
+		remaining := roundCents(t.Amount - refunded[t.ID])
+		if remaining <= 0 {
+			continue
+		}
+		payments = append(payments, refundablePayment{payment: t, remaining: remaining})
+	}
+
+	return payments
+}
+
+// roundCents rounds an amount to whole cents
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// refundOrderPayments refunds up to amount from the approved payments of an order, oldest
+// first, or what is left of every payment when amount is nil. Each refund is recorded as a
+// transaction linked to the original payment. A refund the gateway issued but that could not
+// be recorded stops the loop and flags the order for reconciliation, since the refundable
+// amount can no longer be trusted. Callers hold the order lock so two refunds of the same
+// order cannot both see the same refundable amount.
+func refundOrderPayments(ctx context.Context, app *appcontext.Context, order *domain.Order, amount *float64, reason string, description string) ([]RefundOutputData, apperrors.ApplicationError) {
+	transactions, err := app.Repositories.Transaction.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	left := math.Inf(1)
+	if amount != nil {
+		left = roundCents(*amount)
+	}
+
+	var refunds []RefundOutputData
+	for _, rp := range refundablePayments(transactions) {
+		if left <= 0 {
+			break
+		}
+		t := rp.payment
+		refundAmount := math.Min(rp.remaining, left)
+
+		provider, providerErr := app.Integrations.PaymentProviders.Get(t.Provider)
+		if providerErr != nil {
+			return refunds, apperrors.NewApplicationError(mappings.PaymentProviderUnavailableError, providerErr)
+		}
+		refundResponse, refundErr := provider.Refund(*t.GatewayPaymentID, refundAmount, reason)
+		if refundErr != nil {
+			return refunds, apperrors.NewApplicationError(mappings.OrderRefundFailedError, refundErr)
+		}
+		if refundResponse.Status == domain.TransactionStatusRejected {
+			return refunds, apperrors.NewApplicationError(mappings.OrderRefundFailedError, fmt.Errorf("refund rejected by gateway for payment %s", *t.GatewayPaymentID))
+		}
+
+		parentID := t.ID
+		gatewayRefundID := refundResponse.ID
+		refund := &domain.Transaction{
+			OrderID:             order.ID,
+			UserID:              t.UserID,
+			ProfileID:           t.ProfileID,
+			Type:                domain.TransactionTypeRefund,
+			Provider:            t.Provider,
+			ParentTransactionID: &parentID,
+			Amount:              refundAmount,
+			Currency:            t.Currency,
+			Status:              refundResponse.Status,
+			GatewayPaymentID:    &gatewayRefundID,
+			CollectorID:         t.CollectorID,
+			Description:         &description,
+		}
+		if _, createErr := app.Repositories.Transaction.Create(ctx, refund, domain.TransactionSourceRefund); createErr != nil {
+			log.Printf("Order %s: gateway refund %s of %.2f %s for payment %s was issued but not recorded: %v",
+				order.ID, gatewayRefundID, refundAmount, t.Currency, *t.GatewayPaymentID, createErr)
+			if flagErr := app.Repositories.Order.SetPaymentFlag(ctx, order.ID, domain.PaymentFlagRefundUnrecorded); flagErr != nil {
+				log.Printf("Warning: failed to flag order %s for refund reconciliation: %v", order.ID, flagErr)
+			}
+			return refunds, apperrors.NewApplicationError(mappings.OrderRefundUnrecordedError,
+				fmt.Errorf("gateway refund %s for payment %s: %w", gatewayRefundID, *t.GatewayPaymentID, createErr))
+		}
+
+		refunds = append(refunds, RefundOutputData{
+			TransactionID:       refund.ID,
+			ParentTransactionID: parentID,
+			Amount:              refundAmount,
+			Status:              refundResponse.Status,
+		})
+		left = roundCents(left - refundAmount)
+	}
+
+	return refunds, nil
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"time"
 
+	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
 	"yego/internal/usecases/notification"
 	settingsUsecase "yego/internal/usecases/settings"
@@ -17,6 +18,11 @@ import (
 type ClaimInput struct {
 	Token  string `json:"token" binding:"required"`
 	UserID string `json:"user_id" binding:"required"`
+	// DeliverySlotID and DeliveryDate (YYYY-MM-DD) optionally book a scheduled delivery window
+	DeliverySlotID string `json:"delivery_slot_id"`
+	DeliveryDate   string `json:"delivery_date"`
+	// PaymentMethod optionally chooses how the order is paid, replacing the one it was created with
+	PaymentMethod string `json:"payment_method"`
 }
 
 // ClaimOutput represents the output after claiming an order
@@ -27,6 +33,8 @@ type ClaimOutput struct {
 	Status    string  `json:"status"`
 	ETA       string  `json:"eta"`
 	ClaimedAt string  `json:"claimed_at"`
+	// PaymentMethod is how the order is paid, when one was chosen
+	PaymentMethod *string `json:"payment_method,omitempty"`
 }
 
 // ClaimUsecase defines the interface for claiming orders
@@ -38,14 +46,16 @@ type claimUsecase struct {
 	contextFactory          appcontext.Factory
 	notificationSvc         notification.Service
 	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
+	estimateDeliveryTimeUse settingsUsecase.EstimateDeliveryTimeUsecase
 }
 
 // NewClaimUsecase creates a new instance of ClaimUsecase
-func NewClaimUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) ClaimUsecase {
+func NewClaimUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase, estimateDeliveryTimeUse settingsUsecase.EstimateDeliveryTimeUsecase) ClaimUsecase {
 	return &claimUsecase{
 		contextFactory:          contextFactory,
 		notificationSvc:         notificationSvc,
 		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
+		estimateDeliveryTimeUse: estimateDeliveryTimeUse,
 	}
 }
 
@@ -53,6 +63,11 @@ func NewClaimUsecase(contextFactory appcontext.Factory, notificationSvc notifica
 func (u *claimUsecase) Execute(ctx context.Context, input ClaimInput) (*ClaimOutput, apperrors.ApplicationError) {
 	app := u.contextFactory()
 
+	paymentMethod, methodErr := parsePaymentMethod(input.PaymentMethod)
+	if methodErr != nil {
+		return nil, methodErr
+	}
+
 	// Get the order token
 	orderToken, err := app.Repositories.OrderToken.GetByToken(ctx, input.Token)
 	if err != nil {
@@ -60,7 +75,7 @@ func (u *claimUsecase) Execute(ctx context.Context, input ClaimInput) (*ClaimOut
 	}
 
 	// Check if token has expired
-	if time.Now().After(orderToken.ExpiresAt) {
+	if orderToken.ExpiredAt != nil || time.Now().After(orderToken.ExpiresAt) {
 		return nil, apperrors.NewApplicationError(mappings.OrderTokenExpiredError, errors.New("token expired"))
 	}
 
@@ -92,8 +107,27 @@ func (u *claimUsecase) Execute(ctx context.Context, input ClaimInput) (*ClaimOut
 		return nil, apperrors.NewApplicationError(mappings.OrderAlreadyAssignedError, errors.New("order already assigned to another user"))
 	}
 
+	// A payment already received fixes how the order was paid
+	if paymentMethod != nil && order.PaymentReceivedAt != nil && (order.PaymentMethod == nil || *order.PaymentMethod != *paymentMethod) {
+		return nil, apperrors.NewApplicationError(mappings.OrderPaymentMethodLockedError, errors.New("payment already received"))
+	}
+
+	// Take a place in the requested delivery slot before claiming, so a full slot rejects the claim
+	var slot *domain.DeliverySlot
+	var slotDay time.Time
+	if input.DeliverySlotID != "" {
+		var slotErr apperrors.ApplicationError
+		slot, slotDay, slotErr = reserveDeliverySlot(ctx, app, input.DeliverySlotID, input.DeliveryDate)
+		if slotErr != nil {
+			return nil, slotErr
+		}
+	}
+
 	// Assign user to order
 	if assignErr := app.Repositories.Order.AssignUser(ctx, orderToken.OrderID, input.UserID); assignErr != nil {
+		if slot != nil {
+			releaseDeliverySlot(ctx, app, slot.ID, slotDay)
+		}
 		return nil, assignErr
 	}
 
@@ -130,11 +164,54 @@ func (u *claimUsecase) Execute(ctx context.Context, input ClaimInput) (*ClaimOut
 			if hasChanges {
 				log.Printf("[Claim] applying price corrections to order %s", updatedOrder.ID)
 				updatedOrder.Data.Items = corrected
-				_, _ = app.Repositories.Order.Update(ctx, updatedOrder)
+				if saved, saveErr := app.Repositories.Order.Update(ctx, updatedOrder); saveErr == nil {
+					updatedOrder = saved
+				}
 			}
 		}
 	}
 
+	if slot != nil {
+		previousSlotID, previousDay := updatedOrder.DeliverySlotID, updatedOrder.DeliveryDate
+		assignDeliverySlot(updatedOrder, slot, slotDay)
+		saved, saveErr := app.Repositories.Order.Update(ctx, updatedOrder)
+		if saveErr != nil {
+			releaseDeliverySlot(ctx, app, slot.ID, slotDay)
+			return nil, saveErr
+		}
+		if previousSlotID != nil && previousDay != nil {
+			releaseDeliverySlot(ctx, app, *previousSlotID, *previousDay)
+		}
+		updatedOrder = saved
+	}
+
+	if paymentMethod != nil {
+		updatedOrder.PaymentMethod = paymentMethod
+		saved, saveErr := app.Repositories.Order.Update(ctx, updatedOrder)
+		if saveErr != nil {
+			return nil, saveErr
+		}
+		updatedOrder = saved
+	}
+
+	// Orders created by link have no location until claimed; propose an ETA now
+	if updatedOrder.EstimatedDeliveryAt == nil && updatedOrder.ETA == "" {
+		if applyDeliveryEstimate(ctx, app, updatedOrder, u.estimateDeliveryTimeUse) {
+			if saved, saveErr := app.Repositories.Order.Update(ctx, updatedOrder); saveErr == nil {
+				updatedOrder = saved
+			}
+		}
+	}
+
+	// Price the order now that its delivery location is known
+	if updatedOrder.Data != nil && len(updatedOrder.Data.Items) > 0 {
+		if priceErr := PriceOrder(ctx, app, updatedOrder, u.calculateDeliveryFeeUse); priceErr != nil {
+			log.Printf("Warning: failed to price claimed order %s: %v", updatedOrder.ID, priceErr)
+		} else if saved, saveErr := app.Repositories.Order.Update(ctx, updatedOrder); saveErr == nil {
+			updatedOrder = saved
+		}
+	}
+
 	// Send notification to managers
 	if u.notificationSvc != nil {
 		payload := notification.OrderClaimedPayload{
@@ -157,12 +234,17 @@ func (u *claimUsecase) Execute(ctx context.Context, input ClaimInput) (*ClaimOut
 		}()
 	}
 
-	return &ClaimOutput{
+	output := &ClaimOutput{
 		OrderID:   updatedOrder.ID,
 		UserID:    input.UserID,
 		ProfileID: updatedOrder.ProfileID,
 		Status:    string(updatedOrder.Status),
 		ETA:       updatedOrder.ETA,
 		ClaimedAt: time.Now().Format("2006-01-02T15:04:05Z"),
-	}, nil
+	}
+	if updatedOrder.PaymentMethod != nil {
+		method := string(*updatedOrder.PaymentMethod)
+		output.PaymentMethod = &method
+	}
+	return output, nil
 }
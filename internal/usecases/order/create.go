@@ -20,6 +20,13 @@ type CreateInput struct {
 	ETA          string `json:"eta"`
 	SecurityCode string `json:"security_code"`
 	Token        string
+	// DeliverySlotID and DeliveryDate (YYYY-MM-DD) optionally book a scheduled delivery window
+	DeliverySlotID string `json:"delivery_slot_id"`
+	DeliveryDate   string `json:"delivery_date"`
+	// PaymentProvider optionally chooses the provider the order is paid through
+	PaymentProvider string `json:"payment_provider"`
+	// PaymentMethod optionally chooses how the order is paid: CARD, CHECKOUT_LINK, CASH or TRANSFER
+	PaymentMethod string `json:"payment_method"`
 }
 
 // CreateOutput represents the output after creating an order
@@ -35,14 +42,16 @@ type CreateUsecase interface {
 type createUsecase struct {
 	contextFactory          appcontext.Factory
 	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
+	estimateDeliveryTimeUse settingsUsecase.EstimateDeliveryTimeUsecase
 	notificationSvc         notification.Service
 }
 
 // NewCreateUsecase creates a new instance of CreateUsecase
-func NewCreateUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase, notificationSvc notification.Service) CreateUsecase {
+func NewCreateUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase, estimateDeliveryTimeUse settingsUsecase.EstimateDeliveryTimeUsecase, notificationSvc notification.Service) CreateUsecase {
 	return &createUsecase{
 		contextFactory:          contextFactory,
 		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
+		estimateDeliveryTimeUse: estimateDeliveryTimeUse,
 		notificationSvc:         notificationSvc,
 	}
 }
@@ -51,26 +60,54 @@ func NewCreateUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse
 func (u *createUsecase) Execute(ctx context.Context, input CreateInput) (*CreateOutput, apperrors.ApplicationError) {
 	app := u.contextFactory()
 
+	paymentProvider, providerErr := selectPaymentProvider(app, input.PaymentProvider)
+	if providerErr != nil {
+		return nil, providerErr
+	}
+	paymentMethod, methodErr := parsePaymentMethod(input.PaymentMethod)
+	if methodErr != nil {
+		return nil, methodErr
+	}
+
 	newOrder := &domain.Order{
-		ProfileID: &input.ProfileID,
-		ETA:       input.ETA,
-		Status:    domain.StatusCreated,
+		ProfileID:       &input.ProfileID,
+		ETA:             input.ETA,
+		Status:          domain.StatusCreated,
+		PaymentProvider: paymentProvider,
+		PaymentMethod:   paymentMethod,
+	}
+
+	if input.DeliverySlotID != "" {
+		slot, day, slotErr := reserveDeliverySlot(ctx, app, input.DeliverySlotID, input.DeliveryDate)
+		if slotErr != nil {
+			return nil, slotErr
+		}
+		assignDeliverySlot(newOrder, slot, day)
+	} else if input.ETA == "" {
+		// Without an explicit ETA, propose one from distance, queue and preparation time
+		applyDeliveryEstimate(ctx, app, newOrder, u.estimateDeliveryTimeUse)
 	}
 
 	created, err := app.Repositories.Order.Create(ctx, newOrder)
 	if err != nil {
+		if newOrder.DeliverySlotID != nil {
+			releaseDeliverySlot(ctx, app, *newOrder.DeliverySlotID, *newOrder.DeliveryDate)
+		}
 		return nil, err
 	}
 
-	// Process payment immediately if security code is provided
-	if input.SecurityCode != "" {
+	// Process payment immediately if security code is provided; cash and transfers are received later
+	if input.SecurityCode != "" && !created.PaidOffline() {
 		paymentErr := ProcessPaymentForOrder(ctx, app, created, input.Token, input.SecurityCode, u.calculateDeliveryFeeUse)
 		if paymentErr != nil {
 			log.Printf("Payment failed for order %s: %v", created.ID, paymentErr)
 			return nil, apperrors.NewApplicationError(mappings.OrderPaymentFailedError, fmt.Errorf("payment failed: %w", paymentErr))
 		}
 		created.Status = domain.StatusConfirmed
-		created, _ = app.Repositories.Order.Update(ctx, created)
+		if confirmed, updateErr := app.Repositories.Order.Update(ctx, created); updateErr == nil {
+			recordStatusChange(ctx, app, confirmed.ID, domain.StatusCreated, domain.StatusConfirmed, nil, nil)
+			created = confirmed
+		}
 	}
 
 	// Notify managers of the new order
@@ -47,7 +47,7 @@ func NewCreatePaymentLinkUsecase(contextFactory appcontext.Factory, calculateDel
 	}
 }
 
-// Execute creates a MercadoPago Checkout Pro preference and returns the payment link
+// Execute creates a checkout link with the order's payment provider and returns it
 func (u *createPaymentLinkUsecase) Execute(ctx context.Context, input CreatePaymentLinkInput) (*CreatePaymentLinkOutput, apperrors.ApplicationError) {
 	app := u.contextFactory()
 
@@ -66,13 +66,23 @@ func (u *createPaymentLinkUsecase) Execute(ctx context.Context, input CreatePaym
 		return nil, apperrors.NewApplicationError(mappings.OrderAlreadyAssignedError, errors.New("order has already been paid"))
 	}
 
+	// Cash and transfer orders are paid outside the payment providers
+	if order.PaidOffline() {
+		return nil, apperrors.NewApplicationError(mappings.OrderPaymentOfflineError, fmt.Errorf("order is paid by %s", *order.PaymentMethod))
+	}
+
 	if order.ProfileID == nil {
 		// Profile was created after claiming — try to find and assign it now
 		if order.UserID != nil {
 			userProfile, profileLookupErr := app.Repositories.Profile.GetByUserID(ctx, *order.UserID)
 			if profileLookupErr == nil && userProfile != nil {
-				_ = app.Repositories.Order.AssignProfile(ctx, order.ID, userProfile.ID)
+				if assignErr := app.Repositories.Order.AssignProfile(ctx, order.ID, userProfile.ID); assignErr == nil {
+					// AssignProfile bumps the stored version; keep the in-memory copy in sync
+					order.Version++
+				}
 				order.ProfileID = &userProfile.ID
+				// A snapshot taken without a profile has no delivery fee; reprice with the location
+				order.PriceBreakdown = nil
 			}
 		}
 		if order.ProfileID == nil {
@@ -98,65 +108,36 @@ func (u *createPaymentLinkUsecase) Execute(ctx context.Context, input CreatePaym
 		payerEmail = fmt.Sprintf("%s@yego.local", profile.UserID)
 	}
 
-	// Validate that no item has a zero or missing price before generating a payment link
-	if order.Data != nil {
-		for _, item := range order.Data.Items {
-			if item.Price <= 0 {
-				return nil, apperrors.NewApplicationError(mappings.OrderPaymentFailedError,
-					fmt.Errorf("item '%s' has no price set, cannot generate payment link", item.Name))
-			}
-		}
-	}
+	// Charge the stored price snapshot: substitutes replace their items and unavailable items are dropped
+	items := order.Data.BilledItems()
 
-	// Calculate items subtotal
-	var itemsTotal float64
-	if order.Data != nil {
-		for _, item := range order.Data.Items {
-			itemsTotal += item.Price * float64(item.Quantity)
+	// Validate that no item has a zero or missing price before generating a payment link
+	for _, item := range items {
+		if item.Price <= 0 {
+			return nil, apperrors.NewApplicationError(mappings.OrderPaymentFailedError,
+				fmt.Errorf("item '%s' has no price set, cannot generate payment link", item.Name))
 		}
 	}
 
-	// Calculate delivery fee separately
-	var deliveryFee float64
-	if profile.LocationID != nil {
-		location, locErr := app.Repositories.Profile.GetLocationByID(ctx, *profile.LocationID)
-		if locErr == nil && location != nil {
-			deliveryInput := settingsUsecase.CalculateDeliveryFeeInput{
-				UserLatitude:  location.Latitude,
-				UserLongitude: location.Longitude,
-				Items: make([]struct {
-					Quantity int  `json:"quantity"`
-					Weight   *int `json:"weight,omitempty"`
-				}, len(order.Data.Items)),
-			}
-			if order.Data != nil {
-				for i, item := range order.Data.Items {
-					deliveryInput.Items[i].Quantity = item.Quantity
-					deliveryInput.Items[i].Weight = item.Weight
-				}
-			}
-			if feeOutput, feeErr := u.calculateDeliveryFeeUse.Execute(ctx, deliveryInput); feeErr == nil && feeOutput != nil {
-				deliveryFee = feeOutput.TotalPrice
-			}
-		}
+	breakdown, priceErr := chargeablePrice(ctx, app, order, u.calculateDeliveryFeeUse)
+	if priceErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderPaymentFailedError, priceErr)
 	}
-
-	orderTotal := math.Round((itemsTotal+deliveryFee)*100) / 100
+	deliveryFee := breakdown.DeliveryFee.Total
+	orderTotal := breakdown.Total
 	if orderTotal <= 0 {
 		return nil, apperrors.NewApplicationError(mappings.OrderPaymentFailedError, errors.New("order total is zero or negative"))
 	}
 
 	// Build preference items: products + delivery fee if applicable
 	var prefItems []payments.PreferenceItem
-	if order.Data != nil && len(order.Data.Items) > 0 {
-		for _, item := range order.Data.Items {
-			prefItems = append(prefItems, payments.PreferenceItem{
-				Title:      item.Name,
-				Quantity:   item.Quantity,
-				UnitPrice:  math.Round(item.Price*100) / 100,
-				CurrencyID: "ARS",
-			})
-		}
+	for _, item := range items {
+		prefItems = append(prefItems, payments.PreferenceItem{
+			Title:      item.Name,
+			Quantity:   item.Quantity,
+			UnitPrice:  math.Round(item.Price*100) / 100,
+			CurrencyID: "ARS",
+		})
 	}
 	if deliveryFee > 0 {
 		prefItems = append(prefItems, payments.PreferenceItem{
@@ -166,13 +147,14 @@ func (u *createPaymentLinkUsecase) Execute(ctx context.Context, input CreatePaym
 			CurrencyID: "ARS",
 		})
 	}
-	if len(prefItems) == 0 {
-		prefItems = append(prefItems, payments.PreferenceItem{
+	// Checkout lines cannot be negative, so a discounted order is charged as a single line
+	if len(prefItems) == 0 || breakdown.DiscountTotal > 0 {
+		prefItems = []payments.PreferenceItem{{
 			Title:      fmt.Sprintf("Pedido %s", order.ID),
 			Quantity:   1,
 			UnitPrice:  orderTotal,
 			CurrencyID: "ARS",
-		})
+		}}
 	}
 
 	frontendURL := input.FrontendURL
@@ -181,26 +163,29 @@ func (u *createPaymentLinkUsecase) Execute(ctx context.Context, input CreatePaym
 	}
 	orderURL := fmt.Sprintf("%s/order/%s", frontendURL, order.ID)
 
+	provider, providerErr := orderPaymentProvider(app, order)
+	if providerErr != nil {
+		return nil, providerErr
+	}
+
 	notificationURL := ""
 	if input.BackendURL != "" {
-		notificationURL = fmt.Sprintf("%s/api/orders/webhook/mp", input.BackendURL)
+		notificationURL = fmt.Sprintf("%s/api/orders/webhook/%s", input.BackendURL, provider.Name())
 	}
 
-	prefResp, prefErr := app.Integrations.Payments.CreatePreference(
-		prefItems,
-		payerEmail,
-		order.ID,
-		orderURL,
-		orderURL,
-		orderURL,
-		notificationURL,
-	)
-	if prefErr != nil {
-		return nil, apperrors.NewApplicationError(mappings.OrderPaymentFailedError, fmt.Errorf("failed to create preference: %w", prefErr))
+	link, linkErr := provider.CreateCheckoutLink(payments.CheckoutRequest{
+		OrderID:         order.ID,
+		Items:           prefItems,
+		PayerEmail:      payerEmail,
+		ReturnURL:       orderURL,
+		NotificationURL: notificationURL,
+	})
+	if linkErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderPaymentFailedError, fmt.Errorf("failed to create checkout link: %w", linkErr))
 	}
 
 	return &CreatePaymentLinkOutput{
-		InitPoint:       prefResp.InitPoint,
-		SandboxInitPoint: prefResp.SandboxInitPoint,
+		InitPoint:       link.URL,
+		SandboxInitPoint: link.SandboxURL,
 	}, nil
 }
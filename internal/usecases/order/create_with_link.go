@@ -28,6 +28,10 @@ type CreateWithLinkInput struct {
 	PhoneNumber string                   `json:"phone_number"`
 	ETA         string                   `json:"eta"`
 	Data        *CreateWithLinkDataInput `json:"data,omitempty"`
+	// PaymentProvider optionally chooses the provider the order is paid through
+	PaymentProvider string `json:"payment_provider,omitempty"`
+	// PaymentMethod optionally chooses how the order is paid; the customer may change it when claiming
+	PaymentMethod string `json:"payment_method,omitempty"`
 }
 
 // CreateWithLinkOutput represents the output after creating an order with link
@@ -59,9 +63,20 @@ func NewCreateWithLinkUsecase(contextFactory appcontext.Factory) CreateWithLinkU
 func (u *createWithLinkUsecase) Execute(ctx context.Context, input CreateWithLinkInput, baseURL string) (*CreateWithLinkOutput, apperrors.ApplicationError) {
 	app := u.contextFactory()
 
+	paymentProvider, providerErr := selectPaymentProvider(app, input.PaymentProvider)
+	if providerErr != nil {
+		return nil, providerErr
+	}
+	paymentMethod, methodErr := parsePaymentMethod(input.PaymentMethod)
+	if methodErr != nil {
+		return nil, methodErr
+	}
+
 	// Create order without user assignment
 	newOrder := &domain.Order{
-		ETA: input.ETA,
+		ETA:             input.ETA,
+		PaymentProvider: paymentProvider,
+		PaymentMethod:   paymentMethod,
 	}
 
 	// Convert input data to domain OrderData if provided
@@ -84,33 +99,39 @@ func (u *createWithLinkUsecase) Execute(ctx context.Context, input CreateWithLin
 		return nil, err
 	}
 
-	// Create order token for claiming
-	expiresAt := time.Now().Add(24 * time.Hour)
-	var phoneNumber *string
-	if input.PhoneNumber != "" {
-		phoneNumber = &input.PhoneNumber
-	}
-	orderToken := &domain.OrderToken{
-		OrderID:     created.ID,
-		PhoneNumber: phoneNumber,
-		ExpiresAt:   expiresAt,
-	}
-
-	tokenCreated, err := app.Repositories.OrderToken.Create(ctx, orderToken)
+	tokenCreated, claimURL, err := issueClaimLink(ctx, app, created.ID, input.PhoneNumber, baseURL)
 	if err != nil {
 		return nil, err
 	}
 
-	// Generate claim URL
-	claimURL := baseURL + "/order/claim/" + tokenCreated.Token
-
 	return &CreateWithLinkOutput{
 		OrderID:   created.ID,
 		Token:     tokenCreated.Token,
 		ClaimURL:  claimURL,
 		Status:    string(created.Status),
 		ETA:       created.ETA,
-		ExpiresAt: expiresAt.Format("2006-01-02T15:04:05Z"),
+		ExpiresAt: tokenCreated.ExpiresAt.Format("2006-01-02T15:04:05Z"),
 		CreatedAt: created.CreatedAt.Format("2006-01-02T15:04:05Z"),
 	}, nil
 }
+
+// issueClaimLink creates the token a customer uses to claim an unassigned order
+// and returns it with its claim URL. Tokens expire after 24 hours.
+func issueClaimLink(ctx context.Context, app *appcontext.Context, orderID, phoneNumber, baseURL string) (*domain.OrderToken, string, apperrors.ApplicationError) {
+	var phone *string
+	if phoneNumber != "" {
+		phone = &phoneNumber
+	}
+	orderToken := &domain.OrderToken{
+		OrderID:     orderID,
+		PhoneNumber: phone,
+		ExpiresAt:   time.Now().Add(24 * time.Hour),
+	}
+
+	tokenCreated, err := app.Repositories.OrderToken.Create(ctx, orderToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return tokenCreated, baseURL + "/order/claim/" + tokenCreated.Token, nil
+}
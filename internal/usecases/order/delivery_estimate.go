@@ -0,0 +1,41 @@
+package order
+
+import (
+	"context"
+	"log"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	settingsUsecase "yego/internal/usecases/settings"
+)
+
+// applyDeliveryEstimate sets the estimated delivery time of an order from the
+// location of its profile. It leaves the order untouched when the profile has
+// no location or the estimate fails.
+func applyDeliveryEstimate(ctx context.Context, app *appcontext.Context, order *domain.Order, estimateDeliveryTimeUse settingsUsecase.EstimateDeliveryTimeUsecase) bool {
+	if estimateDeliveryTimeUse == nil || order.ProfileID == nil {
+		return false
+	}
+
+	profile, err := app.Repositories.Profile.GetByID(ctx, *order.ProfileID)
+	if err != nil || profile == nil || profile.LocationID == nil {
+		return false
+	}
+
+	location, err := app.Repositories.Profile.GetLocationByID(ctx, *profile.LocationID)
+	if err != nil || location == nil {
+		return false
+	}
+
+	estimate, err := estimateDeliveryTimeUse.Execute(ctx, settingsUsecase.EstimateDeliveryTimeInput{
+		UserLatitude:  location.Latitude,
+		UserLongitude: location.Longitude,
+	})
+	if err != nil {
+		log.Printf("Warning: failed to estimate delivery time for order %s: %v", order.ID, err)
+		return false
+	}
+
+	order.SetEstimatedDelivery(estimate.EstimatedDeliveryAt, estimate.EstimatedDeliveryWindowEnd)
+	return true
+}
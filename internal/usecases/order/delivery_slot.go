@@ -0,0 +1,56 @@
+package order
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// reserveDeliverySlot checks that the slot can be booked on the given YYYY-MM-DD day
+// and takes one of its places. The caller must release it if the order is not saved.
+func reserveDeliverySlot(ctx context.Context, app *appcontext.Context, slotID, date string) (*domain.DeliverySlot, time.Time, apperrors.ApplicationError) {
+	if _, err := uuid.Parse(slotID); err != nil {
+		return nil, time.Time{}, apperrors.NewApplicationError(mappings.DeliverySlotInvalidError, err)
+	}
+	day, parseErr := domain.ParseDeliveryDate(date)
+	if parseErr != nil {
+		return nil, time.Time{}, apperrors.NewApplicationError(mappings.DeliverySlotInvalidError, parseErr)
+	}
+
+	slot, err := app.Repositories.DeliverySlot.GetByID(ctx, slotID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if bookErr := slot.CanBeBookedOn(day, time.Now()); bookErr != nil {
+		return nil, time.Time{}, apperrors.NewApplicationError(mappings.DeliverySlotUnavailableError, bookErr)
+	}
+
+	if err := app.Repositories.DeliverySlot.Reserve(ctx, slot.ID, day); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	return slot, day, nil
+}
+
+// assignDeliverySlot books the order into the slot and uses the slot window as its ETA
+func assignDeliverySlot(order *domain.Order, slot *domain.DeliverySlot, day time.Time) {
+	slotID := slot.ID
+	order.DeliverySlotID = &slotID
+	order.DeliveryDate = &day
+	windowEnd := slot.EndsAt(day)
+	order.SetEstimatedDelivery(slot.StartsAt(day), &windowEnd)
+}
+
+// releaseDeliverySlot gives back a place taken with reserveDeliverySlot
+func releaseDeliverySlot(ctx context.Context, app *appcontext.Context, slotID string, day time.Time) {
+	if err := app.Repositories.DeliverySlot.Release(ctx, slotID, day); err != nil {
+		log.Printf("Warning: failed to release delivery slot %s on %s: %v", slotID, day.Format(domain.DeliveryDateLayout), err)
+	}
+}
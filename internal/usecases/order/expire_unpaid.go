@@ -0,0 +1,158 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
+)
+
+// expireBatchSize bounds how many unpaid orders a single run cancels
+const expireBatchSize = 100
+
+// ExpireUnpaidOrdersInput represents the input for an expiry run
+type ExpireUnpaidOrdersInput struct {
+	Now time.Time
+	// MaxAge is how long an order may stay CREATED before it is cancelled
+	MaxAge time.Duration
+}
+
+// ExpireUnpaidOrdersOutput summarises an expiry run
+type ExpireUnpaidOrdersOutput struct {
+	Cancelled     int   `json:"cancelled"`
+	Skipped       int   `json:"skipped"`
+	TokensExpired int64 `json:"tokens_expired"`
+}
+
+// ExpireUnpaidOrdersUsecase defines the interface for cancelling unpaid orders and expiring claim tokens
+type ExpireUnpaidOrdersUsecase interface {
+	Execute(ctx context.Context, input ExpireUnpaidOrdersInput) (*ExpireUnpaidOrdersOutput, apperrors.ApplicationError)
+}
+
+type expireUnpaidOrdersUsecase struct {
+	contextFactory  appcontext.Factory
+	notificationSvc notification.Service
+}
+
+// NewExpireUnpaidOrdersUsecase creates a new instance of ExpireUnpaidOrdersUsecase
+func NewExpireUnpaidOrdersUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service) ExpireUnpaidOrdersUsecase {
+	return &expireUnpaidOrdersUsecase{
+		contextFactory:  contextFactory,
+		notificationSvc: notificationSvc,
+	}
+}
+
+// Execute cancels the orders that stayed CREATED for longer than MaxAge and then
+// marks every claim token that is past its expiry or belongs to a cancelled order.
+// Orders with an approved payment are left alone; the payment webhook moves them on.
+func (u *expireUnpaidOrdersUsecase) Execute(ctx context.Context, input ExpireUnpaidOrdersInput) (*ExpireUnpaidOrdersOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if input.MaxAge <= 0 {
+		return nil, apperrors.NewApplicationError(mappings.InternalServerError, fmt.Errorf("invalid max age %s", input.MaxAge))
+	}
+
+	stale, err := app.Repositories.Order.ListCreatedBefore(ctx, input.Now.Add(-input.MaxAge), expireBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ExpireUnpaidOrdersOutput{}
+	var cancelledIDs []string
+	for _, order := range stale {
+		if u.expireOrder(ctx, app, order, input.MaxAge) {
+			output.Cancelled++
+			cancelledIDs = append(cancelledIDs, order.ID)
+		} else {
+			output.Skipped++
+		}
+	}
+
+	tokensExpired, err := app.Repositories.OrderToken.MarkExpired(ctx, input.Now)
+	if err != nil {
+		return nil, err
+	}
+	output.TokensExpired = tokensExpired
+
+	if u.notificationSvc != nil && (len(cancelledIDs) > 0 || tokensExpired > 0) {
+		payload := notification.OrdersExpiredPayload{
+			OrderIDs:      cancelledIDs,
+			TokensExpired: tokensExpired,
+			ExpiredAt:     input.Now.Format("2006-01-02T15:04:05Z"),
+		}
+		go func() {
+			if notifyErr := u.notificationSvc.NotifyOrdersExpired(payload); notifyErr != nil {
+				log.Printf("Warning: failed to notify expired orders: %v", notifyErr)
+			}
+		}()
+	}
+
+	return output, nil
+}
+
+// expireOrder cancels a single unpaid order and reports whether it did
+func (u *expireUnpaidOrdersUsecase) expireOrder(ctx context.Context, app *appcontext.Context, order *domain.Order, maxAge time.Duration) bool {
+	transactions, err := app.Repositories.Transaction.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		log.Printf("Warning: not expiring order %s, failed to load its transactions: %v", order.ID, err)
+		return false
+	}
+	for _, t := range transactions {
+		if t.Type == domain.TransactionTypePayment && t.Status == "approved" {
+			log.Printf("Warning: order %s is still CREATED but has an approved payment, not expiring it", order.ID)
+			return false
+		}
+	}
+
+	previousStatus := order.Status
+	reason := domain.CancellationReasonUnpaidTimeout
+	message := fmt.Sprintf("Cancelado automáticamente: no se pagó dentro de %s", formatAge(maxAge))
+	order.Status = domain.StatusCancelled
+	order.CancellationReason = &reason
+	order.StatusMessage = &message
+
+	// Update is conditional on the version we read, so an order that was paid or
+	// changed in the meantime is not cancelled
+	updated, err := app.Repositories.Order.Update(ctx, order)
+	if err != nil {
+		if err.Code() != mappings.OrderVersionConflictError.Code {
+			log.Printf("Warning: failed to expire order %s: %v", order.ID, err)
+		}
+		return false
+	}
+
+	recordStatusChange(ctx, app, updated.ID, previousStatus, updated.Status, nil, updated.StatusMessage)
+
+	if updated.DeliverySlotID != nil && updated.DeliveryDate != nil {
+		releaseDeliverySlot(ctx, app, *updated.DeliverySlotID, *updated.DeliveryDate)
+	}
+
+	if u.notificationSvc != nil {
+		payload := notification.OrderUpdatedPayload{
+			OrderID: updated.ID,
+			Status:  string(updated.Status),
+			ETA:     updated.ETA,
+		}
+		go func() {
+			if notifyErr := u.notificationSvc.NotifyOrderUpdated(payload); notifyErr != nil {
+				log.Printf("Warning: failed to notify order expired %s: %v", updated.ID, notifyErr)
+			}
+		}()
+	}
+
+	return true
+}
+
+// formatAge renders a duration in whole hours or minutes for status messages
+func formatAge(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		return fmt.Sprintf("%d horas", int(d/time.Hour))
+	}
+	return fmt.Sprintf("%d minutos", int(d.Round(time.Minute)/time.Minute))
+}
@@ -0,0 +1,268 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
+	settingsUsecase "yego/internal/usecases/settings"
+
+	"github.com/google/uuid"
+)
+
+// ItemFulfilmentInput marks a single order item, addressed by its position in the order
+type ItemFulfilmentInput struct {
+	Index      int                    `json:"index"`
+	Status     string                 `json:"status"`
+	Substitute *domain.ItemSubstitute `json:"substitute,omitempty"`
+}
+
+// FulfilItemsInput represents a batch of item marks made while preparing an order
+type FulfilItemsInput struct {
+	OrderID string
+	UserID  string
+	Items   []ItemFulfilmentInput
+	// ExpectedVersion is the order version the client last saw (If-Match); nil accepts any version
+	ExpectedVersion *int
+}
+
+// FulfilItemsOutput represents the order after marking its items, with the recalculated totals.
+// On a version conflict it carries the current state of the order.
+type FulfilItemsOutput struct {
+	Data         OrderOutputData `json:"data"`
+	ItemsTotal   float64         `json:"items_total"`
+	TotalWeightG int             `json:"total_weight_g"`
+	// Total is the repriced amount to charge, delivery fee and discounts included
+	Total *float64 `json:"total,omitempty"`
+	// Overpaid is what the customer paid above the new total and is owed back
+	Overpaid *float64 `json:"overpaid,omitempty"`
+}
+
+// FulfilItemsUsecase defines the interface for marking items as picked, substituted or unavailable
+type FulfilItemsUsecase interface {
+	Execute(ctx context.Context, input FulfilItemsInput) (*FulfilItemsOutput, apperrors.ApplicationError)
+}
+
+type fulfilItemsUsecase struct {
+	contextFactory          appcontext.Factory
+	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
+	notificationSvc         notification.Service
+}
+
+// NewFulfilItemsUsecase creates a new instance of FulfilItemsUsecase
+func NewFulfilItemsUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase, notificationSvc notification.Service) FulfilItemsUsecase {
+	return &fulfilItemsUsecase{
+		contextFactory:          contextFactory,
+		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
+		notificationSvc:         notificationSvc,
+	}
+}
+
+// Execute marks items of a PREPARING order. Substitutes with a product code are priced
+// against the imported catalog. When anything was substituted or marked unavailable the
+// customer is told which items changed. When a paid order ends up cheaper than what was
+// captured it is flagged OVERPAID so staff refund the difference.
+func (u *fulfilItemsUsecase) Execute(ctx context.Context, input FulfilItemsInput) (*FulfilItemsOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+	if len(input.Items) == 0 {
+		return nil, apperrors.NewApplicationError(mappings.OrderItemFulfilmentInvalidError, fmt.Errorf("no items given"))
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != order.Version {
+		return &FulfilItemsOutput{Data: toOrderOutputData(order, false)},
+			apperrors.NewApplicationError(mappings.OrderVersionConflictError, fmt.Errorf("expected version %d, current version %d", *input.ExpectedVersion, order.Version))
+	}
+
+	if order.Status != domain.StatusPreparing {
+		return nil, apperrors.NewApplicationError(mappings.OrderItemFulfilmentNotAllowedError, fmt.Errorf("order is %s", order.Status))
+	}
+	if order.Data == nil || len(order.Data.Items) == 0 {
+		return nil, apperrors.NewApplicationError(mappings.OrderItemNotFoundError, fmt.Errorf("order has no items"))
+	}
+
+	var importRecords []*domain.ImportRecord
+	notifyCustomer := false
+	for _, mark := range input.Items {
+		if mark.Index < 0 || mark.Index >= len(order.Data.Items) {
+			return nil, apperrors.NewApplicationError(mappings.OrderItemNotFoundError, fmt.Errorf("no item at index %d", mark.Index))
+		}
+
+		substitute := mark.Substitute
+		if substitute != nil && substitute.Code != "" {
+			if importRecords == nil {
+				if importRecords, err = app.Repositories.ImportRecord.GetAll(ctx); err != nil {
+					return nil, err
+				}
+			}
+			priced, _ := correctItemPrices([]domain.OrderItem{{
+				Code:     substitute.Code,
+				Name:     substitute.Name,
+				Price:    substitute.Price,
+				Quantity: substitute.Quantity,
+			}}, importRecords)
+			substitute.Name = priced[0].Name
+			substitute.Price = priced[0].Price
+		}
+
+		item := &order.Data.Items[mark.Index]
+		previous := item.FulfilmentStatus()
+		status := domain.ItemFulfilment(mark.Status)
+		if setErr := item.SetFulfilment(status, substitute); setErr != nil {
+			return nil, apperrors.NewApplicationError(mappings.OrderItemFulfilmentInvalidError, fmt.Errorf("item %d: %w", mark.Index, setErr))
+		}
+		switch {
+		case status == domain.ItemSubstituted:
+			notifyCustomer = true
+		case status == domain.ItemUnavailable && previous != domain.ItemUnavailable:
+			notifyCustomer = true
+		}
+	}
+
+	// Saving the fulfilled items over the old price would charge the stale total
+	if priceErr := PriceOrder(ctx, app, order, u.calculateDeliveryFeeUse); priceErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderPricingError, priceErr)
+	}
+
+	updated, err := app.Repositories.Order.Update(ctx, order)
+	if err != nil {
+		if err.Code() == mappings.OrderVersionConflictError.Code {
+			if latest, getErr := app.Repositories.Order.GetByID(ctx, order.ID); getErr == nil {
+				return &FulfilItemsOutput{Data: toOrderOutputData(latest, false)}, err
+			}
+		}
+		return nil, err
+	}
+
+	output := &FulfilItemsOutput{
+		Data:         toOrderOutputData(updated, false),
+		ItemsTotal:   math.Round(updated.Data.ItemsTotal()*100) / 100,
+		TotalWeightG: billedWeight(ctx, app, updated),
+	}
+	if updated.PriceBreakdown != nil {
+		total := updated.PriceBreakdown.Total
+		output.Total = &total
+	}
+
+	if overpaid := u.flagOverpayment(ctx, app, updated); overpaid > 0 {
+		output.Overpaid = &overpaid
+	}
+
+	if notifyCustomer && updated.UserID != nil && u.notificationSvc != nil {
+		payload := substitutionPayload(updated, output.ItemsTotal)
+		go func() {
+			if notifyErr := u.notificationSvc.NotifyOrderItemsSubstituted(payload); notifyErr != nil {
+				log.Printf("Warning: failed to notify substitutions for order %s: %v", updated.ID, notifyErr)
+			}
+		}()
+	}
+
+	return output, nil
+}
+
+// flagOverpayment compares the captured payments of the order with its new total and, when
+// the customer paid more, flags the order and tells managers. Returns the amount owed back.
+func (u *fulfilItemsUsecase) flagOverpayment(ctx context.Context, app *appcontext.Context, order *domain.Order) float64 {
+	if order.PriceBreakdown == nil {
+		return 0
+	}
+	transactions, err := app.Repositories.Transaction.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		log.Printf("Warning: failed to check payments of order %s after marking items: %v", order.ID, err)
+		return 0
+	}
+	summary := summarizeRefunds(transactions)
+	overpaid := roundCents(summary.Captured - summary.Refunded - order.PriceBreakdown.Total)
+	if overpaid <= 0 {
+		return 0
+	}
+
+	log.Printf("Order %s: paid %.2f above its new total after marking items", order.ID, overpaid)
+	if order.PaymentFlag != nil {
+		return overpaid
+	}
+	if flagErr := app.Repositories.Order.SetPaymentFlag(ctx, order.ID, domain.PaymentFlagOverpaid); flagErr != nil {
+		log.Printf("Warning: failed to flag overpaid order %s: %v", order.ID, flagErr)
+		return overpaid
+	}
+	flag := domain.PaymentFlagOverpaid
+	now := time.Now()
+	order.PaymentFlag = &flag
+	order.PaymentFlaggedAt = &now
+
+	if u.notificationSvc != nil {
+		payload := notification.OrderPaymentFlaggedPayload{
+			OrderID:   order.ID,
+			Flag:      string(flag),
+			Amount:    overpaid,
+			FlaggedAt: now.Format("2006-01-02T15:04:05Z"),
+		}
+		go func() {
+			if notifyErr := u.notificationSvc.NotifyOrderPaymentFlagged(payload); notifyErr != nil {
+				log.Printf("Warning: failed to notify order payment flagged %s: %v", payload.OrderID, notifyErr)
+			}
+		}()
+	}
+	return overpaid
+}
+
+// billedWeight returns the delivery weight in grams of the billed items,
+// counting items without a weight at the configured default
+func billedWeight(ctx context.Context, app *appcontext.Context, order *domain.Order) int {
+	defaultWeight := 0
+	if settings, _ := app.Repositories.Settings.Get(ctx); settings != nil {
+		defaultWeight = settings.DefaultItemWeight
+	}
+
+	total := 0
+	for _, item := range order.Data.BilledItems() {
+		weight := defaultWeight
+		if item.Weight != nil {
+			weight = *item.Weight
+		}
+		total += weight * item.Quantity
+	}
+	return total
+}
+
+// substitutionPayload lists every substituted and unavailable item of the order
+func substitutionPayload(order *domain.Order, itemsTotal float64) notification.OrderItemsSubstitutedPayload {
+	payload := notification.OrderItemsSubstitutedPayload{
+		OrderID:       order.ID,
+		UserID:        *order.UserID,
+		Substitutions: []notification.ItemSubstitutionPayload{},
+		Unavailable:   []string{},
+		ItemsTotal:    itemsTotal,
+		UpdatedAt:     order.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	for _, item := range order.Data.Items {
+		switch item.FulfilmentStatus() {
+		case domain.ItemSubstituted:
+			payload.Substitutions = append(payload.Substitutions, notification.ItemSubstitutionPayload{
+				Name:               item.Name,
+				Quantity:           item.Quantity,
+				SubstituteName:     item.Substitute.Name,
+				SubstituteQuantity: item.Substitute.Quantity,
+				SubstitutePrice:    item.Substitute.Price,
+			})
+		case domain.ItemUnavailable:
+			payload.Unavailable = append(payload.Unavailable, item.Name)
+		}
+	}
+	return payload
+}
@@ -0,0 +1,220 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"yego/internal/adapters/web/integrations/payments"
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
+)
+
+// paymentReactions lets an order react to the status of its gateway payments. It is shared by
+// the webhook handler and the reconciliation job so both settle orders the same way.
+type paymentReactions struct {
+	notificationSvc notification.Service
+}
+
+// orderPaymentProvider returns the provider an order is paid through
+func orderPaymentProvider(app *appcontext.Context, order *domain.Order) (payments.Provider, apperrors.ApplicationError) {
+	name := ""
+	if order.PaymentProvider != nil {
+		name = *order.PaymentProvider
+	}
+	provider, err := app.Integrations.PaymentProviders.Get(name)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.PaymentProviderUnavailableError, err)
+	}
+	return provider, nil
+}
+
+// selectPaymentProvider validates the provider requested for a new order; empty leaves the choice to the default
+func selectPaymentProvider(app *appcontext.Context, requested string) (*string, apperrors.ApplicationError) {
+	if requested == "" {
+		return nil, nil
+	}
+	provider, err := app.Integrations.PaymentProviders.Get(requested)
+	if err != nil {
+		return nil, apperrors.NewApplicationError(mappings.PaymentProviderInvalidError, err)
+	}
+	name := provider.Name()
+	return &name, nil
+}
+
+// applyPayment records the payment's current status and lets the order react to it.
+// Reactions are safe to repeat, so a retried notification finishes what a failed one started.
+func (r *paymentReactions) applyPayment(ctx context.Context, app *appcontext.Context, order *domain.Order, provider string, payment payments.Payment, source string) apperrors.ApplicationError {
+	userID := ""
+	if order.UserID != nil {
+		userID = *order.UserID
+	}
+	description := fmt.Sprintf("Pago por link para pedido %s", order.ID)
+	transaction := &domain.Transaction{
+		OrderID:          order.ID,
+		UserID:           userID,
+		ProfileID:        order.ProfileID,
+		Provider:         provider,
+		Amount:           payment.Amount,
+		Currency:         "ARS",
+		Status:           payment.Status,
+		GatewayPaymentID: &payment.ID,
+		Description:      &description,
+	}
+	if payment.StatusDetail != "" {
+		transaction.StatusDetail = &payment.StatusDetail
+	}
+
+	stored, changed, appErr := app.Repositories.Transaction.UpsertByGatewayPaymentID(ctx, transaction, source)
+	if appErr != nil {
+		return appErr
+	}
+	if changed {
+		log.Printf("Payments: %s payment %s of order %s is now %s", provider, payment.ID, order.ID, stored.Status)
+	}
+
+	switch stored.Status {
+	case domain.TransactionStatusApproved:
+		return r.confirmOrder(ctx, app, order, stored)
+	case domain.TransactionStatusRejected, domain.TransactionStatusCancelled:
+		// Only a fresh rejection reverts the order, never a stale notification replayed later
+		if changed {
+			return r.revertOrder(ctx, app, order, stored)
+		}
+	case domain.TransactionStatusChargedBack:
+		return r.flagOrder(ctx, app, order, stored, domain.PaymentFlagChargedBack)
+	}
+
+	return nil
+}
+
+// confirmOrder confirms an order still waiting for its payment
+func (r *paymentReactions) confirmOrder(ctx context.Context, app *appcontext.Context, order *domain.Order, payment *domain.Transaction) apperrors.ApplicationError {
+	if order.Status != domain.StatusCreated {
+		log.Printf("Payments: order %s already in status %s, skipping", order.ID, order.Status)
+		return nil
+	}
+
+	if appErr := r.moveOrder(ctx, app, order, domain.StatusConfirmed, nil); appErr != nil {
+		return appErr
+	}
+	if order.Status != domain.StatusConfirmed {
+		return nil
+	}
+
+	log.Printf("Payments: order %s confirmed via payment link (mp_payment %s amount=%.2f)", order.ID, *payment.GatewayPaymentID, payment.Amount)
+	return nil
+}
+
+// revertOrder moves a confirmed order back to CREATED when the payment that confirmed it
+// was rejected or cancelled and no other approved payment covers it. Orders already being
+// prepared or delivered are left alone.
+func (r *paymentReactions) revertOrder(ctx context.Context, app *appcontext.Context, order *domain.Order, payment *domain.Transaction) apperrors.ApplicationError {
+	if order.Status != domain.StatusConfirmed {
+		log.Printf("Payments: payment %s of order %s is %s, order in status %s is not reverted", *payment.GatewayPaymentID, order.ID, payment.Status, order.Status)
+		return nil
+	}
+
+	transactions, appErr := app.Repositories.Transaction.ListByOrderID(ctx, order.ID)
+	if appErr != nil {
+		return appErr
+	}
+	for _, t := range transactions {
+		if t.Type == domain.TransactionTypePayment && t.Status == domain.TransactionStatusApproved {
+			log.Printf("Payments: payment %s of order %s is %s, but payment %s still covers the order", *payment.GatewayPaymentID, order.ID, payment.Status, t.ID)
+			return nil
+		}
+	}
+
+	message := "Pago rechazado, el pedido espera un nuevo pago"
+	if payment.Status == domain.TransactionStatusCancelled {
+		message = "Pago cancelado, el pedido espera un nuevo pago"
+	}
+	if appErr := r.moveOrder(ctx, app, order, domain.StatusCreated, &message); appErr != nil {
+		return appErr
+	}
+	if order.Status != domain.StatusCreated {
+		return nil
+	}
+
+	log.Printf("Payments: order %s reverted to %s after payment %s was %s", order.ID, domain.StatusCreated, *payment.GatewayPaymentID, payment.Status)
+	return nil
+}
+
+// moveOrder changes the order status on behalf of the payment gateway and records it.
+// The write only applies while the order is still in the status it was read in; when
+// something else moved it meanwhile (e.g. the expiry job) the order is re-read and left alone.
+func (r *paymentReactions) moveOrder(ctx context.Context, app *appcontext.Context, order *domain.Order, to domain.OrderStatus, message *string) apperrors.ApplicationError {
+	from := order.Status
+	updated, appErr := app.Repositories.Order.UpdateStatusFrom(ctx, order.ID, from, to)
+	if appErr != nil {
+		if appErr.Code() != mappings.OrderVersionConflictError.Code {
+			return appErr
+		}
+		latest, getErr := app.Repositories.Order.GetByID(ctx, order.ID)
+		if getErr != nil {
+			return getErr
+		}
+		log.Printf("Payments: order %s moved from %s to %s meanwhile, not moving it to %s", order.ID, from, latest.Status, to)
+		*order = *latest
+		return nil
+	}
+	recordStatusChange(ctx, app, order.ID, from, to, nil, message)
+	*order = *updated
+
+	if r.notificationSvc != nil {
+		payload := notification.OrderUpdatedPayload{
+			OrderID: updated.ID,
+			Status:  string(updated.Status),
+			ETA:     updated.ETA,
+		}
+		go func() {
+			if notifyErr := r.notificationSvc.NotifyOrderUpdated(payload); notifyErr != nil {
+				log.Printf("Warning: failed to notify order updated %s: %v", payload.OrderID, notifyErr)
+			}
+		}()
+	}
+
+	return nil
+}
+
+// flagOrder flags an order for review, e.g. after a chargeback, and tells managers. The flag
+// replaces any other flag already on the order; a replayed notification does nothing.
+func (r *paymentReactions) flagOrder(ctx context.Context, app *appcontext.Context, order *domain.Order, payment *domain.Transaction, flag domain.PaymentFlag) apperrors.ApplicationError {
+	if order.PaymentFlag != nil && *order.PaymentFlag == flag {
+		return nil
+	}
+	if order.PaymentFlag != nil {
+		log.Printf("Payments: order %s flag %s replaced by %s", order.ID, *order.PaymentFlag, flag)
+	}
+
+	if appErr := app.Repositories.Order.SetPaymentFlag(ctx, order.ID, flag); appErr != nil {
+		return appErr
+	}
+	now := time.Now()
+	order.PaymentFlag = &flag
+	order.PaymentFlaggedAt = &now
+
+	log.Printf("Payments: order %s flagged %s after payment %s was %s", order.ID, flag, *payment.GatewayPaymentID, payment.Status)
+
+	if r.notificationSvc != nil {
+		payload := notification.OrderPaymentFlaggedPayload{
+			OrderID:          order.ID,
+			Flag:             string(flag),
+			GatewayPaymentID: *payment.GatewayPaymentID,
+			Amount:           payment.Amount,
+			FlaggedAt:        now.Format("2006-01-02T15:04:05Z"),
+		}
+		go func() {
+			if notifyErr := r.notificationSvc.NotifyOrderPaymentFlagged(payload); notifyErr != nil {
+				log.Printf("Warning: failed to notify order payment flagged %s: %v", payload.OrderID, notifyErr)
+			}
+		}()
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package order
 
 import (
 	"context"
+	"errors"
 
 	"yego/internal/platform/appcontext"
 	apperrors "yego/internal/platform/errors"
@@ -10,6 +11,14 @@ import (
 	"github.com/google/uuid"
 )
 
+// GetInput represents the input for getting an order
+type GetInput struct {
+	OrderID string
+	UserID  string
+	// AsAdmin skips the ownership check
+	AsAdmin bool
+}
+
 // GetOutput represents the output for getting an order
 type GetOutput struct {
 	Data OrderOutputData `json:"data"`
@@ -17,7 +26,7 @@ type GetOutput struct {
 
 // GetUsecase defines the interface for getting orders
 type GetUsecase interface {
-	Execute(ctx context.Context, id string) (*GetOutput, apperrors.ApplicationError)
+	Execute(ctx context.Context, input GetInput) (*GetOutput, apperrors.ApplicationError)
 }
 
 type getUsecase struct {
@@ -29,19 +38,23 @@ func NewGetUsecase(contextFactory appcontext.Factory) GetUsecase {
 	return &getUsecase{contextFactory: contextFactory}
 }
 
-// Execute retrieves an order by ID
-func (u *getUsecase) Execute(ctx context.Context, id string) (*GetOutput, apperrors.ApplicationError) {
+// Execute retrieves an order by ID for its owner, or for an admin
+func (u *getUsecase) Execute(ctx context.Context, input GetInput) (*GetOutput, apperrors.ApplicationError) {
 	app := u.contextFactory()
 
-	if _, err := uuid.Parse(id); err != nil {
+	if _, err := uuid.Parse(input.OrderID); err != nil {
 		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
 	}
 
-	orderData, err := app.Repositories.Order.GetByID(ctx, id)
+	orderData, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
 	if err != nil {
 		return nil, err
 	}
 
+	if !input.AsAdmin && (orderData.UserID == nil || *orderData.UserID != input.UserID) {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+
 	return &GetOutput{
 		Data: toOrderOutputData(orderData, true),
 	}, nil
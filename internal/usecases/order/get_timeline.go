@@ -0,0 +1,145 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// GetTimelineInput represents the input for getting an order timeline
+type GetTimelineInput struct {
+	OrderID string
+	UserID  string
+	// AsAdmin skips the ownership check and includes the actor of each change
+	AsAdmin bool
+}
+
+// TimelineEventOutput represents a single status change in the timeline
+type TimelineEventOutput struct {
+	FromStatus    *string `json:"from_status,omitempty"`
+	ToStatus      string  `json:"to_status"`
+	ActorUserID   *string `json:"actor_user_id,omitempty"`
+	StatusMessage *string `json:"status_message,omitempty"`
+	At            string  `json:"at"`
+}
+
+// TimelineStageOutput represents the time an order spent in a status
+type TimelineStageOutput struct {
+	Status          string  `json:"status"`
+	StartedAt       string  `json:"started_at"`
+	EndedAt         *string `json:"ended_at,omitempty"`
+	DurationSeconds int64   `json:"duration_seconds"`
+}
+
+// GetTimelineOutput represents the status history of an order
+type GetTimelineOutput struct {
+	OrderID string                `json:"order_id"`
+	Status  string                `json:"status"`
+	Events  []TimelineEventOutput `json:"events"`
+	Stages  []TimelineStageOutput `json:"stages"`
+}
+
+// GetTimelineUsecase defines the interface for getting an order timeline
+type GetTimelineUsecase interface {
+	Execute(ctx context.Context, input GetTimelineInput) (*GetTimelineOutput, apperrors.ApplicationError)
+}
+
+type getTimelineUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewGetTimelineUsecase creates a new instance of GetTimelineUsecase
+func NewGetTimelineUsecase(contextFactory appcontext.Factory) GetTimelineUsecase {
+	return &getTimelineUsecase{contextFactory: contextFactory}
+}
+
+// Execute returns the status changes of an order and the time spent in each stage
+func (u *getTimelineUsecase) Execute(ctx context.Context, input GetTimelineInput) (*GetTimelineOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !input.AsAdmin && (order.UserID == nil || *order.UserID != input.UserID) {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+
+	events, err := app.Repositories.OrderStatusEvent.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &GetTimelineOutput{
+		OrderID: order.ID,
+		Status:  string(order.Status),
+		Events:  make([]TimelineEventOutput, 0, len(events)),
+		Stages:  buildTimelineStages(order, events, time.Now()),
+	}
+
+	for _, e := range events {
+		event := TimelineEventOutput{
+			ToStatus:      string(e.ToStatus),
+			StatusMessage: e.StatusMessage,
+			At:            e.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+		if e.FromStatus != nil {
+			from := string(*e.FromStatus)
+			event.FromStatus = &from
+		}
+		if input.AsAdmin {
+			event.ActorUserID = e.ActorUserID
+		}
+		output.Events = append(output.Events, event)
+	}
+
+	return output, nil
+}
+
+// buildTimelineStages splits the life of an order into consecutive status stages.
+// The first stage starts at order creation; the last one stays open unless the
+// order reached a terminal status.
+func buildTimelineStages(order *domain.Order, events []*domain.OrderStatusEvent, now time.Time) []TimelineStageOutput {
+	stages := make([]TimelineStageOutput, 0, len(events)+1)
+
+	currentStatus := domain.StatusCreated
+	if len(events) > 0 && events[0].FromStatus != nil {
+		currentStatus = *events[0].FromStatus
+	}
+	startedAt := order.CreatedAt
+
+	for _, e := range events {
+		endedAt := e.CreatedAt.Format("2006-01-02T15:04:05Z")
+		stages = append(stages, TimelineStageOutput{
+			Status:          string(currentStatus),
+			StartedAt:       startedAt.Format("2006-01-02T15:04:05Z"),
+			EndedAt:         &endedAt,
+			DurationSeconds: int64(e.CreatedAt.Sub(startedAt).Seconds()),
+		})
+		currentStatus = e.ToStatus
+		startedAt = e.CreatedAt
+	}
+
+	last := TimelineStageOutput{
+		Status:    string(currentStatus),
+		StartedAt: startedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if len(domain.OrderTransitions[currentStatus]) > 0 {
+		last.DurationSeconds = int64(now.Sub(startedAt).Seconds())
+	}
+	stages = append(stages, last)
+
+	return stages
+}
@@ -2,219 +2,68 @@ package order
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 
 	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
 	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
 )
 
-// HandlePaymentWebhookInput represents the MercadoPago webhook notification
-type HandlePaymentWebhookInput struct {
-	// From query params (IPN format)
-	PaymentID string
-	Topic     string
-	// From JSON body (Webhooks format)
-	Type string
-	Data struct {
-		ID string
-	}
-}
-
+// HandlePaymentWebhookUsecase applies a payment provider notification to its order: every payment
+// it refers to is upserted by gateway payment ID and the order reacts to the payment status.
+// It returns an error only when the notification should be retried: the gateway could not be
+// reached or something could not be saved. Notifications that can never apply are logged and dropped.
 type HandlePaymentWebhookUsecase interface {
-	Execute(ctx context.Context, resourceID string, topic string) apperrors.ApplicationError
+	Execute(ctx context.Context, provider string, resourceID string, topic string) apperrors.ApplicationError
 }
 
 type handlePaymentWebhookUsecase struct {
 	contextFactory appcontext.Factory
+	reactions      paymentReactions
 }
 
-func NewHandlePaymentWebhookUsecase(contextFactory appcontext.Factory) HandlePaymentWebhookUsecase {
-	return &handlePaymentWebhookUsecase{contextFactory: contextFactory}
-}
-
-func (u *handlePaymentWebhookUsecase) Execute(ctx context.Context, resourceID string, topic string) apperrors.ApplicationError {
-	app := u.contextFactory()
-	mpAccessToken := app.ConfigService.MPAccessToken
-	if mpAccessToken == "" {
-		log.Printf("Webhook: MP_ACCESS_TOKEN not configured, skipping")
-		return nil
-	}
-
-	// Checkout Pro webhooks (merchant_order) must be queried with the Checkout Pro token
-	// because MP requires caller_id == collector_id of the preference creator.
-	checkoutProToken := app.ConfigService.MPCheckoutProAccessToken
-	if checkoutProToken == "" {
-		checkoutProToken = mpAccessToken
-	}
-
-	type paymentInfo struct {
-		orderID     string
-		amount      float64
-		mpPaymentID string
-	}
-
-	var info paymentInfo
-
-	switch topic {
-	case "payment":
-		pi, err := u.getPaymentInfo(resourceID, mpAccessToken)
-		if err != nil {
-			log.Printf("Webhook: error getting payment %s: %v", resourceID, err)
-			return nil
-		}
-		info = paymentInfo{orderID: pi.orderID, amount: pi.amount, mpPaymentID: resourceID}
-
-	case "merchant_order":
-		pi, err := u.getMerchantOrderInfo(resourceID, checkoutProToken)
-		if err != nil {
-			log.Printf("Webhook: error getting merchant_order %s: %v", resourceID, err)
-			return nil
-		}
-		info = paymentInfo{orderID: pi.orderID, amount: pi.amount, mpPaymentID: pi.mpPaymentID}
-
-	default:
-		log.Printf("Webhook: unsupported topic %s, skipping", topic)
-		return nil
-	}
-
-	if info.orderID == "" {
-		log.Printf("Webhook: %s/%s has no approved payment yet, skipping", topic, resourceID)
-		return nil
-	}
-
-	return u.confirmOrder(ctx, info.orderID, info.mpPaymentID, info.amount)
-}
-
-func (u *handlePaymentWebhookUsecase) mpGet(path string, token string) ([]byte, error) {
-	req, err := http.NewRequest("GET", "https://api.mercadopago.com"+path, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	body, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("MP API %d: %s", resp.StatusCode, string(body))
+func NewHandlePaymentWebhookUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service) HandlePaymentWebhookUsecase {
+	return &handlePaymentWebhookUsecase{
+		contextFactory: contextFactory,
+		reactions:      paymentReactions{notificationSvc: notificationSvc},
 	}
-	return body, nil
 }
 
-type mpPaymentResult struct {
-	orderID     string
-	amount      float64
-	mpPaymentID string
-}
+func (u *handlePaymentWebhookUsecase) Execute(ctx context.Context, providerName string, resourceID string, topic string) apperrors.ApplicationError {
+	app := u.contextFactory()
 
-func (u *handlePaymentWebhookUsecase) getPaymentInfo(paymentID string, token string) (*mpPaymentResult, error) {
-	body, err := u.mpGet("/v1/payments/"+paymentID, token)
+	provider, err := app.Integrations.PaymentProviders.Get(providerName)
 	if err != nil {
-		return nil, err
+		return apperrors.NewApplicationError(mappings.PaymentProviderUnavailableError, err)
 	}
-	var p struct {
-		Status            string  `json:"status"`
-		ExternalReference string  `json:"external_reference"`
-		TransactionAmount float64 `json:"transaction_amount"`
-	}
-	if err := json.Unmarshal(body, &p); err != nil {
-		return nil, err
-	}
-	log.Printf("Webhook: payment %s status=%s external_reference=%s amount=%.2f", paymentID, p.Status, p.ExternalReference, p.TransactionAmount)
-	if p.Status != "approved" {
-		return &mpPaymentResult{}, nil
-	}
-	return &mpPaymentResult{orderID: p.ExternalReference, amount: p.TransactionAmount, mpPaymentID: paymentID}, nil
-}
 
-func (u *handlePaymentWebhookUsecase) getMerchantOrderInfo(orderID string, token string) (*mpPaymentResult, error) {
-	body, err := u.mpGet("/merchant_orders/"+orderID, token)
+	orderID, notified, err := provider.FetchNotified(topic, resourceID)
 	if err != nil {
-		return nil, err
-	}
-	var mo struct {
-		Status            string  `json:"status"`
-		ExternalReference string  `json:"external_reference"`
-		TotalAmount       float64 `json:"total_amount"`
-		Payments          []struct {
-			ID     int64   `json:"id"`
-			Status string  `json:"status"`
-			Amount float64 `json:"transaction_amount"`
-		} `json:"payments"`
-	}
-	if err := json.Unmarshal(body, &mo); err != nil {
-		return nil, err
-	}
-	log.Printf("Webhook: merchant_order %s status=%s external_reference=%s payments_count=%d", orderID, mo.Status, mo.ExternalReference, len(mo.Payments))
-	for i, p := range mo.Payments {
-		log.Printf("Webhook: merchant_order %s payment[%d] id=%d status=%s amount=%.2f", orderID, i, p.ID, p.Status, p.Amount)
-	}
-
-	var approvedPaymentID string
-	var approvedAmount float64
-	for _, p := range mo.Payments {
-		if p.Status == "approved" {
-			approvedPaymentID = fmt.Sprintf("%d", p.ID)
-			approvedAmount = p.Amount
-			break
-		}
-	}
-	if approvedPaymentID == "" && mo.Status != "closed" {
-		log.Printf("Webhook: merchant_order %s skipping — no approved payment and status=%s", orderID, mo.Status)
-		return &mpPaymentResult{}, nil
+		return apperrors.NewApplicationError(mappings.WebhookGatewayError, fmt.Errorf("getting %s %s from %s: %w", topic, resourceID, provider.Name(), err))
 	}
-	amount := approvedAmount
-	if amount == 0 {
-		amount = mo.TotalAmount
-	}
-	return &mpPaymentResult{orderID: mo.ExternalReference, amount: amount, mpPaymentID: approvedPaymentID}, nil
-}
-
-func (u *handlePaymentWebhookUsecase) confirmOrder(ctx context.Context, orderID string, mpPaymentID string, amount float64) apperrors.ApplicationError {
-	app := u.contextFactory()
 
-	order, appErr := app.Repositories.Order.GetByID(ctx, orderID)
-	if appErr != nil {
-		log.Printf("Webhook: order %s not found: %v", orderID, appErr)
+	if orderID == "" || len(notified) == 0 {
+		log.Printf("Webhook: %s %s/%s has no order reference or no payments yet, skipping", provider.Name(), topic, resourceID)
 		return nil
 	}
 
-	if order.Status != domain.StatusCreated {
-		log.Printf("Webhook: order %s already in status %s, skipping", orderID, order.Status)
-		return nil
-	}
-
-	_, appErr = app.Repositories.Order.UpdateStatus(ctx, orderID, domain.StatusConfirmed)
+	order, appErr := app.Repositories.Order.GetByID(ctx, orderID)
 	if appErr != nil {
+		if appErr.Code() == mappings.OrderNotFoundError.Code {
+			log.Printf("Webhook: order %s not found: %v", orderID, appErr)
+			return nil
+		}
 		return appErr
 	}
 
-	userID := ""
-	if order.UserID != nil {
-		userID = *order.UserID
-	}
-	description := fmt.Sprintf("Pago por link para pedido %s", orderID)
-	transaction := &domain.Transaction{
-		OrderID:          orderID,
-		UserID:           userID,
-		ProfileID:        order.ProfileID,
-		Amount:           amount,
-		Currency:         "ARS",
-		Status:           "approved",
-		GatewayPaymentID: &mpPaymentID,
-		Description:      &description,
-	}
-	if _, transErr := app.Repositories.Transaction.Create(ctx, transaction); transErr != nil {
-		log.Printf("Webhook: warning: failed to create transaction for order %s: %v", orderID, transErr)
+	for _, payment := range notified {
+		if appErr := u.reactions.applyPayment(ctx, app, order, provider.Name(), payment, domain.TransactionSourceWebhook); appErr != nil {
+			return appErr
+		}
 	}
 
-	log.Printf("Webhook: order %s confirmed via payment link (mp_payment %s amount=%.2f)", orderID, mpPaymentID, amount)
 	return nil
 }
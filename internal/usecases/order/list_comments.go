@@ -0,0 +1,70 @@
+package order
+
+import (
+	"context"
+	"errors"
+
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// ListCommentsInput represents the input for listing the comments of an order
+type ListCommentsInput struct {
+	OrderID string
+	UserID  string
+	// AsAdmin skips the ownership check and includes internal notes
+	AsAdmin bool
+}
+
+// ListCommentsOutput represents the comment thread of an order
+type ListCommentsOutput struct {
+	Data []CommentOutput `json:"data"`
+}
+
+// ListCommentsUsecase defines the interface for listing the comments of an order
+type ListCommentsUsecase interface {
+	Execute(ctx context.Context, input ListCommentsInput) (*ListCommentsOutput, apperrors.ApplicationError)
+}
+
+type listCommentsUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewListCommentsUsecase creates a new instance of ListCommentsUsecase
+func NewListCommentsUsecase(contextFactory appcontext.Factory) ListCommentsUsecase {
+	return &listCommentsUsecase{contextFactory: contextFactory}
+}
+
+// Execute returns the comments of an order oldest first.
+// Customers only see the messages meant for them.
+func (u *listCommentsUsecase) Execute(ctx context.Context, input ListCommentsInput) (*ListCommentsOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !input.AsAdmin && (order.UserID == nil || *order.UserID != input.UserID) {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+
+	comments, err := app.Repositories.OrderComment.ListByOrderID(ctx, order.ID, input.AsAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListCommentsOutput{Data: make([]CommentOutput, 0, len(comments))}
+	for _, c := range comments {
+		output.Data = append(output.Data, toCommentOutput(c))
+	}
+
+	return output, nil
+}
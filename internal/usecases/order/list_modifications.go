@@ -0,0 +1,90 @@
+package order
+
+import (
+	"context"
+	"errors"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// ListModificationsInput represents the input for listing modification requests.
+// Without an OrderID (admins only) it lists every request in the given status.
+type ListModificationsInput struct {
+	OrderID string
+	UserID  string
+	Status  string
+	// AsAdmin skips the ownership check
+	AsAdmin bool
+}
+
+// ListModificationsOutput represents a list of modification requests
+type ListModificationsOutput struct {
+	Data []ModificationRequestOutput `json:"data"`
+}
+
+// ListModificationsUsecase defines the interface for listing modification requests
+type ListModificationsUsecase interface {
+	Execute(ctx context.Context, input ListModificationsInput) (*ListModificationsOutput, apperrors.ApplicationError)
+}
+
+type listModificationsUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewListModificationsUsecase creates a new instance of ListModificationsUsecase
+func NewListModificationsUsecase(contextFactory appcontext.Factory) ListModificationsUsecase {
+	return &listModificationsUsecase{contextFactory: contextFactory}
+}
+
+// Execute lists the modification requests of an order, or the review queue for admins
+func (u *listModificationsUsecase) Execute(ctx context.Context, input ListModificationsInput) (*ListModificationsOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	var requests []*domain.OrderModificationRequest
+	if input.OrderID == "" {
+		if !input.AsAdmin {
+			return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order id is required"))
+		}
+		status := domain.ModificationRequestPending
+		if input.Status != "" {
+			status = domain.ModificationRequestStatus(input.Status)
+		}
+		list, err := app.Repositories.OrderModification.ListByStatus(ctx, status)
+		if err != nil {
+			return nil, err
+		}
+		requests = list
+	} else {
+		if _, err := uuid.Parse(input.OrderID); err != nil {
+			return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+		}
+		order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		if !input.AsAdmin && (order.UserID == nil || *order.UserID != input.UserID) {
+			return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+		}
+		list, err := app.Repositories.OrderModification.ListByOrderID(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+		requests = list
+	}
+
+	output := &ListModificationsOutput{Data: make([]ModificationRequestOutput, 0, len(requests))}
+	for _, m := range requests {
+		item := toModificationRequestOutput(m)
+		if !input.AsAdmin {
+			item.ReviewedByUserID = nil
+		}
+		output.Data = append(output.Data, item)
+	}
+
+	return output, nil
+}
@@ -6,16 +6,28 @@ import (
 
 // OrderOutputData represents basic order data for outputs
 type OrderOutputData struct {
-	ID          string          `json:"id"`
-	ProfileID   *string         `json:"profile_id,omitempty"`
-	UserID      *string         `json:"user_id,omitempty"`
-	Status      string          `json:"status"`
-	StatusIndex int             `json:"status_index"`
-	ETA         string          `json:"eta"`
-	Data        *OrderItemsData `json:"data,omitempty"`
-	CreatedAt   string          `json:"created_at"`
-	UpdatedAt   string          `json:"updated_at"`
-	AllStatuses []string        `json:"all_statuses,omitempty"`
+	ID                         string                 `json:"id"`
+	ProfileID                  *string                `json:"profile_id,omitempty"`
+	UserID                     *string                `json:"user_id,omitempty"`
+	Status                     string                 `json:"status"`
+	StatusIndex                int                    `json:"status_index"`
+	ETA                        string                 `json:"eta"`
+	EstimatedDeliveryAt        *string                `json:"estimated_delivery_at,omitempty"`
+	EstimatedDeliveryWindowEnd *string                `json:"estimated_delivery_window_end,omitempty"`
+	Data                       *OrderItemsData        `json:"data,omitempty"`
+	Version                    int                    `json:"version"`
+	CancellationReason         *string                `json:"cancellation_reason,omitempty"`
+	DeliverySlotID             *string                `json:"delivery_slot_id,omitempty"`
+	DeliveryDate               *string                `json:"delivery_date,omitempty"`
+	PriceBreakdown             *domain.PriceBreakdown `json:"price_breakdown,omitempty"`
+	PaymentFlag                *string                `json:"payment_flag,omitempty"`
+	PaymentFlaggedAt           *string                `json:"payment_flagged_at,omitempty"`
+	PaymentProvider            *string                `json:"payment_provider,omitempty"`
+	PaymentMethod              *string                `json:"payment_method,omitempty"`
+	PaymentReceivedAt          *string                `json:"payment_received_at,omitempty"`
+	CreatedAt                  string                 `json:"created_at"`
+	UpdatedAt                  string                 `json:"updated_at"`
+	AllStatuses                []string               `json:"all_statuses,omitempty"`
 }
 
 // OrderItemsData represents the items data in an order
@@ -25,10 +37,12 @@ type OrderItemsData struct {
 
 // OrderItemOutput represents a single item in the order output
 type OrderItemOutput struct {
-	Name     string  `json:"name"`
-	Price    float64 `json:"price"`
-	Quantity int     `json:"quantity"`
-	Weight   *int    `json:"weight,omitempty"`
+	Name       string                 `json:"name"`
+	Price      float64                `json:"price"`
+	Quantity   int                    `json:"quantity"`
+	Weight     *int                   `json:"weight,omitempty"`
+	Fulfilment string                 `json:"fulfilment,omitempty"`
+	Substitute *domain.ItemSubstitute `json:"substitute,omitempty"`
 }
 
 // toOrderOutputData converts a domain order to output data
@@ -40,18 +54,59 @@ func toOrderOutputData(order *domain.Order, includeStatuses bool) OrderOutputDat
 		Status:      string(order.Status),
 		StatusIndex: order.StatusIndex(),
 		ETA:         order.ETA,
+		Version:     order.Version,
 		CreatedAt:   order.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt:   order.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 	}
 
+	if order.EstimatedDeliveryAt != nil {
+		estimatedAt := order.EstimatedDeliveryAt.Format("2006-01-02T15:04:05Z")
+		output.EstimatedDeliveryAt = &estimatedAt
+	}
+	if order.EstimatedDeliveryWindowEnd != nil {
+		windowEnd := order.EstimatedDeliveryWindowEnd.Format("2006-01-02T15:04:05Z")
+		output.EstimatedDeliveryWindowEnd = &windowEnd
+	}
+
+	if order.CancellationReason != nil {
+		reason := string(*order.CancellationReason)
+		output.CancellationReason = &reason
+	}
+
+	output.PriceBreakdown = order.PriceBreakdown
+	if order.PaymentFlag != nil {
+		flag := string(*order.PaymentFlag)
+		output.PaymentFlag = &flag
+	}
+	if order.PaymentFlaggedAt != nil {
+		flaggedAt := order.PaymentFlaggedAt.Format("2006-01-02T15:04:05Z")
+		output.PaymentFlaggedAt = &flaggedAt
+	}
+	output.PaymentProvider = order.PaymentProvider
+	if order.PaymentMethod != nil {
+		method := string(*order.PaymentMethod)
+		output.PaymentMethod = &method
+	}
+	if order.PaymentReceivedAt != nil {
+		receivedAt := order.PaymentReceivedAt.Format("2006-01-02T15:04:05Z")
+		output.PaymentReceivedAt = &receivedAt
+	}
+	output.DeliverySlotID = order.DeliverySlotID
+	if order.DeliveryDate != nil {
+		deliveryDate := order.DeliveryDate.Format(domain.DeliveryDateLayout)
+		output.DeliveryDate = &deliveryDate
+	}
+
 	if order.Data != nil && len(order.Data.Items) > 0 {
 		items := make([]OrderItemOutput, len(order.Data.Items))
 		for i, item := range order.Data.Items {
 			items[i] = OrderItemOutput{
-				Name:     item.Name,
-				Price:    item.Price,
-				Quantity: item.Quantity,
-				Weight:   item.Weight,
+				Name:       item.Name,
+				Price:      item.Price,
+				Quantity:   item.Quantity,
+				Weight:     item.Weight,
+				Fulfilment: string(item.FulfilmentStatus()),
+				Substitute: item.Substitute,
 			}
 		}
 		output.Data = &OrderItemsData{Items: items}
@@ -76,3 +131,80 @@ func getAllStatuses() []string {
 	}
 	return allStatuses
 }
+
+// ModificationRequestOutput represents a customer's proposed change to an order
+type ModificationRequestOutput struct {
+	ID                string                   `json:"id"`
+	OrderID           string                   `json:"order_id"`
+	RequestedByUserID string                   `json:"requested_by_user_id"`
+	Status            string                   `json:"status"`
+	PreviousStatus    string                   `json:"previous_status"`
+	Changes           []domain.OrderItemChange `json:"changes"`
+	ProposedData      *OrderItemsData          `json:"proposed_data,omitempty"`
+	Note              *string                  `json:"note,omitempty"`
+	ReviewedByUserID  *string                  `json:"reviewed_by_user_id,omitempty"`
+	ReviewNote        *string                  `json:"review_note,omitempty"`
+	ReviewedAt        *string                  `json:"reviewed_at,omitempty"`
+	CreatedAt         string                   `json:"created_at"`
+}
+
+// toModificationRequestOutput converts a domain modification request to output
+func toModificationRequestOutput(m *domain.OrderModificationRequest) ModificationRequestOutput {
+	output := ModificationRequestOutput{
+		ID:                m.ID,
+		OrderID:           m.OrderID,
+		RequestedByUserID: m.RequestedByUserID,
+		Status:            string(m.Status),
+		PreviousStatus:    string(m.PreviousStatus),
+		Changes:           m.Changes,
+		Note:              m.Note,
+		ReviewedByUserID:  m.ReviewedByUserID,
+		ReviewNote:        m.ReviewNote,
+		CreatedAt:         m.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if m.ProposedData != nil {
+		items := make([]OrderItemOutput, len(m.ProposedData.Items))
+		for i, item := range m.ProposedData.Items {
+			items[i] = OrderItemOutput{
+				Name:     item.Name,
+				Price:    item.Price,
+				Quantity: item.Quantity,
+				Weight:   item.Weight,
+			}
+		}
+		output.ProposedData = &OrderItemsData{Items: items}
+	}
+	if m.ReviewedAt != nil {
+		reviewedAt := m.ReviewedAt.Format("2006-01-02T15:04:05Z")
+		output.ReviewedAt = &reviewedAt
+	}
+
+	return output
+}
+
+// CommentOutput represents a comment or internal note on an order
+type CommentOutput struct {
+	ID            string  `json:"id"`
+	OrderID       string  `json:"order_id"`
+	AuthorUserID  string  `json:"author_user_id"`
+	AuthorRole    string  `json:"author_role"`
+	Visibility    string  `json:"visibility"`
+	Body          string  `json:"body"`
+	AttachmentKey *string `json:"attachment_key,omitempty"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+// toCommentOutput converts a domain order comment to output
+func toCommentOutput(c *domain.OrderComment) CommentOutput {
+	return CommentOutput{
+		ID:            c.ID,
+		OrderID:       c.OrderID,
+		AuthorUserID:  c.AuthorUserID,
+		AuthorRole:    string(c.AuthorRole),
+		Visibility:    string(c.Visibility),
+		Body:          c.Body,
+		AttachmentKey: c.AttachmentKey,
+		CreatedAt:     c.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -3,7 +3,10 @@ package order
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
 
+	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
 	apperrors "yego/internal/platform/errors"
 	"yego/internal/platform/errors/mappings"
@@ -61,15 +64,43 @@ func (u *payForOrderUsecase) Execute(ctx context.Context, input PayForOrderInput
 		return nil, apperrors.NewApplicationError(mappings.OrderAlreadyAssignedError, errors.New("order has already been paid"))
 	}
 
+	// Cash and transfer orders are paid outside the payment providers
+	if order.PaidOffline() {
+		return nil, apperrors.NewApplicationError(mappings.OrderPaymentOfflineError, fmt.Errorf("order is paid by %s", *order.PaymentMethod))
+	}
+
 	paymentErr := ProcessPaymentForOrder(ctx, app, order, input.AuthToken, input.SecurityCode, u.calculateDeliveryFeeUse)
 	if paymentErr != nil {
 		return nil, apperrors.NewApplicationError(mappings.OrderPaymentFailedError, paymentErr)
 	}
 
-	_, _ = app.Repositories.Order.UpdateStatus(ctx, input.OrderID, "CONFIRMED")
+	// The expiry job may have cancelled the order while it was being charged, so it is only
+	// confirmed if it is still CREATED. The payment webhook may also have confirmed it first.
+	_, updateErr := app.Repositories.Order.UpdateStatusFrom(ctx, input.OrderID, domain.StatusCreated, domain.StatusConfirmed)
+	switch {
+	case updateErr == nil:
+		recordStatusChange(ctx, app, input.OrderID, order.Status, domain.StatusConfirmed, &input.UserID, nil)
+	case updateErr.Code() == mappings.OrderVersionConflictError.Code && !orderCancelled(ctx, app, input.OrderID):
+		log.Printf("Order %s: already moved on while it was being paid", input.OrderID)
+	case updateErr.Code() == mappings.OrderVersionConflictError.Code:
+		log.Printf("Order %s: charged after it was cancelled, flagging it for a refund", input.OrderID)
+		if flagErr := app.Repositories.Order.SetPaymentFlag(ctx, input.OrderID, domain.PaymentFlagRefundDue); flagErr != nil {
+			log.Printf("Warning: failed to flag order %s for a refund: %v", input.OrderID, flagErr)
+		}
+		return nil, apperrors.NewApplicationError(mappings.OrderChangedWhilePayingError, updateErr)
+	default:
+		// The charge went through; the payment webhook confirms the order
+		log.Printf("Warning: failed to confirm paid order %s: %v", input.OrderID, updateErr)
+	}
 
 	return &PayForOrderOutput{
 		OrderID: input.OrderID,
 		Status:  "CONFIRMED",
 	}, nil
 }
+
+// orderCancelled re-reads the order and reports whether it is cancelled
+func orderCancelled(ctx context.Context, app *appcontext.Context, orderID string) bool {
+	latest, err := app.Repositories.Order.GetByID(ctx, orderID)
+	return err == nil && latest.Status == domain.StatusCancelled
+}
@@ -4,11 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"math"
 
 	"yego/internal/adapters/web/integrations/payments"
 	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
 	settingsUsecase "yego/internal/usecases/settings"
 )
 
@@ -21,8 +21,13 @@ func ProcessPaymentForOrder(ctx context.Context, app *appcontext.Context, order
 		if order.UserID != nil {
 			userProfile, profileLookupErr := app.Repositories.Profile.GetByUserID(ctx, *order.UserID)
 			if profileLookupErr == nil && userProfile != nil {
-				_ = app.Repositories.Order.AssignProfile(ctx, order.ID, userProfile.ID)
+				if assignErr := app.Repositories.Order.AssignProfile(ctx, order.ID, userProfile.ID); assignErr == nil {
+					// AssignProfile bumps the stored version; keep the in-memory copy in sync
+					order.Version++
+				}
 				order.ProfileID = &userProfile.ID
+				// A snapshot taken without a profile has no delivery fee; reprice with the location
+				order.PriceBreakdown = nil
 			}
 		}
 		if order.ProfileID == nil {
@@ -39,7 +44,12 @@ func ProcessPaymentForOrder(ctx context.Context, app *appcontext.Context, order
 	// Use it directly to avoid the GetUserIDByUsername UUID mismatch.
 	paymentUserID := profile.UserID
 
-	hasPaymentMethod, paymentErr := app.Integrations.Payments.HasPaymentMethod(paymentUserID)
+	provider, providerErr := orderPaymentProvider(app, order)
+	if providerErr != nil {
+		return providerErr
+	}
+
+	hasPaymentMethod, paymentErr := provider.HasPaymentMethod(paymentUserID)
 	if paymentErr != nil {
 		return fmt.Errorf("failed to check payment method: %w", paymentErr)
 	}
@@ -47,24 +57,22 @@ func ProcessPaymentForOrder(ctx context.Context, app *appcontext.Context, order
 		return fmt.Errorf("user has no payment method configured")
 	}
 
-	// Validate that no item has a zero or missing price before charging
-	if order.Data != nil {
-		for _, item := range order.Data.Items {
-			if item.Price <= 0 {
-				return fmt.Errorf("item '%s' has no price set, cannot process payment", item.Name)
-			}
+	// Validate that no billed item has a zero or missing price before charging
+	for _, item := range order.Data.BilledItems() {
+		if item.Price <= 0 {
+			return fmt.Errorf("item '%s' has no price set, cannot process payment", item.Name)
 		}
 	}
 
-	orderTotal, calcErr := calculateOrderTotal(ctx, app, order, profile, calculateDeliveryFeeUse)
+	// Charge the stored price snapshot so the amount matches what the customer was shown
+	breakdown, calcErr := chargeablePrice(ctx, app, order, calculateDeliveryFeeUse)
 	if calcErr != nil {
 		return fmt.Errorf("failed to calculate order total: %w", calcErr)
 	}
+	orderTotal := breakdown.Total
 	if orderTotal <= 0 {
 		return fmt.Errorf("order total is zero or negative")
 	}
-	// Round to 2 decimal places to avoid floating point issues with MercadoPago
-	orderTotal = math.Round(orderTotal*100) / 100
 
 	var userEmail string
 	if token != "" {
@@ -88,84 +96,52 @@ func ProcessPaymentForOrder(ctx context.Context, app *appcontext.Context, order
 		collectorID = *settings.ManagerCollectorID
 	}
 
-	var paymentResponse *payments.ProcessPaymentResponse
-	paymentResponse, paymentErr = app.Integrations.Payments.ProcessPaymentWithSavedMethod(
-		paymentUserID,
-		orderTotal,
-		fmt.Sprintf("Pago por pedido %s", order.ID),
-		order.ID,
-		userEmail,
-		collectorID,
-		securityCode,
-	)
+	var paymentResponse *payments.ChargeResponse
+	paymentResponse, paymentErr = provider.Charge(payments.ChargeRequest{
+		UserID:       paymentUserID,
+		OrderID:      order.ID,
+		Amount:       orderTotal,
+		Description:  fmt.Sprintf("Pago por pedido %s", order.ID),
+		PayerEmail:   userEmail,
+		CollectorID:  collectorID,
+		SecurityCode: securityCode,
+	})
 	if paymentErr != nil {
 		return fmt.Errorf("failed to process payment: %w", paymentErr)
 	}
 
-	log.Printf("Payment processed for order %s: Payment ID %d, Gateway ID %s, Status %s",
-		order.ID, paymentResponse.PaymentID, paymentResponse.GatewayPaymentID, paymentResponse.Status)
-
-	if paymentResponse.Status == "rejected" {
-		return fmt.Errorf("payment rejected by gateway (status: %s)", paymentResponse.Status)
-	}
+	log.Printf("Payment processed for order %s through %s: Gateway ID %s, Status %s",
+		order.ID, provider.Name(), paymentResponse.GatewayPaymentID, paymentResponse.Status)
 
 	description := fmt.Sprintf("Pago por pedido %s", order.ID)
 	transaction := &domain.Transaction{
 		OrderID:          order.ID,
 		UserID:           paymentUserID,
 		ProfileID:        order.ProfileID,
+		Provider:         provider.Name(),
 		Amount:           orderTotal,
 		Currency:         "ARS",
 		Status:           paymentResponse.Status,
-		PaymentID:        &paymentResponse.PaymentID,
+		PaymentID:        paymentResponse.PaymentID,
 		GatewayPaymentID: &paymentResponse.GatewayPaymentID,
 		CollectorID:      &collectorID,
 		Description:      &description,
 	}
 
-	_, transErr := app.Repositories.Transaction.Create(ctx, transaction)
+	// The payment notification for this charge may already have stored it; upsert by gateway ID
+	var transErr apperrors.ApplicationError
+	if paymentResponse.GatewayPaymentID != "" {
+		_, _, transErr = app.Repositories.Transaction.UpsertByGatewayPaymentID(ctx, transaction, domain.TransactionSourceCharge)
+	} else {
+		_, transErr = app.Repositories.Transaction.Create(ctx, transaction, domain.TransactionSourceCharge)
+	}
 	if transErr != nil {
 		log.Printf("Warning: Failed to create transaction record for order %s: %v", order.ID, transErr)
 	}
 
-	return nil
-}
-
-// calculateOrderTotal calculates the total amount for an order (items + delivery fee).
-func calculateOrderTotal(ctx context.Context, app *appcontext.Context, order *domain.Order, profile *domain.Profile, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) (float64, error) {
-	if order.Data == nil || len(order.Data.Items) == 0 {
-		return 0, nil
-	}
-
-	var itemsTotal float64
-	for _, item := range order.Data.Items {
-		itemsTotal += item.Price * float64(item.Quantity)
-	}
-
-	var deliveryFee float64
-	if profile.LocationID != nil {
-		location, err := app.Repositories.Profile.GetLocationByID(ctx, *profile.LocationID)
-		if err == nil && location != nil {
-			deliveryFeeInput := settingsUsecase.CalculateDeliveryFeeInput{
-				UserLatitude:  location.Latitude,
-				UserLongitude: location.Longitude,
-				Items: make([]struct {
-					Quantity int  `json:"quantity"`
-					Weight   *int `json:"weight,omitempty"`
-				}, len(order.Data.Items)),
-			}
-
-			for i, item := range order.Data.Items {
-				deliveryFeeInput.Items[i].Quantity = item.Quantity
-				deliveryFeeInput.Items[i].Weight = item.Weight
-			}
-
-			deliveryFeeOutput, err := calculateDeliveryFeeUse.Execute(ctx, deliveryFeeInput)
-			if err == nil && deliveryFeeOutput != nil {
-				deliveryFee = deliveryFeeOutput.TotalPrice
-			}
-		}
+	if paymentResponse.Status == domain.TransactionStatusRejected {
+		return fmt.Errorf("payment rejected by gateway (status: %s)", paymentResponse.Status)
 	}
 
-	return itemsTotal + deliveryFee, nil
+	return nil
 }
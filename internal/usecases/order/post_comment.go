@@ -0,0 +1,138 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
+
+	"github.com/google/uuid"
+)
+
+// PostCommentInput represents a new comment on an order
+type PostCommentInput struct {
+	OrderID string
+	UserID  string
+	Body    string
+	// AttachmentKey is an S3 key obtained from the upload presign endpoint. Customers can only
+	// attach keys presigned for this order's comments.
+	AttachmentKey string
+	// AsAdmin posts on behalf of the managers and skips the ownership check
+	AsAdmin bool
+	// Internal marks the comment as a manager-only note; only allowed with AsAdmin
+	Internal bool
+}
+
+// PostCommentOutput represents the stored comment
+type PostCommentOutput struct {
+	Data CommentOutput `json:"data"`
+}
+
+// PostCommentUsecase defines the interface for posting a comment on an order
+type PostCommentUsecase interface {
+	Execute(ctx context.Context, input PostCommentInput) (*PostCommentOutput, apperrors.ApplicationError)
+}
+
+type postCommentUsecase struct {
+	contextFactory  appcontext.Factory
+	notificationSvc notification.Service
+}
+
+// NewPostCommentUsecase creates a new instance of PostCommentUsecase
+func NewPostCommentUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service) PostCommentUsecase {
+	return &postCommentUsecase{
+		contextFactory:  contextFactory,
+		notificationSvc: notificationSvc,
+	}
+}
+
+// Execute stores a customer message, manager reply or internal note, tells managers about it
+// and pushes manager replies to the order's customer
+func (u *postCommentUsecase) Execute(ctx context.Context, input PostCommentInput) (*PostCommentOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	if input.Internal && !input.AsAdmin {
+		return nil, apperrors.NewApplicationError(mappings.OrderCommentInvalidError, errors.New("only managers can post internal notes"))
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !input.AsAdmin && (order.UserID == nil || *order.UserID != input.UserID) {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+
+	comment := &domain.OrderComment{
+		OrderID:      order.ID,
+		AuthorUserID: input.UserID,
+		AuthorRole:   domain.CommentAuthorCustomer,
+		Visibility:   domain.CommentVisibilityCustomer,
+		Body:         strings.TrimSpace(input.Body),
+	}
+	if input.AsAdmin {
+		comment.AuthorRole = domain.CommentAuthorManager
+	}
+	if input.Internal {
+		comment.Visibility = domain.CommentVisibilityInternal
+	}
+	if key := strings.TrimSpace(input.AttachmentKey); key != "" {
+		if !input.AsAdmin && !strings.HasPrefix(key, domain.CommentAttachmentPrefix(order.ID)) {
+			return nil, apperrors.NewApplicationError(mappings.OrderCommentInvalidError, errors.New("attachment was not uploaded for this order"))
+		}
+		comment.AttachmentKey = &key
+	}
+
+	if validateErr := comment.Validate(); validateErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderCommentInvalidError, validateErr)
+	}
+
+	created, err := app.Repositories.OrderComment.Create(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.notificationSvc != nil {
+		// Managers only get the IDs, so internal notes never travel in a broadcast
+		payload := notification.OrderCommentPostedPayload{
+			CommentID: created.ID,
+			OrderID:   created.OrderID,
+		}
+		var reply *notification.OrderCommentRepliedPayload
+		if created.AuthorRole == domain.CommentAuthorManager && created.Visibility == domain.CommentVisibilityCustomer && order.UserID != nil {
+			reply = &notification.OrderCommentRepliedPayload{
+				CommentID: created.ID,
+				OrderID:   created.OrderID,
+				UserID:    *order.UserID,
+				Body:      created.Body,
+				CreatedAt: created.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			}
+			if created.AttachmentKey != nil {
+				reply.AttachmentKey = *created.AttachmentKey
+			}
+		}
+		go func() {
+			if notifyErr := u.notificationSvc.NotifyOrderCommentPosted(payload); notifyErr != nil {
+				log.Printf("Warning: failed to notify comment %s on order %s: %v", payload.CommentID, payload.OrderID, notifyErr)
+			}
+			if reply != nil {
+				if notifyErr := u.notificationSvc.NotifyOrderCommentReplied(*reply); notifyErr != nil {
+					log.Printf("Warning: failed to send reply %s to the customer of order %s: %v", reply.CommentID, reply.OrderID, notifyErr)
+				}
+			}
+		}()
+	}
+
+	return &PostCommentOutput{Data: toCommentOutput(created)}, nil
+}
@@ -0,0 +1,99 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	s3service "yego/internal/services/s3"
+
+	"github.com/google/uuid"
+)
+
+// commentAttachmentExtensions lists the file types a customer can attach to a comment
+var commentAttachmentExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".webp": true,
+	".pdf":  true,
+}
+
+// PresignCommentAttachmentInput represents a customer asking to upload a comment attachment
+type PresignCommentAttachmentInput struct {
+	OrderID  string
+	UserID   string
+	Filename string
+}
+
+// PresignCommentAttachmentOutput represents where to upload the attachment and the key to post
+type PresignCommentAttachmentOutput struct {
+	UploadURL string `json:"upload_url"`
+	PublicURL string `json:"public_url"`
+	Key       string `json:"key"`
+}
+
+// PresignCommentAttachmentUsecase defines the interface for presigning a comment attachment upload
+type PresignCommentAttachmentUsecase interface {
+	Execute(ctx context.Context, input PresignCommentAttachmentInput) (*PresignCommentAttachmentOutput, apperrors.ApplicationError)
+}
+
+type presignCommentAttachmentUsecase struct {
+	contextFactory appcontext.Factory
+	s3Client       *s3service.Client
+}
+
+// NewPresignCommentAttachmentUsecase creates a new instance of PresignCommentAttachmentUsecase
+func NewPresignCommentAttachmentUsecase(contextFactory appcontext.Factory, s3Client *s3service.Client) PresignCommentAttachmentUsecase {
+	return &presignCommentAttachmentUsecase{
+		contextFactory: contextFactory,
+		s3Client:       s3Client,
+	}
+}
+
+// Execute presigns an upload under the order's comment attachment prefix, which is the only
+// place a customer comment can attach from
+func (u *presignCommentAttachmentUsecase) Execute(ctx context.Context, input PresignCommentAttachmentInput) (*PresignCommentAttachmentOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(input.Filename))
+	if !commentAttachmentExtensions[ext] {
+		return nil, apperrors.NewApplicationError(mappings.OrderCommentInvalidError, fmt.Errorf("unsupported attachment type %q", ext))
+	}
+
+	if u.s3Client == nil || !u.s3Client.IsConfigured() {
+		return nil, apperrors.NewApplicationError(mappings.OrderCommentAttachmentUnavailableError, errors.New("S3 not configured"))
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.UserID == nil || *order.UserID != input.UserID {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+
+	key := domain.CommentAttachmentPrefix(order.ID) + uuid.New().String() + ext
+	uploadURL, publicURL, presignErr := u.s3Client.PresignPut(key, 15*time.Minute)
+	if presignErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderCommentAttachmentUnavailableError, presignErr)
+	}
+
+	return &PresignCommentAttachmentOutput{
+		UploadURL: uploadURL,
+		PublicURL: publicURL,
+		Key:       key,
+	}, nil
+}
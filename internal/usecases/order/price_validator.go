@@ -123,14 +123,36 @@ func findImportByName(records []*domain.ImportRecord, name string) *domain.Impor
 	return nil
 }
 
+// itemPriceCheck records the outcome of validating a single item against the import records
+type itemPriceCheck struct {
+	Matched       bool
+	PreviousName  string
+	PreviousPrice float64
+}
+
 // correctItemPrices looks up each item by code (or name as fallback) against the
 // import records and corrects Name and Price to match. Returns the (possibly
 // corrected) slice and a boolean indicating whether any changes were made.
 func correctItemPrices(items []domain.OrderItem, records []*domain.ImportRecord) ([]domain.OrderItem, bool) {
+	corrected, checks := checkItemPrices(items, records)
 	hasChanges := false
+	for i, check := range checks {
+		if corrected[i].Name != check.PreviousName || corrected[i].Price != check.PreviousPrice {
+			hasChanges = true
+		}
+	}
+	log.Printf("[PriceValidator] hasChanges=%v", hasChanges)
+	return corrected, hasChanges
+}
+
+// checkItemPrices works like correctItemPrices but reports, for every item,
+// whether it matched an import record and what its name and price were before.
+func checkItemPrices(items []domain.OrderItem, records []*domain.ImportRecord) ([]domain.OrderItem, []itemPriceCheck) {
 	corrected := make([]domain.OrderItem, len(items))
+	checks := make([]itemPriceCheck, len(items))
 	for i, item := range items {
 		corrected[i] = item
+		checks[i] = itemPriceCheck{PreviousName: item.Name, PreviousPrice: item.Price}
 		log.Printf("[PriceValidator] item[%d] code=%q name=%q price=%.2f", i, item.Code, item.Name, item.Price)
 
 		if item.Code == "" && item.Name == "" {
@@ -153,21 +175,19 @@ func correctItemPrices(items []domain.OrderItem, records []*domain.ImportRecord)
 			continue
 		}
 
+		checks[i].Matched = true
 		log.Printf("[PriceValidator] item[%d] matched import id=%s", i, matched.ID)
 		if name := importName(matched.Data); name != "" && name != item.Name {
 			log.Printf("[PriceValidator] item[%d] correcting name: %q → %q", i, item.Name, name)
 			corrected[i].Name = name
-			hasChanges = true
 		}
 		if price, ok := importPrice(matched.Data); ok {
 			log.Printf("[PriceValidator] item[%d] import price=%.4f item price=%.4f equal=%v", i, price, item.Price, price == item.Price)
 			if price != item.Price {
 				log.Printf("[PriceValidator] item[%d] correcting price: %.4f → %.4f", i, item.Price, price)
 				corrected[i].Price = price
-				hasChanges = true
 			}
 		}
 	}
-	log.Printf("[PriceValidator] hasChanges=%v", hasChanges)
-	return corrected, hasChanges
+	return corrected, checks
 }
@@ -0,0 +1,102 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	settingsUsecase "yego/internal/usecases/settings"
+)
+
+// PriceOrder snapshots the price of the order's billed items into order.PriceBreakdown,
+// using the current delivery settings and the location of the order's profile.
+// Discounts already on the order are kept. An order without billed items is left unpriced.
+// The caller persists the order.
+func PriceOrder(ctx context.Context, app *appcontext.Context, order *domain.Order, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) error {
+	items := order.Data.BilledItems()
+	if len(items) == 0 {
+		order.PriceBreakdown = nil
+		return nil
+	}
+
+	var deliveryFee domain.DeliveryFeeBreakdown
+	if order.ProfileID != nil {
+		profile, profileErr := app.Repositories.Profile.GetByID(ctx, *order.ProfileID)
+		if profileErr != nil {
+			return fmt.Errorf("failed to get profile: %w", profileErr)
+		}
+		deliveryFee = calculateDeliveryFee(ctx, app, profile, items, calculateDeliveryFeeUse)
+	}
+
+	var discounts []domain.PriceDiscount
+	if order.PriceBreakdown != nil {
+		discounts = order.PriceBreakdown.Discounts
+	}
+
+	order.PriceBreakdown = domain.NewPriceBreakdown(order.Data.ItemsTotal(), deliveryFee, discounts, time.Now())
+	return nil
+}
+
+// calculateDeliveryFee prices delivering the items to the profile's location.
+// Without a location, or when the fee cannot be calculated, delivery is free.
+func calculateDeliveryFee(ctx context.Context, app *appcontext.Context, profile *domain.Profile, items []domain.OrderItem, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) domain.DeliveryFeeBreakdown {
+	if profile == nil || profile.LocationID == nil {
+		return domain.DeliveryFeeBreakdown{}
+	}
+
+	location, err := app.Repositories.Profile.GetLocationByID(ctx, *profile.LocationID)
+	if err != nil || location == nil {
+		return domain.DeliveryFeeBreakdown{}
+	}
+
+	deliveryFeeInput := settingsUsecase.CalculateDeliveryFeeInput{
+		UserLatitude:  location.Latitude,
+		UserLongitude: location.Longitude,
+		Items: make([]struct {
+			Quantity int  `json:"quantity"`
+			Weight   *int `json:"weight,omitempty"`
+		}, len(items)),
+	}
+	for i, item := range items {
+		deliveryFeeInput.Items[i].Quantity = item.Quantity
+		deliveryFeeInput.Items[i].Weight = item.Weight
+	}
+
+	fee, feeErr := calculateDeliveryFeeUse.Execute(ctx, deliveryFeeInput)
+	if feeErr != nil || fee == nil {
+		log.Printf("Warning: failed to calculate delivery fee for profile %s: %v", profile.ID, feeErr)
+		return domain.DeliveryFeeBreakdown{}
+	}
+
+	return domain.DeliveryFeeBreakdown{
+		BasePrice:     fee.BasePrice,
+		DistanceKm:    fee.DistanceKm,
+		DistancePrice: fee.DistancePrice,
+		TotalWeightG:  fee.TotalWeightG,
+		WeightPrice:   fee.WeightPrice,
+		Total:         fee.TotalPrice,
+	}
+}
+
+// chargeablePrice returns the stored price snapshot that payments charge.
+// Orders priced before snapshots existed are priced once and stored.
+func chargeablePrice(ctx context.Context, app *appcontext.Context, order *domain.Order, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) (*domain.PriceBreakdown, error) {
+	if order.PriceBreakdown != nil {
+		return order.PriceBreakdown, nil
+	}
+
+	if err := PriceOrder(ctx, app, order, calculateDeliveryFeeUse); err != nil {
+		return nil, err
+	}
+	if order.PriceBreakdown == nil {
+		return nil, fmt.Errorf("order has no items to charge")
+	}
+	if err := app.Repositories.Order.SetPriceBreakdown(ctx, order.ID, order.PriceBreakdown); err != nil {
+		return nil, fmt.Errorf("failed to store price breakdown: %w", err)
+	}
+
+	return order.PriceBreakdown, nil
+}
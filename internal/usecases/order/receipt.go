@@ -0,0 +1,322 @@
+package order
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+
+	"yego/internal/adapters/web/integrations/payments"
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/services/pdf"
+	s3service "yego/internal/services/s3"
+
+	"github.com/google/uuid"
+)
+
+// GetReceiptInput represents the input for getting the receipt of an order
+type GetReceiptInput struct {
+	OrderID string
+	UserID  string
+	// AsAdmin skips the ownership check
+	AsAdmin bool
+}
+
+// GetReceiptOutput is a rendered PDF receipt
+type GetReceiptOutput struct {
+	Filename string
+	Content  []byte
+	// URL is where the receipt was stored in S3, when storing is enabled
+	URL *string
+}
+
+// GetReceiptUsecase defines the interface for getting the receipt of a paid order
+type GetReceiptUsecase interface {
+	Execute(ctx context.Context, input GetReceiptInput) (*GetReceiptOutput, apperrors.ApplicationError)
+}
+
+type getReceiptUsecase struct {
+	contextFactory appcontext.Factory
+	s3Client       *s3service.Client
+	storeInS3      bool
+}
+
+// NewGetReceiptUsecase creates a new instance of GetReceiptUsecase.
+// When storeInS3 is set and the client is configured, every receipt is also uploaded once.
+func NewGetReceiptUsecase(contextFactory appcontext.Factory, s3Client *s3service.Client, storeInS3 bool) GetReceiptUsecase {
+	return &getReceiptUsecase{
+		contextFactory: contextFactory,
+		s3Client:       s3Client,
+		storeInS3:      storeInS3,
+	}
+}
+
+// Execute renders the receipt of an order that has an approved payment
+func (u *getReceiptUsecase) Execute(ctx context.Context, input GetReceiptInput) (*GetReceiptOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !input.AsAdmin && (order.UserID == nil || *order.UserID != input.UserID) {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+
+	transactions, err := app.Repositories.Transaction.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var payment *domain.Transaction
+	var refunded float64
+	for _, t := range transactions {
+		switch {
+		case t.Type == domain.TransactionTypePayment && t.Status == "approved":
+			payment = t
+		case t.Type == domain.TransactionTypeRefund && t.Status == "approved":
+			refunded += t.Amount
+		}
+	}
+	if payment == nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderReceiptNotAvailableError, errors.New("no approved payment"))
+	}
+
+	// Orders paid before prices were snapshotted print what was actually charged; a receipt
+	// is never recomputed with today's settings
+	breakdown := order.PriceBreakdown
+	if breakdown == nil {
+		breakdown = paidBreakdown(payment)
+	}
+
+	businessName := "Yego"
+	if settings, _ := app.Repositories.Settings.Get(ctx); settings != nil && settings.BusinessName != "" {
+		businessName = settings.BusinessName
+	}
+
+	content := renderReceipt(receiptData{
+		BusinessName: businessName,
+		Order:        order,
+		Breakdown:    breakdown,
+		Payment:      payment,
+		Refunded:     refunded,
+		PaidOnly:     order.PriceBreakdown == nil,
+	})
+
+	output := &GetReceiptOutput{
+		Filename: fmt.Sprintf("recibo-%s.pdf", shortOrderID(order.ID)),
+		Content:  content,
+	}
+
+	if u.storeInS3 && u.s3Client != nil && u.s3Client.IsConfigured() {
+		// The key follows the content, so each version of a receipt (e.g. after a refund) is uploaded once
+		sum := sha256.Sum256(content)
+		key := fmt.Sprintf("receipts/%s-%s.pdf", order.ID, hex.EncodeToString(sum[:6]))
+		if exists, headErr := u.s3Client.ObjectExists(key); headErr == nil && exists {
+			url := u.s3Client.PublicURL(key)
+			output.URL = &url
+		} else if url, uploadErr := u.s3Client.PutObject(key, "application/pdf", content); uploadErr != nil {
+			// The customer still gets the receipt; storing it is best effort
+			log.Printf("Warning: failed to store receipt for order %s: %v", order.ID, uploadErr)
+		} else {
+			output.URL = &url
+		}
+	}
+
+	return output, nil
+}
+
+// receiptData is everything printed on a receipt
+type receiptData struct {
+	BusinessName string
+	Order        *domain.Order
+	Breakdown    *domain.PriceBreakdown
+	Payment      *domain.Transaction
+	Refunded     float64
+	// PaidOnly prints just the total, for orders without a price snapshot
+	PaidOnly bool
+}
+
+// paidBreakdown is the breakdown of an order without a price snapshot: only the amount of
+// its payment is known
+func paidBreakdown(payment *domain.Transaction) *domain.PriceBreakdown {
+	return &domain.PriceBreakdown{
+		Subtotal:  payment.Amount,
+		Discounts: []domain.PriceDiscount{},
+		Total:     payment.Amount,
+		Currency:  payment.Currency,
+		PricedAt:  payment.CreatedAt,
+	}
+}
+
+// Receipt layout, in points
+const (
+	receiptMargin     = 50.0
+	receiptLineHeight = 16.0
+	receiptBottom     = pdf.PageHeight - 60
+	receiptAmountX    = pdf.PageWidth - receiptMargin
+	receiptQtyX       = 380.0
+)
+
+// renderReceipt lays out the receipt as a single column, continuing on new pages as needed
+func renderReceipt(r receiptData) []byte {
+	doc := pdf.New()
+	y := 70.0
+
+	newLine := func() {
+		y += receiptLineHeight
+		if y > receiptBottom {
+			doc.AddPage()
+			y = 70.0
+		}
+	}
+	row := func(font pdf.Font, label, amount string) {
+		doc.Text(receiptMargin, y, font, 10, label)
+		doc.TextRight(receiptAmountX, y, 10, amount)
+		newLine()
+	}
+
+	doc.Text(receiptMargin, y, pdf.HelveticaBold, 18, r.BusinessName)
+	y += 24
+	doc.Text(receiptMargin, y, pdf.Helvetica, 12, "Comprobante de pago")
+	y += 24
+
+	doc.Text(receiptMargin, y, pdf.Helvetica, 10, "Pedido: "+r.Order.ID)
+	newLine()
+	doc.Text(receiptMargin, y, pdf.Helvetica, 10, "Fecha del pedido: "+r.Order.CreatedAt.Format("02/01/2006 15:04"))
+	newLine()
+	doc.Text(receiptMargin, y, pdf.Helvetica, 10, "Fecha de pago: "+r.Payment.CreatedAt.Format("02/01/2006 15:04"))
+	newLine()
+	newLine()
+
+	doc.Text(receiptMargin, y, pdf.HelveticaBold, 10, "Producto")
+	doc.Text(receiptQtyX, y, pdf.HelveticaBold, 10, "Cant.")
+	doc.TextRight(receiptAmountX, y, 10, "Importe")
+	y += 6
+	doc.Line(receiptMargin, y, receiptAmountX, y)
+	newLine()
+
+	if r.Order.Data != nil {
+		for _, item := range r.Order.Data.Items {
+			billed, ok := item.Billed()
+			name := item.Name
+			switch {
+			case !ok:
+				name += " (sin stock)"
+			case item.FulfilmentStatus() == domain.ItemSubstituted:
+				name = billed.Name + " (reemplaza a " + item.Name + ")"
+			}
+
+			doc.Text(receiptMargin, y, pdf.Helvetica, 10, truncate(name, 60))
+			if ok {
+				doc.Text(receiptQtyX, y, pdf.Helvetica, 10, fmt.Sprintf("%d", billed.Quantity))
+				doc.TextRight(receiptAmountX, y, 10, formatMoney(billed.Price*float64(billed.Quantity)))
+			} else {
+				doc.TextRight(receiptAmountX, y, 10, "-")
+			}
+			newLine()
+		}
+	}
+
+	doc.Line(receiptMargin, y-10, receiptAmountX, y-10)
+	newLine()
+
+	if !r.PaidOnly {
+		row(pdf.Helvetica, "Subtotal", formatMoney(r.Breakdown.Subtotal))
+		row(pdf.Helvetica, "Envío", formatMoney(r.Breakdown.DeliveryFee.Total))
+		for _, d := range r.Breakdown.Discounts {
+			label := "Descuento"
+			if d.Code != "" {
+				label += " " + d.Code
+			} else if d.Description != "" {
+				label += " " + d.Description
+			}
+			row(pdf.Helvetica, label, "-"+formatMoney(d.Amount))
+		}
+	}
+	row(pdf.HelveticaBold, "Total", formatMoney(r.Breakdown.Total))
+	if r.Payment.Amount != r.Breakdown.Total {
+		row(pdf.Helvetica, "Pagado", formatMoney(r.Payment.Amount))
+	}
+	if r.Refunded > 0 {
+		row(pdf.Helvetica, "Reintegrado", "-"+formatMoney(r.Refunded))
+	}
+	newLine()
+
+	doc.Text(receiptMargin, y, pdf.Helvetica, 10, "Medio de pago: "+paymentMethodLabel(r.Payment))
+	newLine()
+	if r.Payment.GatewayPaymentID != nil {
+		doc.Text(receiptMargin, y, pdf.Helvetica, 10, "ID de pago: "+*r.Payment.GatewayPaymentID)
+		newLine()
+	}
+
+	return doc.Bytes()
+}
+
+// paymentMethodLabel describes how a payment was made. Cash and transfers are recorded under
+// their own provider name; saved-card payments go through the payment service and carry its
+// payment ID, and other Mercado Pago payments come from its links.
+func paymentMethodLabel(t *domain.Transaction) string {
+	switch {
+	case t.Provider == strings.ToLower(string(domain.PaymentMethodCash)):
+		return "Efectivo"
+	case t.Provider == strings.ToLower(string(domain.PaymentMethodTransfer)):
+		return "Transferencia bancaria"
+	case t.PaymentID != nil:
+		return "Tarjeta guardada (Mercado Pago)"
+	case t.Provider == payments.ProviderMercadoPago:
+		return "Link de pago de Mercado Pago"
+	default:
+		return "Tarjeta"
+	}
+}
+
+// formatMoney formats an amount in pesos, e.g. $ 1.234,50
+func formatMoney(amount float64) string {
+	cents := int64(math.Round(amount * 100))
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	whole := fmt.Sprintf("%d", cents/100)
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	return fmt.Sprintf("%s$ %s,%02d", sign, grouped.String(), cents%100)
+}
+
+// shortOrderID returns the first block of an order UUID, as shown to customers
+func shortOrderID(id string) string {
+	if i := strings.IndexByte(id, '-'); i > 0 {
+		return id[:i]
+	}
+	return id
+}
+
+// truncate shortens s to at most max characters so it does not run into the next column
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-3]) + "..."
+}
@@ -0,0 +1,211 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	orderrepo "yego/internal/adapters/datasources/repositories/order"
+	transactionrepo "yego/internal/adapters/datasources/repositories/transaction"
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
+
+	"github.com/google/uuid"
+)
+
+// parsePaymentMethod validates the payment method chosen for an order; empty leaves it unset
+func parsePaymentMethod(s string) (*domain.PaymentMethod, apperrors.ApplicationError) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return nil, nil
+	}
+	if !domain.IsValidPaymentMethod(s) {
+		return nil, apperrors.NewApplicationError(mappings.OrderPaymentMethodInvalidError, fmt.Errorf("unknown payment method %q", s))
+	}
+	method := domain.PaymentMethod(s)
+	return &method, nil
+}
+
+// ReceivePaymentInput represents a courier or manager confirming a cash or transfer payment
+type ReceivePaymentInput struct {
+	OrderID string
+	// UserID is the courier or manager who received the payment
+	UserID string
+	// Amount received; nil takes the priced order total
+	Amount *float64
+	// ProofKey is the S3 key of the transfer proof, obtained from the upload presign endpoint
+	ProofKey string
+}
+
+// ReceivedPaymentOutput represents the transaction recorded for a received payment
+type ReceivedPaymentOutput struct {
+	TransactionID     string  `json:"transaction_id"`
+	Method            string  `json:"method"`
+	Amount            float64 `json:"amount"`
+	Currency          string  `json:"currency"`
+	ConfirmedByUserID string  `json:"confirmed_by_user_id"`
+	ProofKey          *string `json:"proof_key,omitempty"`
+	ReceivedAt        string  `json:"received_at"`
+}
+
+// ReceivePaymentOutput represents the order after its payment was received
+type ReceivePaymentOutput struct {
+	Data    OrderOutputData       `json:"data"`
+	Payment ReceivedPaymentOutput `json:"payment"`
+}
+
+// ReceivePaymentUsecase defines the interface for confirming receipt of an offline payment
+type ReceivePaymentUsecase interface {
+	Execute(ctx context.Context, input ReceivePaymentInput) (*ReceivePaymentOutput, apperrors.ApplicationError)
+}
+
+type receivePaymentUsecase struct {
+	contextFactory  appcontext.Factory
+	notificationSvc notification.Service
+}
+
+// NewReceivePaymentUsecase creates a new instance of ReceivePaymentUsecase
+func NewReceivePaymentUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service) ReceivePaymentUsecase {
+	return &receivePaymentUsecase{
+		contextFactory:  contextFactory,
+		notificationSvc: notificationSvc,
+	}
+}
+
+// Execute records a cash or transfer payment as an approved transaction and marks the order
+// paid, which lets a cash order be delivered. An order still waiting in CREATED is confirmed.
+// A payment is received once per order.
+func (u *receivePaymentUsecase) Execute(ctx context.Context, input ReceivePaymentInput) (*ReceivePaymentOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !order.PaidOffline() {
+		return nil, apperrors.NewApplicationError(mappings.OrderPaymentNotOfflineError, errors.New("order is not paid in cash or by transfer"))
+	}
+	if order.Status == domain.StatusCancelled {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidTransitionError, errors.New("order is cancelled"))
+	}
+	if order.PaymentReceivedAt != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderPaymentAlreadyReceivedError, fmt.Errorf("received at %s", order.PaymentReceivedAt.Format("2006-01-02T15:04:05Z")))
+	}
+
+	method := *order.PaymentMethod
+	proofKey := strings.TrimSpace(input.ProofKey)
+	if method == domain.PaymentMethodTransfer && proofKey == "" {
+		return nil, apperrors.NewApplicationError(mappings.OrderPaymentProofRequiredError, errors.New("empty proof key"))
+	}
+
+	var amount float64
+	if input.Amount != nil {
+		amount = roundCents(*input.Amount)
+	} else if order.PriceBreakdown != nil {
+		amount = order.PriceBreakdown.Total
+	}
+	if amount <= 0 {
+		return nil, apperrors.NewApplicationError(mappings.OrderPaymentInvalidAmountError, fmt.Errorf("amount %.2f", amount))
+	}
+
+	userID := ""
+	if order.UserID != nil {
+		userID = *order.UserID
+	}
+	currency := "ARS"
+	if order.PriceBreakdown != nil && order.PriceBreakdown.Currency != "" {
+		currency = order.PriceBreakdown.Currency
+	}
+	description := fmt.Sprintf("Pago en efectivo recibido para pedido %s", order.ID)
+	if method == domain.PaymentMethodTransfer {
+		description = fmt.Sprintf("Transferencia recibida para pedido %s", order.ID)
+	}
+	transaction := &domain.Transaction{
+		OrderID:           order.ID,
+		UserID:            userID,
+		ProfileID:         order.ProfileID,
+		Type:              domain.TransactionTypePayment,
+		Provider:          strings.ToLower(string(method)),
+		Amount:            amount,
+		Currency:          currency,
+		Status:            domain.TransactionStatusApproved,
+		Description:       &description,
+		ConfirmedByUserID: &input.UserID,
+	}
+	if proofKey != "" {
+		transaction.ProofKey = &proofKey
+	}
+
+	// Marking the order and recording the payment commit together. Marking first makes a
+	// second confirmation fail before it records a second payment.
+	receivedAt := time.Now()
+	var updated *domain.Order
+	txErr := app.Repositories.InTransaction(ctx, func(orders orderrepo.Repository, transactions transactionrepo.Repository) apperrors.ApplicationError {
+		marked, markErr := orders.MarkPaymentReceived(ctx, order.ID, receivedAt)
+		if markErr != nil {
+			return markErr
+		}
+		if _, createErr := transactions.Create(ctx, transaction, domain.TransactionSourceReceipt); createErr != nil {
+			return createErr
+		}
+		updated = marked
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	log.Printf("Order %s: %s payment of %.2f received by %s", order.ID, method, amount, input.UserID)
+
+	if updated.Status == domain.StatusCreated {
+		previousStatus := updated.Status
+		confirmed, statusErr := app.Repositories.Order.UpdateStatusFrom(ctx, updated.ID, domain.StatusCreated, domain.StatusConfirmed)
+		if statusErr != nil {
+			log.Printf("Warning: failed to confirm order %s after receiving its payment: %v", updated.ID, statusErr)
+		} else {
+			var actorUserID *string
+			if input.UserID != "" {
+				actorUserID = &input.UserID
+			}
+			recordStatusChange(ctx, app, confirmed.ID, previousStatus, confirmed.Status, actorUserID, nil)
+			updated = confirmed
+
+			if u.notificationSvc != nil {
+				payload := notification.OrderUpdatedPayload{
+					OrderID: confirmed.ID,
+					Status:  string(confirmed.Status),
+					ETA:     confirmed.ETA,
+				}
+				go func() {
+					if notifyErr := u.notificationSvc.NotifyOrderUpdated(payload); notifyErr != nil {
+						log.Printf("Warning: failed to notify order updated %s: %v", payload.OrderID, notifyErr)
+					}
+				}()
+			}
+		}
+	}
+
+	return &ReceivePaymentOutput{
+		Data: toOrderOutputData(updated, false),
+		Payment: ReceivedPaymentOutput{
+			TransactionID:     transaction.ID,
+			Method:            string(method),
+			Amount:            amount,
+			Currency:          currency,
+			ConfirmedByUserID: input.UserID,
+			ProofKey:          transaction.ProofKey,
+			ReceivedAt:        receivedAt.Format("2006-01-02T15:04:05Z"),
+		},
+	}, nil
+}
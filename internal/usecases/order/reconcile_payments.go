@@ -0,0 +1,349 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"yego/internal/adapters/web/integrations/payments"
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
+)
+
+const (
+	// defaultReconcileLookback is how far back a run looks when no lookback is given
+	defaultReconcileLookback = 72 * time.Hour
+	// reconcileBatchSize bounds how many orders a single run checks against the gateway
+	reconcileBatchSize = 500
+)
+
+// Kinds of mismatch found by a reconciliation run
+const (
+	// MismatchMissingTransaction is a gateway payment with no transaction recorded for it
+	MismatchMissingTransaction = "MISSING_TRANSACTION"
+	// MismatchStatus is a transaction whose status differs from the gateway's
+	MismatchStatus = "STATUS_MISMATCH"
+	// MismatchAmount is a transaction whose amount differs from the gateway's
+	MismatchAmount = "AMOUNT_MISMATCH"
+	// MismatchUnknownPayment is a transaction the gateway does not know
+	MismatchUnknownPayment = "UNKNOWN_GATEWAY_PAYMENT"
+	// MismatchPaidNotConfirmed is an order still CREATED although a payment for it was approved
+	MismatchPaidNotConfirmed = "PAID_ORDER_NOT_CONFIRMED"
+	// MismatchApprovedOnCancelled is a CANCELLED order with approved payments left unrefunded
+	MismatchApprovedOnCancelled = "APPROVED_PAYMENT_ON_CANCELLED_ORDER"
+)
+
+// ReconcilePaymentsInput represents the input for a reconciliation run
+type ReconcilePaymentsInput struct {
+	Now time.Time
+	// Lookback selects the orders updated, or paid, within this long before Now
+	Lookback time.Duration
+	// AutoFix applies the safe fixes: recording missing payments, moving stale statuses
+	// forward and confirming paid orders. Everything else is only reported.
+	AutoFix bool
+}
+
+// PaymentMismatchOutput represents a difference between our records and the gateway
+type PaymentMismatchOutput struct {
+	Kind             string   `json:"kind"`
+	OrderID          string   `json:"order_id"`
+	OrderStatus      string   `json:"order_status"`
+	TransactionID    *string  `json:"transaction_id,omitempty"`
+	GatewayPaymentID *string  `json:"gateway_payment_id,omitempty"`
+	LocalStatus      string   `json:"local_status,omitempty"`
+	GatewayStatus    string   `json:"gateway_status,omitempty"`
+	LocalAmount      *float64 `json:"local_amount,omitempty"`
+	GatewayAmount    *float64 `json:"gateway_amount,omitempty"`
+	Detail           string   `json:"detail"`
+	Fixable          bool     `json:"fixable"`
+	Fixed            bool     `json:"fixed"`
+}
+
+// ReconcilePaymentsOutput is the report of a reconciliation run
+type ReconcilePaymentsOutput struct {
+	Since           string                  `json:"since"`
+	AutoFix         bool                    `json:"auto_fix"`
+	OrdersChecked   int                     `json:"orders_checked"`
+	PaymentsChecked int                     `json:"payments_checked"`
+	Truncated       bool                    `json:"truncated"`
+	Fixed           int                     `json:"fixed"`
+	Mismatches      []PaymentMismatchOutput `json:"mismatches"`
+	Errors          []string                `json:"errors,omitempty"`
+	StartedAt       string                  `json:"started_at"`
+	FinishedAt      string                  `json:"finished_at"`
+}
+
+// ReconcilePaymentsUsecase defines the interface for reconciling recent orders with the payment gateway
+type ReconcilePaymentsUsecase interface {
+	Execute(ctx context.Context, input ReconcilePaymentsInput) (*ReconcilePaymentsOutput, apperrors.ApplicationError)
+}
+
+type reconcilePaymentsUsecase struct {
+	contextFactory appcontext.Factory
+	reactions      paymentReactions
+}
+
+// NewReconcilePaymentsUsecase creates a new instance of ReconcilePaymentsUsecase
+func NewReconcilePaymentsUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service) ReconcilePaymentsUsecase {
+	return &reconcilePaymentsUsecase{
+		contextFactory: contextFactory,
+		reactions:      paymentReactions{notificationSvc: notificationSvc},
+	}
+}
+
+// Execute compares the transactions of recently active orders with the payments their
+// provider holds for them and reports every difference. Fixes go through the same path as payment
+// webhooks, so a fixed order ends up exactly as if the lost notification had arrived.
+// An order the gateway cannot be queried for is reported as an error and skipped.
+func (u *reconcilePaymentsUsecase) Execute(ctx context.Context, input ReconcilePaymentsInput) (*ReconcilePaymentsOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if input.Lookback < 0 {
+		return nil, apperrors.NewApplicationError(mappings.PaymentReconciliationInvalidInputError, fmt.Errorf("invalid lookback %s", input.Lookback))
+	}
+	if input.Lookback == 0 {
+		input.Lookback = defaultReconcileLookback
+	}
+
+	since := input.Now.Add(-input.Lookback)
+	orders, appErr := app.Repositories.Order.ListActiveSince(ctx, since, reconcileBatchSize+1)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	output := &ReconcilePaymentsOutput{
+		Since:      since.Format("2006-01-02T15:04:05Z"),
+		AutoFix:    input.AutoFix,
+		Mismatches: []PaymentMismatchOutput{},
+		StartedAt:  time.Now().Format("2006-01-02T15:04:05Z"),
+	}
+	if len(orders) > reconcileBatchSize {
+		orders = orders[:reconcileBatchSize]
+		output.Truncated = true
+	}
+
+	for _, order := range orders {
+		if err := u.reconcileOrder(ctx, app, order, input.AutoFix, output); err != nil {
+			log.Printf("Reconciliation: order %s skipped: %v", order.ID, err)
+			output.Errors = append(output.Errors, fmt.Sprintf("order %s: %v", order.ID, err))
+			continue
+		}
+		output.OrdersChecked++
+	}
+
+	for _, m := range output.Mismatches {
+		if m.Fixed {
+			output.Fixed++
+		}
+	}
+	output.FinishedAt = time.Now().Format("2006-01-02T15:04:05Z")
+
+	return output, nil
+}
+
+// reconcileOrder compares one order with the gateway and appends its mismatches to the report
+func (u *reconcilePaymentsUsecase) reconcileOrder(ctx context.Context, app *appcontext.Context, order *domain.Order, autoFix bool, output *ReconcilePaymentsOutput) error {
+	provider, appErr := orderPaymentProvider(app, order)
+	if appErr != nil {
+		return appErr
+	}
+
+	transactions, appErr := app.Repositories.Transaction.ListByOrderID(ctx, order.ID)
+	if appErr != nil {
+		return appErr
+	}
+	// Fixes may move the order, the report shows the status it was found in
+	status := order.Status
+
+	local := make(map[string]*domain.Transaction)
+	for _, t := range transactions {
+		if t.Type == domain.TransactionTypePayment && t.Provider == provider.Name() && t.GatewayPaymentID != nil && *t.GatewayPaymentID != "" {
+			local[*t.GatewayPaymentID] = t
+		}
+	}
+
+	found, err := provider.SearchPayments(order.ID)
+	if err != nil {
+		return fmt.Errorf("searching %s payments: %w", provider.Name(), err)
+	}
+	gateway := make(map[string]payments.Payment)
+	for _, p := range found {
+		gateway[p.ID] = p
+	}
+
+	// Payments made with a reference other than the order ID are fetched one by one
+	for id := range local {
+		if _, found := gateway[id]; found {
+			continue
+		}
+		payment, err := provider.FetchPayment(id)
+		if errors.Is(err, payments.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("getting %s payment %s: %w", provider.Name(), id, err)
+		}
+		gateway[id] = *payment
+	}
+
+	var mismatches []PaymentMismatchOutput
+	var toApply []payments.Payment
+	effective := make([]*domain.Transaction, 0, len(transactions))
+	for _, t := range transactions {
+		if t.Type != domain.TransactionTypePayment || t.GatewayPaymentID == nil {
+			effective = append(effective, t)
+			continue
+		}
+		current := *t
+		if p, found := gateway[*t.GatewayPaymentID]; found {
+			current.Status = p.Status
+		}
+		effective = append(effective, &current)
+	}
+
+	for _, id := range sortedKeys(local) {
+		if _, found := gateway[id]; !found {
+			t := local[id]
+			mismatches = append(mismatches, newTransactionMismatch(MismatchUnknownPayment, order, t, nil,
+				"the gateway has no payment with this ID"))
+		}
+	}
+
+	for _, id := range sortedKeys(gateway) {
+		p := gateway[id]
+		output.PaymentsChecked++
+		t, found := local[id]
+		if !found {
+			m := newTransactionMismatch(MismatchMissingTransaction, order, nil, &p,
+				fmt.Sprintf("gateway payment %s is %s but no transaction was recorded", id, p.Status))
+			m.Fixable = true
+			mismatches = append(mismatches, m)
+			toApply = append(toApply, p)
+			gatewayOnly := &domain.Transaction{Type: domain.TransactionTypePayment, Amount: p.Amount, Status: p.Status, GatewayPaymentID: &p.ID}
+			effective = append(effective, gatewayOnly)
+			continue
+		}
+
+		if t.Status != p.Status {
+			m := newTransactionMismatch(MismatchStatus, order, t, &p,
+				fmt.Sprintf("transaction is %s but the gateway reports %s", t.Status, p.Status))
+			m.Fixable = domain.CanMoveTransactionStatus(t.Status, p.Status)
+			if !m.Fixable {
+				m.Detail += "; moving back in the payment lifecycle needs a manual review"
+			}
+			mismatches = append(mismatches, m)
+			if m.Fixable {
+				toApply = append(toApply, p)
+			}
+		}
+		if roundCents(t.Amount) != roundCents(p.Amount) {
+			mismatches = append(mismatches, newTransactionMismatch(MismatchAmount, order, t, &p,
+				fmt.Sprintf("transaction amount %.2f differs from the gateway amount %.2f", t.Amount, p.Amount)))
+		}
+	}
+
+	if autoFix {
+		fixed := make(map[string]bool)
+		for _, p := range toApply {
+			if appErr := u.reactions.applyPayment(ctx, app, order, provider.Name(), p, domain.TransactionSourceReconciliation); appErr != nil {
+				output.Errors = append(output.Errors, fmt.Sprintf("fixing payment %s of order %s: %v", p.ID, order.ID, appErr))
+				continue
+			}
+			fixed[p.ID] = true
+		}
+		for i := range mismatches {
+			if mismatches[i].Fixable && mismatches[i].GatewayPaymentID != nil && fixed[*mismatches[i].GatewayPaymentID] {
+				mismatches[i].Fixed = true
+				log.Printf("Reconciliation: fixed %s for payment %s of order %s", mismatches[i].Kind, *mismatches[i].GatewayPaymentID, order.ID)
+			}
+		}
+	}
+
+	// Order level checks run on what the transactions look like once the gateway is trusted
+	var approved *domain.Transaction
+	for _, t := range effective {
+		if t.Type == domain.TransactionTypePayment && t.Status == domain.TransactionStatusApproved {
+			approved = t
+			break
+		}
+	}
+
+	if status == domain.StatusCreated && approved != nil {
+		m := PaymentMismatchOutput{
+			Kind:             MismatchPaidNotConfirmed,
+			OrderID:          order.ID,
+			GatewayPaymentID: approved.GatewayPaymentID,
+			Detail:           fmt.Sprintf("payment %s is approved but the order was not confirmed", *approved.GatewayPaymentID),
+			Fixable:          true,
+		}
+		if autoFix {
+			if appErr := u.reactions.confirmOrder(ctx, app, order, approved); appErr != nil {
+				output.Errors = append(output.Errors, fmt.Sprintf("confirming order %s: %v", order.ID, appErr))
+			} else {
+				m.Fixed = true
+				log.Printf("Reconciliation: confirmed paid order %s", order.ID)
+			}
+		}
+		mismatches = append(mismatches, m)
+	}
+
+	if status == domain.StatusCancelled {
+		var unrefunded float64
+		for _, rp := range refundablePayments(effective) {
+			unrefunded += rp.remaining
+		}
+		if unrefunded = roundCents(unrefunded); unrefunded > 0 {
+			mismatches = append(mismatches, PaymentMismatchOutput{
+				Kind:        MismatchApprovedOnCancelled,
+				OrderID:     order.ID,
+				LocalAmount: &unrefunded,
+				Detail:      fmt.Sprintf("%.2f in approved payments was never refunded", unrefunded),
+			})
+		}
+	}
+
+	for i := range mismatches {
+		mismatches[i].OrderStatus = string(status)
+	}
+	output.Mismatches = append(output.Mismatches, mismatches...)
+	return nil
+}
+
+// newTransactionMismatch describes a mismatch about a single payment
+func newTransactionMismatch(kind string, order *domain.Order, t *domain.Transaction, p *payments.Payment, detail string) PaymentMismatchOutput {
+	m := PaymentMismatchOutput{
+		Kind:    kind,
+		OrderID: order.ID,
+		Detail:  detail,
+	}
+	if t != nil {
+		amount := t.Amount
+		m.TransactionID = &t.ID
+		m.GatewayPaymentID = t.GatewayPaymentID
+		m.LocalStatus = t.Status
+		m.LocalAmount = &amount
+	}
+	if p != nil {
+		id, amount := p.ID, p.Amount
+		m.GatewayPaymentID = &id
+		m.GatewayStatus = p.Status
+		m.GatewayAmount = &amount
+	}
+	return m
+}
+
+// sortedKeys returns the keys of a map in order, so reports list payments the same way every run
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
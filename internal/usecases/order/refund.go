@@ -0,0 +1,233 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// RefundInput represents the input for refunding an order
+type RefundInput struct {
+	OrderID string
+	UserID  string
+	// Amount to give back; nil refunds everything left of the captured payments
+	Amount *float64
+	Reason string
+}
+
+// RefundSummaryOutput represents how much of an order was captured and given back
+type RefundSummaryOutput struct {
+	Captured   float64 `json:"captured"`
+	Refunded   float64 `json:"refunded"`
+	Refundable float64 `json:"refundable"`
+}
+
+// RefundOutput represents the refunds issued for an order
+type RefundOutput struct {
+	Refunds []RefundOutputData  `json:"refunds"`
+	Summary RefundSummaryOutput `json:"summary"`
+}
+
+// RefundUsecase defines the interface for giving money back on an order
+type RefundUsecase interface {
+	Execute(ctx context.Context, input RefundInput) (*RefundOutput, apperrors.ApplicationError)
+}
+
+type refundUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewRefundUsecase creates a new instance of RefundUsecase
+func NewRefundUsecase(contextFactory appcontext.Factory) RefundUsecase {
+	return &refundUsecase{contextFactory: contextFactory}
+}
+
+// Execute refunds the amount from the order's approved payments, oldest first. The order
+// itself is not changed, so it works for item substitutions and complaints on orders in any status.
+func (u *refundUsecase) Execute(ctx context.Context, input RefundInput) (*RefundOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, apperrors.NewApplicationError(mappings.OrderRefundReasonRequiredError, errors.New("empty reason"))
+	}
+
+	var amount *float64
+	if input.Amount != nil {
+		rounded := roundCents(*input.Amount)
+		if rounded <= 0 {
+			return nil, apperrors.NewApplicationError(mappings.OrderRefundInvalidAmountError, fmt.Errorf("amount %.2f", *input.Amount))
+		}
+		amount = &rounded
+	}
+
+	// Held until the refunds are recorded so a concurrent refund or cancellation sees them
+	release, lockErr := app.Repositories.LockOrder(ctx, input.OrderID)
+	if lockErr != nil {
+		return nil, lockErr
+	}
+	defer release()
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := app.Repositories.Transaction.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	refundable := summarizeRefunds(transactions).Refundable
+	if refundable <= 0 {
+		return nil, apperrors.NewApplicationError(mappings.OrderRefundNothingToRefundError, errors.New("no approved payment left to refund"))
+	}
+	if amount != nil && *amount > refundable {
+		return nil, apperrors.NewApplicationError(mappings.OrderRefundExceedsCapturedError,
+			fmt.Errorf("requested %.2f, refundable %.2f", *amount, refundable))
+	}
+
+	description := fmt.Sprintf("Reembolso de pedido %s: %s", order.ID, reason)
+	refunds, refundErr := refundOrderPayments(ctx, app, order, amount, reason, description)
+	if refundErr != nil {
+		return nil, refundErr
+	}
+
+	var total float64
+	for _, r := range refunds {
+		total += r.Amount
+	}
+	log.Printf("Order %s: refunded %.2f by %s (%s)", order.ID, total, input.UserID, reason)
+
+	transactions, err = app.Repositories.Transaction.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RefundOutput{
+		Refunds: refunds,
+		Summary: summarizeRefunds(transactions),
+	}, nil
+}
+
+// RefundHistoryOutput represents a refund recorded on an order
+type RefundHistoryOutput struct {
+	ID                  string  `json:"id"`
+	ParentTransactionID *string `json:"parent_transaction_id,omitempty"`
+	GatewayRefundID     *string `json:"gateway_refund_id,omitempty"`
+	Amount              float64 `json:"amount"`
+	Currency            string  `json:"currency"`
+	Status              string  `json:"status"`
+	Description         *string `json:"description,omitempty"`
+	CreatedAt           string  `json:"created_at"`
+}
+
+// ListRefundsInput represents the input for listing the refunds of an order
+type ListRefundsInput struct {
+	OrderID string
+	UserID  string
+	// AsAdmin skips the ownership check
+	AsAdmin bool
+}
+
+// ListRefundsOutput represents the refund history of an order, oldest first
+type ListRefundsOutput struct {
+	Summary RefundSummaryOutput   `json:"summary"`
+	Refunds []RefundHistoryOutput `json:"refunds"`
+}
+
+// ListRefundsUsecase defines the interface for listing the refunds of an order
+type ListRefundsUsecase interface {
+	Execute(ctx context.Context, input ListRefundsInput) (*ListRefundsOutput, apperrors.ApplicationError)
+}
+
+type listRefundsUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewListRefundsUsecase creates a new instance of ListRefundsUsecase
+func NewListRefundsUsecase(contextFactory appcontext.Factory) ListRefundsUsecase {
+	return &listRefundsUsecase{contextFactory: contextFactory}
+}
+
+// Execute lists every refund of the order with the captured and refunded totals
+func (u *listRefundsUsecase) Execute(ctx context.Context, input ListRefundsInput) (*ListRefundsOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !input.AsAdmin && (order.UserID == nil || *order.UserID != input.UserID) {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+
+	transactions, err := app.Repositories.Transaction.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListRefundsOutput{
+		Summary: summarizeRefunds(transactions),
+		Refunds: []RefundHistoryOutput{},
+	}
+	for _, t := range transactions {
+		if t.Type != domain.TransactionTypeRefund {
+			continue
+		}
+		output.Refunds = append(output.Refunds, RefundHistoryOutput{
+			ID:                  t.ID,
+			ParentTransactionID: t.ParentTransactionID,
+			GatewayRefundID:     t.GatewayPaymentID,
+			Amount:              t.Amount,
+			Currency:            t.Currency,
+			Status:              t.Status,
+			Description:         t.Description,
+			CreatedAt:           t.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	return output, nil
+}
+
+// summarizeRefunds totals the captured payments of an order, what was given back and
+// what can still be refunded. Payments later refunded in full by the gateway still
+// count as captured; rejected refunds do not count as refunded.
+func summarizeRefunds(transactions []*domain.Transaction) RefundSummaryOutput {
+	var summary RefundSummaryOutput
+	for _, t := range transactions {
+		switch {
+		case t.Type == domain.TransactionTypePayment &&
+			(t.Status == domain.TransactionStatusApproved || t.Status == domain.TransactionStatusRefunded):
+			summary.Captured += t.Amount
+		case t.Type == domain.TransactionTypeRefund && t.Status != domain.TransactionStatusRejected:
+			summary.Refunded += t.Amount
+		}
+	}
+	for _, rp := range refundablePayments(transactions) {
+		summary.Refundable += rp.remaining
+	}
+
+	summary.Captured = roundCents(summary.Captured)
+	summary.Refunded = roundCents(summary.Refunded)
+	summary.Refundable = roundCents(summary.Refundable)
+	return summary
+}
@@ -0,0 +1,170 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
+	settingsUsecase "yego/internal/usecases/settings"
+
+	"github.com/google/uuid"
+)
+
+// ReorderInput represents the input for repeating a previous order
+type ReorderInput struct {
+	OrderID string
+	UserID  string
+}
+
+// ReorderItemChangeOutput describes an item whose name or price was refreshed from the catalogue
+type ReorderItemChangeOutput struct {
+	Code          string  `json:"code,omitempty"`
+	Name          string  `json:"name"`
+	PreviousName  string  `json:"previous_name"`
+	Price         float64 `json:"price"`
+	PreviousPrice float64 `json:"previous_price"`
+	Quantity      int     `json:"quantity"`
+}
+
+// ReorderOutput represents the new order and how its items differ from the original
+type ReorderOutput struct {
+	Data          OrderOutputData           `json:"data"`
+	SourceOrderID string                    `json:"source_order_id"`
+	ChangedItems  []ReorderItemChangeOutput `json:"changed_items"`
+	// UnmatchedItems are kept at their previous price because they are no longer in the catalogue
+	UnmatchedItems []OrderItemOutput `json:"unmatched_items"`
+}
+
+// ReorderUsecase defines the interface for creating a new order from a previous one
+type ReorderUsecase interface {
+	Execute(ctx context.Context, input ReorderInput) (*ReorderOutput, apperrors.ApplicationError)
+}
+
+type reorderUsecase struct {
+	contextFactory          appcontext.Factory
+	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
+	estimateDeliveryTimeUse settingsUsecase.EstimateDeliveryTimeUsecase
+	notificationSvc         notification.Service
+}
+
+// NewReorderUsecase creates a new instance of ReorderUsecase
+func NewReorderUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase, estimateDeliveryTimeUse settingsUsecase.EstimateDeliveryTimeUsecase, notificationSvc notification.Service) ReorderUsecase {
+	return &reorderUsecase{
+		contextFactory:          contextFactory,
+		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
+		estimateDeliveryTimeUse: estimateDeliveryTimeUse,
+		notificationSvc:         notificationSvc,
+	}
+}
+
+// Execute copies the items of one of the user's orders into a new CREATED order,
+// refreshing names and prices from the current import records
+func (u *reorderUsecase) Execute(ctx context.Context, input ReorderInput) (*ReorderOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	source, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.UserID == nil || *source.UserID != input.UserID {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+
+	if source.Data == nil || len(source.Data.Items) == 0 {
+		return nil, apperrors.NewApplicationError(mappings.OrderReorderEmptyError, nil)
+	}
+
+	items := make([]domain.OrderItem, len(source.Data.Items))
+	copy(items, source.Data.Items)
+	for i := range items {
+		items[i].ResetFulfilment()
+	}
+
+	importRecords, err := app.Repositories.ImportRecord.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	corrected, checks := checkItemPrices(items, importRecords)
+
+	output := &ReorderOutput{
+		SourceOrderID:  source.ID,
+		ChangedItems:   []ReorderItemChangeOutput{},
+		UnmatchedItems: []OrderItemOutput{},
+	}
+	for i, check := range checks {
+		item := corrected[i]
+		if !check.Matched {
+			output.UnmatchedItems = append(output.UnmatchedItems, OrderItemOutput{
+				Name:     item.Name,
+				Price:    item.Price,
+				Quantity: item.Quantity,
+				Weight:   item.Weight,
+			})
+			continue
+		}
+		if item.Name != check.PreviousName || item.Price != check.PreviousPrice {
+			output.ChangedItems = append(output.ChangedItems, ReorderItemChangeOutput{
+				Code:          item.Code,
+				Name:          item.Name,
+				PreviousName:  check.PreviousName,
+				Price:         item.Price,
+				PreviousPrice: check.PreviousPrice,
+				Quantity:      item.Quantity,
+			})
+		}
+	}
+
+	userID := input.UserID
+	newOrder := &domain.Order{
+		ProfileID:       source.ProfileID,
+		UserID:          &userID,
+		Status:          domain.StatusCreated,
+		Data:            &domain.OrderData{Items: corrected},
+		PaymentProvider: source.PaymentProvider,
+		PaymentMethod:   source.PaymentMethod,
+	}
+	applyDeliveryEstimate(ctx, app, newOrder, u.estimateDeliveryTimeUse)
+	if priceErr := PriceOrder(ctx, app, newOrder, u.calculateDeliveryFeeUse); priceErr != nil {
+		// Left unpriced, the first payment prices it
+		log.Printf("Warning: failed to price reorder of %s: %v", source.ID, priceErr)
+		newOrder.PriceBreakdown = nil
+	}
+
+	created, err := app.Repositories.Order.Create(ctx, newOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.notificationSvc != nil {
+		profileID := ""
+		if created.ProfileID != nil {
+			profileID = *created.ProfileID
+		}
+		payload := notification.OrderCreatedPayload{
+			OrderID:   created.ID,
+			ProfileID: profileID,
+			Status:    string(created.Status),
+			ETA:       created.ETA,
+			CreatedAt: time.Now().Format("2006-01-02T15:04:05Z"),
+		}
+		go func() {
+			if notifyErr := u.notificationSvc.NotifyOrderCreated(payload); notifyErr != nil {
+				log.Printf("Warning: failed to notify order created %s: %v", created.ID, notifyErr)
+			}
+		}()
+	}
+
+	output.Data = toOrderOutputData(created, false)
+	return output, nil
+}
@@ -0,0 +1,156 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
+
+	"github.com/google/uuid"
+)
+
+// RequestModificationInput represents a customer's proposed change to their order
+type RequestModificationInput struct {
+	OrderID string
+	UserID  string
+	Changes []domain.OrderItemChange
+	Note    string
+}
+
+// RequestModificationOutput represents the stored modification request
+type RequestModificationOutput struct {
+	Data ModificationRequestOutput `json:"data"`
+}
+
+// RequestModificationUsecase defines the interface for proposing a change to an order
+type RequestModificationUsecase interface {
+	Execute(ctx context.Context, input RequestModificationInput) (*RequestModificationOutput, apperrors.ApplicationError)
+}
+
+type requestModificationUsecase struct {
+	contextFactory  appcontext.Factory
+	notificationSvc notification.Service
+}
+
+// NewRequestModificationUsecase creates a new instance of RequestModificationUsecase
+func NewRequestModificationUsecase(contextFactory appcontext.Factory, notificationSvc notification.Service) RequestModificationUsecase {
+	return &requestModificationUsecase{
+		contextFactory:  contextFactory,
+		notificationSvc: notificationSvc,
+	}
+}
+
+// Execute stores the proposed items as a pending revision and moves the order to
+// MODIFICATION_REQUESTED until a manager reviews it
+func (u *requestModificationUsecase) Execute(ctx context.Context, input RequestModificationInput) (*RequestModificationOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	if len(input.Changes) == 0 {
+		return nil, apperrors.NewApplicationError(mappings.OrderModificationInvalidError, errors.New("at least one change is required"))
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.UserID == nil || *order.UserID != input.UserID {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+
+	if order.Status == domain.StatusModificationRequested {
+		return nil, apperrors.NewApplicationError(mappings.OrderModificationPendingError, nil)
+	}
+	if transitionErr := order.ValidateTransition(domain.StatusModificationRequested); transitionErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidTransitionError, transitionErr)
+	}
+
+	proposed, applyErr := domain.ApplyItemChanges(order.Data, input.Changes)
+	if applyErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderModificationInvalidError, applyErr)
+	}
+
+	request := &domain.OrderModificationRequest{
+		OrderID:           order.ID,
+		RequestedByUserID: input.UserID,
+		PreviousStatus:    order.Status,
+		Changes:           input.Changes,
+		ProposedData:      proposed,
+	}
+	if input.Note != "" {
+		note := input.Note
+		request.Note = &note
+	}
+
+	// Moving the order first makes the version check reject concurrent requests
+	previousStatus := order.Status
+	order.Status = domain.StatusModificationRequested
+	updated, err := app.Repositories.Order.Update(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	// A pending request left behind after the order was moved out of
+	// MODIFICATION_REQUESTED by hand is closed so it cannot block the new one.
+	// This runs after the version check so a conflict leaves it untouched.
+	if existing, listErr := app.Repositories.OrderModification.ListByOrderID(ctx, order.ID); listErr == nil {
+		for _, stale := range existing {
+			if stale.Status != domain.ModificationRequestPending {
+				continue
+			}
+			note := "superseded by a new request"
+			stale.Status = domain.ModificationRequestRejected
+			stale.ReviewNote = &note
+			if _, reviewErr := app.Repositories.OrderModification.Review(ctx, stale); reviewErr != nil {
+				log.Printf("Warning: failed to close stale modification request %s: %v", stale.ID, reviewErr)
+			}
+		}
+	}
+
+	created, err := app.Repositories.OrderModification.Create(ctx, request)
+	if err != nil {
+		updated.Status = previousStatus
+		if _, restoreErr := app.Repositories.Order.Update(ctx, updated); restoreErr != nil {
+			log.Printf("Warning: failed to restore status of order %s after modification request error: %v", order.ID, restoreErr)
+		}
+		return nil, err
+	}
+
+	actorUserID := input.UserID
+	recordStatusChange(ctx, app, order.ID, previousStatus, domain.StatusModificationRequested, &actorUserID, request.Note)
+
+	if u.notificationSvc != nil {
+		payload := notification.OrderModificationRequestedPayload{
+			RequestID:   created.ID,
+			OrderID:     created.OrderID,
+			UserID:      input.UserID,
+			ChangeCount: len(created.Changes),
+			Note:        input.Note,
+			RequestedAt: created.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+		statusPayload := notification.OrderUpdatedPayload{
+			OrderID: updated.ID,
+			Status:  string(updated.Status),
+			ETA:     updated.ETA,
+		}
+		go func() {
+			if notifyErr := u.notificationSvc.NotifyOrderModificationRequested(payload); notifyErr != nil {
+				log.Printf("Warning: failed to notify modification request %s: %v", payload.RequestID, notifyErr)
+			}
+			if notifyErr := u.notificationSvc.NotifyOrderUpdated(statusPayload); notifyErr != nil {
+				log.Printf("Warning: failed to notify order updated %s: %v", statusPayload.OrderID, notifyErr)
+			}
+		}()
+	}
+
+	return &RequestModificationOutput{Data: toModificationRequestOutput(created)}, nil
+}
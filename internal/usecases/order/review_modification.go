@@ -0,0 +1,169 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/usecases/notification"
+	settingsUsecase "yego/internal/usecases/settings"
+
+	"github.com/google/uuid"
+)
+
+// ReviewModificationInput represents a manager's decision on a modification request
+type ReviewModificationInput struct {
+	RequestID  string
+	UserID     string
+	Approve    bool
+	ReviewNote string
+}
+
+// ReviewModificationOutput represents the reviewed request and the resulting order
+type ReviewModificationOutput struct {
+	Request ModificationRequestOutput `json:"request"`
+	Order   OrderOutputData           `json:"order"`
+	// Total is the recalculated order amount (items + delivery fee) after an approval
+	Total *float64 `json:"total,omitempty"`
+}
+
+// ReviewModificationUsecase defines the interface for approving or rejecting a modification request
+type ReviewModificationUsecase interface {
+	Execute(ctx context.Context, input ReviewModificationInput) (*ReviewModificationOutput, apperrors.ApplicationError)
+}
+
+type reviewModificationUsecase struct {
+	contextFactory          appcontext.Factory
+	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
+	notificationSvc         notification.Service
+}
+
+// NewReviewModificationUsecase creates a new instance of ReviewModificationUsecase
+func NewReviewModificationUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase, notificationSvc notification.Service) ReviewModificationUsecase {
+	return &reviewModificationUsecase{
+		contextFactory:          contextFactory,
+		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
+		notificationSvc:         notificationSvc,
+	}
+}
+
+// Execute applies (approval) or discards (rejection) the proposed items and
+// returns the order to the status it had before the request
+func (u *reviewModificationUsecase) Execute(ctx context.Context, input ReviewModificationInput) (*ReviewModificationOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.RequestID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderModificationNotFoundError, err)
+	}
+
+	request, err := app.Repositories.OrderModification.GetByID(ctx, input.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != domain.ModificationRequestPending {
+		return nil, apperrors.NewApplicationError(mappings.OrderModificationNotPendingError, fmt.Errorf("request is %s", request.Status))
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, request.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.StatusModificationRequested {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidTransitionError, fmt.Errorf("order is %s, not %s", order.Status, domain.StatusModificationRequested))
+	}
+	if transitionErr := order.ValidateTransition(request.PreviousStatus); transitionErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidTransitionError, transitionErr)
+	}
+
+	if input.Approve {
+		if request.ProposedData == nil || len(request.ProposedData.Items) == 0 {
+			return nil, apperrors.NewApplicationError(mappings.OrderModificationInvalidError, errors.New("request has no proposed items"))
+		}
+		var current []domain.OrderItem
+		if order.Data != nil {
+			current = order.Data.Items
+		}
+
+		// Added items carry the price the customer sent, so every one of them
+		// must be priced from the import records before the request is approved
+		importRecords, importErr := app.Repositories.ImportRecord.GetAll(ctx)
+		if importErr != nil {
+			return nil, importErr
+		}
+		corrected, checks := checkItemPrices(request.ProposedData.Items, importRecords)
+		var unpriced []string
+		for i, check := range checks {
+			if !check.Matched && !domain.ContainsOrderItem(current, request.ProposedData.Items[i]) {
+				unpriced = append(unpriced, check.PreviousName)
+			}
+		}
+		if len(unpriced) > 0 {
+			return nil, apperrors.NewApplicationError(mappings.OrderModificationUnpricedItemError, fmt.Errorf("no import record for %s", strings.Join(unpriced, ", ")))
+		}
+		order.Data = &domain.OrderData{Items: corrected}
+
+		// Saving the approved items over the old price would charge the stale total
+		if priceErr := PriceOrder(ctx, app, order, u.calculateDeliveryFeeUse); priceErr != nil {
+			return nil, apperrors.NewApplicationError(mappings.OrderPricingError, priceErr)
+		}
+	}
+
+	order.Status = request.PreviousStatus
+	updated, err := app.Repositories.Order.Update(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	var actorUserID *string
+	if input.UserID != "" {
+		actorUserID = &input.UserID
+		request.ReviewedByUserID = actorUserID
+	}
+	if input.ReviewNote != "" {
+		note := input.ReviewNote
+		request.ReviewNote = &note
+	}
+	request.Status = domain.ModificationRequestRejected
+	if input.Approve {
+		request.Status = domain.ModificationRequestApproved
+	}
+
+	reviewed, err := app.Repositories.OrderModification.Review(ctx, request)
+	if err != nil {
+		log.Printf("Warning: order %s was updated but modification request %s could not be marked %s: %v", updated.ID, request.ID, request.Status, err)
+		reviewed = request
+	}
+
+	recordStatusChange(ctx, app, updated.ID, domain.StatusModificationRequested, updated.Status, actorUserID, request.ReviewNote)
+
+	output := &ReviewModificationOutput{
+		Request: toModificationRequestOutput(reviewed),
+		Order:   toOrderOutputData(updated, false),
+	}
+
+	if input.Approve && updated.PriceBreakdown != nil {
+		total := updated.PriceBreakdown.Total
+		output.Total = &total
+	}
+
+	if u.notificationSvc != nil {
+		payload := notification.OrderUpdatedPayload{
+			OrderID: updated.ID,
+			Status:  string(updated.Status),
+			ETA:     updated.ETA,
+		}
+		go func() {
+			if notifyErr := u.notificationSvc.NotifyOrderUpdated(payload); notifyErr != nil {
+				log.Printf("Warning: failed to notify order updated %s: %v", updated.ID, notifyErr)
+			}
+		}()
+	}
+
+	return output, nil
+}
@@ -0,0 +1,219 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/usecases/notification"
+	settingsUsecase "yego/internal/usecases/settings"
+)
+
+// subscriptionBatchSize bounds how many due subscriptions a single run processes
+const subscriptionBatchSize = 50
+
+// RunDueSubscriptionsOutput summarises a scheduler run
+type RunDueSubscriptionsOutput struct {
+	Processed int `json:"processed"`
+	Created   int `json:"created"`
+	Charged   int `json:"charged"`
+	Failed    int `json:"failed"`
+}
+
+// RunDueSubscriptionsUsecase defines the interface for placing the orders of due subscriptions
+type RunDueSubscriptionsUsecase interface {
+	Execute(ctx context.Context, now time.Time) (*RunDueSubscriptionsOutput, apperrors.ApplicationError)
+}
+
+type runDueSubscriptionsUsecase struct {
+	contextFactory          appcontext.Factory
+	calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase
+	estimateDeliveryTimeUse settingsUsecase.EstimateDeliveryTimeUsecase
+	notificationSvc         notification.Service
+}
+
+// NewRunDueSubscriptionsUsecase creates a new instance of RunDueSubscriptionsUsecase
+func NewRunDueSubscriptionsUsecase(contextFactory appcontext.Factory, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase, estimateDeliveryTimeUse settingsUsecase.EstimateDeliveryTimeUsecase, notificationSvc notification.Service) RunDueSubscriptionsUsecase {
+	return &runDueSubscriptionsUsecase{
+		contextFactory:          contextFactory,
+		calculateDeliveryFeeUse: calculateDeliveryFeeUse,
+		estimateDeliveryTimeUse: estimateDeliveryTimeUse,
+		notificationSvc:         notificationSvc,
+	}
+}
+
+// Execute places a CREATED order for every active subscription that is due, re-priced
+// against the import records, and charges it when the subscription has auto charge on.
+// A run whose items are not all in the import records fails without placing an order.
+// Each run is claimed before the order is placed, so concurrent schedulers skip it.
+func (u *runDueSubscriptionsUsecase) Execute(ctx context.Context, now time.Time) (*RunDueSubscriptionsOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	due, err := app.Repositories.Subscription.ListDue(ctx, now, subscriptionBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &RunDueSubscriptionsOutput{}
+	if len(due) == 0 {
+		return output, nil
+	}
+
+	// Without the import records nothing can be priced; the subscriptions stay due for the next run
+	importRecords, err := app.Repositories.ImportRecord.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sub := range due {
+		scheduledFor := sub.NextRunAt
+		claimed, claimErr := app.Repositories.Subscription.ClaimRun(ctx, sub.ID, scheduledFor, sub.NextRunAfter(now))
+		if claimErr != nil {
+			log.Printf("Warning: failed to claim run of subscription %s: %v", sub.ID, claimErr)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		output.Processed++
+
+		run := u.placeSubscriptionOrder(ctx, app, sub, importRecords)
+		run.SubscriptionID = sub.ID
+		run.ScheduledFor = scheduledFor
+		if _, runErr := app.Repositories.Subscription.CreateRun(ctx, run); runErr != nil {
+			log.Printf("Warning: failed to record run of subscription %s: %v", sub.ID, runErr)
+		}
+
+		switch run.Status {
+		case domain.SubscriptionRunOrderCreated:
+			output.Created++
+		case domain.SubscriptionRunCharged:
+			output.Created++
+			output.Charged++
+		case domain.SubscriptionRunChargeFailed:
+			output.Created++
+			output.Failed++
+		default:
+			output.Failed++
+		}
+
+		if run.Error != nil && u.notificationSvc != nil {
+			payload := notification.SubscriptionRunFailedPayload{
+				SubscriptionID: sub.ID,
+				UserID:         sub.UserID,
+				Status:         string(run.Status),
+				FailedAt:       time.Now().Format("2006-01-02T15:04:05Z"),
+			}
+			if run.OrderID != nil {
+				payload.OrderID = *run.OrderID
+			}
+			go func() {
+				if notifyErr := u.notificationSvc.NotifySubscriptionRunFailed(payload); notifyErr != nil {
+					log.Printf("Warning: failed to notify subscription run failure %s: %v", payload.SubscriptionID, notifyErr)
+				}
+			}()
+		}
+	}
+
+	return output, nil
+}
+
+// placeSubscriptionOrder creates (and optionally charges) the order of one subscription run
+func (u *runDueSubscriptionsUsecase) placeSubscriptionOrder(ctx context.Context, app *appcontext.Context, sub *domain.Subscription, importRecords []*domain.ImportRecord) *domain.SubscriptionRun {
+	run := &domain.SubscriptionRun{}
+	fail := func(status domain.SubscriptionRunStatus, err error) *domain.SubscriptionRun {
+		message := err.Error()
+		run.Status = status
+		run.Error = &message
+		log.Printf("Subscription %s run failed (%s): %v", sub.ID, status, err)
+		return run
+	}
+
+	items := make([]domain.OrderItem, len(sub.Items))
+	copy(items, sub.Items)
+	for i := range items {
+		items[i].ResetFulfilment()
+	}
+	// Template prices are never charged: every item must be priced from the import records
+	items, checks := checkItemPrices(items, importRecords)
+	var unpriced []string
+	for i, check := range checks {
+		if !check.Matched {
+			unpriced = append(unpriced, items[i].Name)
+		}
+	}
+	if len(unpriced) > 0 {
+		return fail(domain.SubscriptionRunFailed, fmt.Errorf("items not in the price list: %s", strings.Join(unpriced, ", ")))
+	}
+
+	profileID := sub.ProfileID
+	if profileID == nil {
+		if profile, _ := app.Repositories.Profile.GetByUserID(ctx, sub.UserID); profile != nil {
+			profileID = &profile.ID
+		}
+	}
+
+	userID := sub.UserID
+	newOrder := &domain.Order{
+		ProfileID: profileID,
+		UserID:    &userID,
+		Status:    domain.StatusCreated,
+		Data:      &domain.OrderData{Items: items},
+	}
+	applyDeliveryEstimate(ctx, app, newOrder, u.estimateDeliveryTimeUse)
+	if priceErr := PriceOrder(ctx, app, newOrder, u.calculateDeliveryFeeUse); priceErr != nil {
+		// Left unpriced, the charge below or the first payment prices it
+		log.Printf("Warning: failed to price subscription %s order: %v", sub.ID, priceErr)
+		newOrder.PriceBreakdown = nil
+	}
+
+	created, err := app.Repositories.Order.Create(ctx, newOrder)
+	if err != nil {
+		return fail(domain.SubscriptionRunFailed, err)
+	}
+	run.OrderID = &created.ID
+	run.Status = domain.SubscriptionRunOrderCreated
+
+	if setErr := app.Repositories.Subscription.SetLastOrder(ctx, sub.ID, created.ID); setErr != nil {
+		log.Printf("Warning: failed to link order %s to subscription %s: %v", created.ID, sub.ID, setErr)
+	}
+
+	if sub.AutoCharge {
+		if paymentErr := ProcessPaymentForOrder(ctx, app, created, "", "", u.calculateDeliveryFeeUse); paymentErr != nil {
+			fail(domain.SubscriptionRunChargeFailed, fmt.Errorf("payment failed: %w", paymentErr))
+		} else {
+			run.Status = domain.SubscriptionRunCharged
+			created.Status = domain.StatusConfirmed
+			if confirmed, updateErr := app.Repositories.Order.Update(ctx, created); updateErr == nil {
+				recordStatusChange(ctx, app, confirmed.ID, domain.StatusCreated, domain.StatusConfirmed, nil, nil)
+				created = confirmed
+			} else {
+				log.Printf("Warning: failed to confirm paid subscription order %s: %v", created.ID, updateErr)
+			}
+		}
+	}
+
+	if u.notificationSvc != nil {
+		payload := notification.OrderCreatedPayload{
+			OrderID:   created.ID,
+			Status:    string(created.Status),
+			ETA:       created.ETA,
+			CreatedAt: time.Now().Format("2006-01-02T15:04:05Z"),
+		}
+		if created.ProfileID != nil {
+			payload.ProfileID = *created.ProfileID
+		}
+		go func() {
+			if notifyErr := u.notificationSvc.NotifyOrderCreated(payload); notifyErr != nil {
+				log.Printf("Warning: failed to notify order created %s: %v", payload.OrderID, notifyErr)
+			}
+		}()
+	}
+
+	return run
+}
@@ -0,0 +1,27 @@
+package order
+
+import (
+	"context"
+	"log"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+)
+
+// recordStatusChange stores a status change in the order history.
+// Failures are logged and never block the status change itself.
+func recordStatusChange(ctx context.Context, app *appcontext.Context, orderID string, from, to domain.OrderStatus, actorUserID *string, statusMessage *string) {
+	if from == to {
+		return
+	}
+	event := &domain.OrderStatusEvent{
+		OrderID:       orderID,
+		FromStatus:    &from,
+		ToStatus:      to,
+		ActorUserID:   actorUserID,
+		StatusMessage: statusMessage,
+	}
+	if _, err := app.Repositories.OrderStatusEvent.Create(ctx, event); err != nil {
+		log.Printf("Warning: failed to record status change for order %s (%s -> %s): %v", orderID, from, to, err)
+	}
+}
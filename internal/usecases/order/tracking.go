@@ -0,0 +1,157 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+	"yego/internal/services/tracking"
+
+	"github.com/google/uuid"
+)
+
+// coarsePositionDecimals rounds coordinates to about a kilometre, enough to show
+// the delivery area on a map without revealing the address
+const coarsePositionDecimals = 2
+
+// CreateTrackingLinkInput represents the input for creating a tracking link
+type CreateTrackingLinkInput struct {
+	OrderID string
+	UserID  string
+	// AsAdmin skips the ownership check
+	AsAdmin bool
+}
+
+// CreateTrackingLinkOutput is a shareable, expiring link to the public tracking page
+type CreateTrackingLinkOutput struct {
+	Token       string `json:"token"`
+	TrackingURL string `json:"tracking_url"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+// CreateTrackingLinkUsecase defines the interface for creating a public tracking link for an order
+type CreateTrackingLinkUsecase interface {
+	Execute(ctx context.Context, input CreateTrackingLinkInput, baseURL string) (*CreateTrackingLinkOutput, apperrors.ApplicationError)
+}
+
+type createTrackingLinkUsecase struct {
+	contextFactory appcontext.Factory
+	signer         *tracking.Signer
+}
+
+// NewCreateTrackingLinkUsecase creates a new instance of CreateTrackingLinkUsecase
+func NewCreateTrackingLinkUsecase(contextFactory appcontext.Factory, signer *tracking.Signer) CreateTrackingLinkUsecase {
+	return &createTrackingLinkUsecase{
+		contextFactory: contextFactory,
+		signer:         signer,
+	}
+}
+
+// Execute signs a tracking token for an order the user owns
+func (u *createTrackingLinkUsecase) Execute(ctx context.Context, input CreateTrackingLinkInput, baseURL string) (*CreateTrackingLinkOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, err := uuid.Parse(input.OrderID); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidIDError, err)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !input.AsAdmin && (order.UserID == nil || *order.UserID != input.UserID) {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("order does not belong to this user"))
+	}
+
+	token, expiresAt := u.signer.Sign(order.ID, time.Now())
+
+	return &CreateTrackingLinkOutput{
+		Token:       token,
+		TrackingURL: baseURL + "/track/" + token,
+		ExpiresAt:   expiresAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}, nil
+}
+
+// CoarsePosition is a delivery location rounded to about a kilometre
+type CoarsePosition struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// TrackOrderOutput is the public view of an order: nothing that identifies the customer
+type TrackOrderOutput struct {
+	Status           string          `json:"status"`
+	StatusIndex      int             `json:"status_index"`
+	ETA              string          `json:"eta"`
+	BusinessName     string          `json:"business_name"`
+	DeliveryPosition *CoarsePosition `json:"delivery_position,omitempty"`
+}
+
+// TrackOrderUsecase defines the interface for tracking an order with a tracking token
+type TrackOrderUsecase interface {
+	Execute(ctx context.Context, token string) (*TrackOrderOutput, apperrors.ApplicationError)
+}
+
+type trackOrderUsecase struct {
+	contextFactory appcontext.Factory
+	signer         *tracking.Signer
+}
+
+// NewTrackOrderUsecase creates a new instance of TrackOrderUsecase
+func NewTrackOrderUsecase(contextFactory appcontext.Factory, signer *tracking.Signer) TrackOrderUsecase {
+	return &trackOrderUsecase{
+		contextFactory: contextFactory,
+		signer:         signer,
+	}
+}
+
+// Execute verifies the token and returns the limited tracking view of its order
+func (u *trackOrderUsecase) Execute(ctx context.Context, token string) (*TrackOrderOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	orderID, verifyErr := u.signer.Verify(token, time.Now())
+	if verifyErr != nil {
+		if errors.Is(verifyErr, tracking.ErrExpiredToken) {
+			return nil, apperrors.NewApplicationError(mappings.OrderTrackingTokenExpiredError, verifyErr)
+		}
+		return nil, apperrors.NewApplicationError(mappings.OrderTrackingTokenInvalidError, verifyErr)
+	}
+
+	order, err := app.Repositories.Order.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &TrackOrderOutput{
+		Status:      string(order.Status),
+		StatusIndex: order.StatusIndex(),
+		ETA:         order.ETA,
+	}
+
+	if settings, _ := app.Repositories.Settings.Get(ctx); settings != nil {
+		output.BusinessName = settings.BusinessName
+	}
+
+	if order.ProfileID != nil {
+		if profile, profileErr := app.Repositories.Profile.GetByID(ctx, *order.ProfileID); profileErr == nil && profile.LocationID != nil {
+			if location, locationErr := app.Repositories.Profile.GetLocationByID(ctx, *profile.LocationID); locationErr == nil {
+				output.DeliveryPosition = &CoarsePosition{
+					Latitude:  roundCoordinate(location.Latitude),
+					Longitude: roundCoordinate(location.Longitude),
+				}
+			}
+		}
+	}
+
+	return output, nil
+}
+
+func roundCoordinate(value float64) float64 {
+	factor := math.Pow(10, coarsePositionDecimals)
+	return math.Round(value*factor) / factor
+}
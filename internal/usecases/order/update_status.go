@@ -2,6 +2,8 @@ package order
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 
 	"yego/internal/domain"
@@ -18,9 +20,13 @@ import (
 type UpdateStatusInput struct {
 	Status string `json:"status" binding:"required"`
 	Token  string
+	UserID string
+	// ExpectedVersion is the order version the client last saw (If-Match); nil accepts any version
+	ExpectedVersion *int
 }
 
-// UpdateStatusOutput represents the output after updating order status
+// UpdateStatusOutput represents the output after updating order status.
+// On a version conflict it carries the current state of the order.
 type UpdateStatusOutput struct {
 	Data OrderOutputData `json:"data"`
 }
@@ -57,11 +63,45 @@ func (u *updateStatusUsecase) Execute(ctx context.Context, id string, input Upda
 		return nil, apperrors.NewApplicationError(mappings.OrderInvalidStatusError, nil)
 	}
 
-	updated, err := app.Repositories.Order.UpdateStatus(ctx, id, domain.OrderStatus(input.Status))
+	current, err := app.Repositories.Order.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != current.Version {
+		return &UpdateStatusOutput{Data: toOrderOutputData(current, false)},
+			apperrors.NewApplicationError(mappings.OrderVersionConflictError, fmt.Errorf("expected version %d, current version %d", *input.ExpectedVersion, current.Version))
+	}
+
+	newStatus := domain.OrderStatus(input.Status)
+	if newStatus == domain.StatusCancelled && current.Status != domain.StatusCancelled {
+		return nil, apperrors.NewApplicationError(mappings.OrderCancelRequiresEndpointError, nil)
+	}
+	if transitionErr := current.ValidateTransition(newStatus); transitionErr != nil {
+		if errors.Is(transitionErr, domain.ErrPaymentNotReceived) {
+			return nil, apperrors.NewApplicationError(mappings.OrderPaymentNotReceivedError, transitionErr)
+		}
+		return nil, apperrors.NewApplicationError(mappings.OrderInvalidTransitionError, transitionErr)
+	}
+
+	previousStatus := current.Status
+	current.Status = newStatus
+	updated, err := app.Repositories.Order.Update(ctx, current)
+	if err != nil {
+		if err.Code() == mappings.OrderVersionConflictError.Code {
+			if latest, getErr := app.Repositories.Order.GetByID(ctx, id); getErr == nil {
+				return &UpdateStatusOutput{Data: toOrderOutputData(latest, false)}, err
+			}
+		}
+		return nil, err
+	}
+
+	var actorUserID *string
+	if input.UserID != "" {
+		actorUserID = &input.UserID
+	}
+	recordStatusChange(ctx, app, id, previousStatus, updated.Status, actorUserID, nil)
+
 	// Notify managers of the status change
 	if u.notificationSvc != nil {
 		payload := notification.OrderUpdatedPayload{
@@ -17,11 +17,11 @@ type Usecases struct {
 }
 
 // NewUsecases creates all order use cases
-func NewUsecases(contextFactory appcontext.Factory, notificationSvc notification.Service, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase) *Usecases {
+func NewUsecases(contextFactory appcontext.Factory, notificationSvc notification.Service, calculateDeliveryFeeUse settingsUsecase.CalculateDeliveryFeeUsecase, estimateDeliveryTimeUse settingsUsecase.EstimateDeliveryTimeUsecase) *Usecases {
 	return &Usecases{
-		Create:         NewCreateUsecase(contextFactory, calculateDeliveryFeeUse, notificationSvc),
+		Create:         NewCreateUsecase(contextFactory, calculateDeliveryFeeUse, estimateDeliveryTimeUse, notificationSvc),
 		CreateWithLink: NewCreateWithLinkUsecase(contextFactory),
-		Claim:          NewClaimUsecase(contextFactory, notificationSvc, calculateDeliveryFeeUse),
+		Claim:          NewClaimUsecase(contextFactory, notificationSvc, calculateDeliveryFeeUse, estimateDeliveryTimeUse),
 		Get:            NewGetUsecase(contextFactory),
 		UpdateStatus:   NewUpdateStatusUsecase(contextFactory, calculateDeliveryFeeUse, notificationSvc),
 		ListMyOrders:   NewListMyOrdersUsecase(contextFactory),
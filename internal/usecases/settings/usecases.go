@@ -2,11 +2,15 @@ package settings
 
 import (
 	"context"
+	"errors"
 	"math"
+	"time"
 
+	"github.com/google/uuid"
 	"yego/internal/domain"
 	"yego/internal/platform/appcontext"
 	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
 )
 
 // Usecases contains all settings-related use cases
@@ -14,6 +18,12 @@ type Usecases struct {
 	Get                  GetUsecase
 	Update               UpdateUsecase
 	CalculateDeliveryFee CalculateDeliveryFeeUsecase
+	EstimateDeliveryTime EstimateDeliveryTimeUsecase
+	ListDeliverySlots    ListDeliverySlotsUsecase
+	CreateDeliverySlot   CreateDeliverySlotUsecase
+	UpdateDeliverySlot   UpdateDeliverySlotUsecase
+	DeleteDeliverySlot   DeleteDeliverySlotUsecase
+	GetSlotAvailability  GetSlotAvailabilityUsecase
 }
 
 // NewUsecases creates all settings usecases
@@ -22,6 +32,12 @@ func NewUsecases(contextFactory appcontext.Factory) *Usecases {
 		Get:                  NewGetUsecase(contextFactory),
 		Update:               NewUpdateUsecase(contextFactory),
 		CalculateDeliveryFee: NewCalculateDeliveryFeeUsecase(contextFactory),
+		EstimateDeliveryTime: NewEstimateDeliveryTimeUsecase(contextFactory),
+		ListDeliverySlots:    NewListDeliverySlotsUsecase(contextFactory),
+		CreateDeliverySlot:   NewCreateDeliverySlotUsecase(contextFactory),
+		UpdateDeliverySlot:   NewUpdateDeliverySlotUsecase(contextFactory),
+		DeleteDeliverySlot:   NewDeleteDeliverySlotUsecase(contextFactory),
+		GetSlotAvailability:  NewGetSlotAvailabilityUsecase(contextFactory),
 	}
 }
 
@@ -67,6 +83,11 @@ type UpdateInput struct {
 	DeliveryPricePerKm  *float64 `json:"delivery_price_per_km,omitempty"`
 	DeliveryPricePerKg  *float64 `json:"delivery_price_per_kg,omitempty"`
 	ManagerCollectorID  *string  `json:"manager_collector_id,omitempty"`
+
+	PreparationTimeMinutes *int     `json:"preparation_time_minutes,omitempty"`
+	QueueMinutesPerOrder   *int     `json:"queue_minutes_per_order,omitempty"`
+	DeliveryMinutesPerKm   *float64 `json:"delivery_minutes_per_km,omitempty"`
+	ETAWindowMinutes       *int     `json:"eta_window_minutes,omitempty"`
 }
 
 type UpdateOutput struct {
@@ -128,6 +149,18 @@ func (u *updateUsecase) Execute(ctx context.Context, input UpdateInput) (*Update
 	if input.ManagerCollectorID != nil {
 		current.ManagerCollectorID = input.ManagerCollectorID
 	}
+	if input.PreparationTimeMinutes != nil {
+		current.PreparationTimeMinutes = *input.PreparationTimeMinutes
+	}
+	if input.QueueMinutesPerOrder != nil {
+		current.QueueMinutesPerOrder = *input.QueueMinutesPerOrder
+	}
+	if input.DeliveryMinutesPerKm != nil {
+		current.DeliveryMinutesPerKm = *input.DeliveryMinutesPerKm
+	}
+	if input.ETAWindowMinutes != nil {
+		current.ETAWindowMinutes = *input.ETAWindowMinutes
+	}
 
 	// Save
 	updated, err := app.Repositories.Settings.Upsert(ctx, current)
@@ -213,6 +246,82 @@ func (u *calculateDeliveryFeeUsecase) Execute(ctx context.Context, input Calcula
 	}, nil
 }
 
+// --- Estimate Delivery Time Usecase ---
+
+// QueueStatuses are the statuses whose orders are ahead in the delivery queue
+var QueueStatuses = []domain.OrderStatus{domain.StatusPreparing, domain.StatusOnTheWay}
+
+type EstimateDeliveryTimeInput struct {
+	UserLatitude  float64 `json:"user_latitude" binding:"required"`
+	UserLongitude float64 `json:"user_longitude" binding:"required"`
+}
+
+type EstimateDeliveryTimeOutput struct {
+	DistanceKm                 float64    `json:"distance_km"`
+	QueueLength                int        `json:"queue_length"`
+	PreparationMinutes         int        `json:"preparation_minutes"`
+	QueueMinutes               int        `json:"queue_minutes"`
+	TravelMinutes              int        `json:"travel_minutes"`
+	TotalMinutes               int        `json:"total_minutes"`
+	EstimatedDeliveryAt        time.Time  `json:"estimated_delivery_at"`
+	EstimatedDeliveryWindowEnd *time.Time `json:"estimated_delivery_window_end,omitempty"`
+}
+
+type EstimateDeliveryTimeUsecase interface {
+	Execute(ctx context.Context, input EstimateDeliveryTimeInput) (*EstimateDeliveryTimeOutput, apperrors.ApplicationError)
+}
+
+type estimateDeliveryTimeUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+func NewEstimateDeliveryTimeUsecase(contextFactory appcontext.Factory) EstimateDeliveryTimeUsecase {
+	return &estimateDeliveryTimeUsecase{contextFactory: contextFactory}
+}
+
+// Execute proposes a delivery time from the preparation time, the orders already
+// in PREPARING/ON_THE_WAY and the distance between the business and the customer
+func (u *estimateDeliveryTimeUsecase) Execute(ctx context.Context, input EstimateDeliveryTimeInput) (*EstimateDeliveryTimeOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	settings, err := app.Repositories.Settings.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	queueLength, err := app.Repositories.Order.CountByStatuses(ctx, QueueStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	distanceKm := haversineDistance(
+		settings.BusinessLatitude, settings.BusinessLongitude,
+		input.UserLatitude, input.UserLongitude,
+	)
+
+	preparationMinutes := settings.PreparationTimeMinutes
+	queueMinutes := queueLength * settings.QueueMinutesPerOrder
+	travelMinutes := int(math.Ceil(distanceKm * settings.DeliveryMinutesPerKm))
+	totalMinutes := preparationMinutes + queueMinutes + travelMinutes
+
+	estimatedAt := time.Now().Add(time.Duration(totalMinutes) * time.Minute).Truncate(time.Minute)
+	output := &EstimateDeliveryTimeOutput{
+		DistanceKm:          math.Round(distanceKm*100) / 100,
+		QueueLength:         queueLength,
+		PreparationMinutes:  preparationMinutes,
+		QueueMinutes:        queueMinutes,
+		TravelMinutes:       travelMinutes,
+		TotalMinutes:        totalMinutes,
+		EstimatedDeliveryAt: estimatedAt,
+	}
+	if settings.ETAWindowMinutes > 0 {
+		windowEnd := estimatedAt.Add(time.Duration(settings.ETAWindowMinutes) * time.Minute)
+		output.EstimatedDeliveryWindowEnd = &windowEnd
+	}
+
+	return output, nil
+}
+
 // haversineDistance calculates the distance between two points on Earth in km
 func haversineDistance(lat1, lon1, lat2, lon2 float64) float64 {
 	const earthRadiusKm = 6371.0
@@ -233,3 +342,257 @@ func haversineDistance(lat1, lon1, lat2, lon2 float64) float64 {
 func degreesToRadians(deg float64) float64 {
 	return deg * math.Pi / 180
 }
+
+// --- Delivery Slot Usecases ---
+
+type DeliverySlotInput struct {
+	Weekday   *int    `json:"weekday,omitempty"`
+	StartTime *string `json:"start_time,omitempty"`
+	EndTime   *string `json:"end_time,omitempty"`
+	Capacity  *int    `json:"capacity,omitempty"`
+	Active    *bool   `json:"active,omitempty"`
+}
+
+type DeliverySlotOutput struct {
+	Slot *domain.DeliverySlot `json:"slot"`
+}
+
+type ListDeliverySlotsOutput struct {
+	Slots []*domain.DeliverySlot `json:"slots"`
+}
+
+type ListDeliverySlotsUsecase interface {
+	Execute(ctx context.Context, activeOnly bool) (*ListDeliverySlotsOutput, apperrors.ApplicationError)
+}
+
+type listDeliverySlotsUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+func NewListDeliverySlotsUsecase(contextFactory appcontext.Factory) ListDeliverySlotsUsecase {
+	return &listDeliverySlotsUsecase{contextFactory: contextFactory}
+}
+
+func (u *listDeliverySlotsUsecase) Execute(ctx context.Context, activeOnly bool) (*ListDeliverySlotsOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	slots, err := app.Repositories.DeliverySlot.List(ctx, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	if slots == nil {
+		slots = []*domain.DeliverySlot{}
+	}
+	return &ListDeliverySlotsOutput{Slots: slots}, nil
+}
+
+type CreateDeliverySlotUsecase interface {
+	Execute(ctx context.Context, input DeliverySlotInput) (*DeliverySlotOutput, apperrors.ApplicationError)
+}
+
+type createDeliverySlotUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+func NewCreateDeliverySlotUsecase(contextFactory appcontext.Factory) CreateDeliverySlotUsecase {
+	return &createDeliverySlotUsecase{contextFactory: contextFactory}
+}
+
+func (u *createDeliverySlotUsecase) Execute(ctx context.Context, input DeliverySlotInput) (*DeliverySlotOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if input.Weekday == nil || input.StartTime == nil || input.EndTime == nil || input.Capacity == nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotInvalidError, errors.New("weekday, start_time, end_time and capacity are required"))
+	}
+
+	slot := &domain.DeliverySlot{Active: true}
+	applyDeliverySlotInput(slot, input)
+	if validateErr := slot.Validate(); validateErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotInvalidError, validateErr)
+	}
+
+	created, err := app.Repositories.DeliverySlot.Create(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+	return &DeliverySlotOutput{Slot: created}, nil
+}
+
+type UpdateDeliverySlotUsecase interface {
+	Execute(ctx context.Context, id string, input DeliverySlotInput) (*DeliverySlotOutput, apperrors.ApplicationError)
+}
+
+type updateDeliverySlotUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+func NewUpdateDeliverySlotUsecase(contextFactory appcontext.Factory) UpdateDeliverySlotUsecase {
+	return &updateDeliverySlotUsecase{contextFactory: contextFactory}
+}
+
+func (u *updateDeliverySlotUsecase) Execute(ctx context.Context, id string, input DeliverySlotInput) (*DeliverySlotOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if _, parseErr := uuid.Parse(id); parseErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotInvalidError, parseErr)
+	}
+
+	slot, err := app.Repositories.DeliverySlot.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	applyDeliverySlotInput(slot, input)
+	if validateErr := slot.Validate(); validateErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.DeliverySlotInvalidError, validateErr)
+	}
+
+	updated, err := app.Repositories.DeliverySlot.Update(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+	return &DeliverySlotOutput{Slot: updated}, nil
+}
+
+type DeleteDeliverySlotUsecase interface {
+	Execute(ctx context.Context, id string) apperrors.ApplicationError
+}
+
+type deleteDeliverySlotUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+func NewDeleteDeliverySlotUsecase(contextFactory appcontext.Factory) DeleteDeliverySlotUsecase {
+	return &deleteDeliverySlotUsecase{contextFactory: contextFactory}
+}
+
+func (u *deleteDeliverySlotUsecase) Execute(ctx context.Context, id string) apperrors.ApplicationError {
+	app := u.contextFactory()
+
+	if _, parseErr := uuid.Parse(id); parseErr != nil {
+		return apperrors.NewApplicationError(mappings.DeliverySlotInvalidError, parseErr)
+	}
+	return app.Repositories.DeliverySlot.Delete(ctx, id)
+}
+
+// applyDeliverySlotInput copies the fields present in the input onto the slot
+func applyDeliverySlotInput(slot *domain.DeliverySlot, input DeliverySlotInput) {
+	if input.Weekday != nil {
+		slot.Weekday = time.Weekday(*input.Weekday)
+	}
+	if input.StartTime != nil {
+		slot.StartTime = *input.StartTime
+	}
+	if input.EndTime != nil {
+		slot.EndTime = *input.EndTime
+	}
+	if input.Capacity != nil {
+		slot.Capacity = *input.Capacity
+	}
+	if input.Active != nil {
+		slot.Active = *input.Active
+	}
+}
+
+// --- Delivery Slot Availability Usecase ---
+
+// MaxSlotAvailabilityDays bounds how far ahead availability can be requested at once
+const MaxSlotAvailabilityDays = 31
+
+type GetSlotAvailabilityInput struct {
+	From string // YYYY-MM-DD, defaults to today
+	Days int    // defaults to 7
+}
+
+type SlotAvailabilityOutput struct {
+	SlotID    string    `json:"slot_id"`
+	Date      string    `json:"date"`
+	Weekday   int       `json:"weekday"`
+	StartTime string    `json:"start_time"`
+	EndTime   string    `json:"end_time"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Capacity  int       `json:"capacity"`
+	Booked    int       `json:"booked"`
+	Remaining int       `json:"remaining"`
+	Available bool      `json:"available"`
+}
+
+type GetSlotAvailabilityOutput struct {
+	Slots []SlotAvailabilityOutput `json:"slots"`
+}
+
+type GetSlotAvailabilityUsecase interface {
+	Execute(ctx context.Context, input GetSlotAvailabilityInput) (*GetSlotAvailabilityOutput, apperrors.ApplicationError)
+}
+
+type getSlotAvailabilityUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+func NewGetSlotAvailabilityUsecase(contextFactory appcontext.Factory) GetSlotAvailabilityUsecase {
+	return &getSlotAvailabilityUsecase{contextFactory: contextFactory}
+}
+
+// Execute lists every active slot occurrence in the requested days that has not ended yet,
+// with how many places are left
+func (u *getSlotAvailabilityUsecase) Execute(ctx context.Context, input GetSlotAvailabilityInput) (*GetSlotAvailabilityOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	now := time.Now()
+	from := domain.BusinessToday()
+	if input.From != "" {
+		parsed, parseErr := domain.ParseDeliveryDate(input.From)
+		if parseErr != nil {
+			return nil, apperrors.NewApplicationError(mappings.DeliverySlotInvalidError, parseErr)
+		}
+		from = parsed
+	}
+	days := input.Days
+	if days <= 0 {
+		days = 7
+	}
+	if days > MaxSlotAvailabilityDays {
+		days = MaxSlotAvailabilityDays
+	}
+	to := from.AddDate(0, 0, days-1)
+
+	slots, err := app.Repositories.DeliverySlot.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	bookings, err := app.Repositories.DeliverySlot.CountBookings(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &GetSlotAvailabilityOutput{Slots: []SlotAvailabilityOutput{}}
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		dateKey := date.Format(domain.DeliveryDateLayout)
+		for _, slot := range slots {
+			if slot.CanBeBookedOn(date, now) != nil {
+				continue
+			}
+			booked := bookings[slot.ID][dateKey]
+			remaining := slot.Capacity - booked
+			if remaining < 0 {
+				remaining = 0
+			}
+			output.Slots = append(output.Slots, SlotAvailabilityOutput{
+				SlotID:    slot.ID,
+				Date:      dateKey,
+				Weekday:   int(slot.Weekday),
+				StartTime: slot.StartTime,
+				EndTime:   slot.EndTime,
+				StartsAt:  slot.StartsAt(date),
+				EndsAt:    slot.EndsAt(date),
+				Capacity:  slot.Capacity,
+				Booked:    booked,
+				Remaining: remaining,
+				Available: remaining > 0,
+			})
+		}
+	}
+
+	return output, nil
+}
@@ -0,0 +1,84 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// CreateInput represents the input for creating a subscription
+type CreateInput struct {
+	UserID     string
+	Items      []domain.OrderItem
+	Frequency  string
+	AutoCharge bool
+	// FirstRunAt is when the first order is placed; defaults to one period from now
+	FirstRunAt *time.Time
+}
+
+// CreateOutput represents the created subscription
+type CreateOutput struct {
+	Data SubscriptionOutput `json:"data"`
+}
+
+// CreateUsecase defines the interface for creating subscriptions
+type CreateUsecase interface {
+	Execute(ctx context.Context, input CreateInput) (*CreateOutput, apperrors.ApplicationError)
+}
+
+type createUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewCreateUsecase creates a new instance of CreateUsecase
+func NewCreateUsecase(contextFactory appcontext.Factory) CreateUsecase {
+	return &createUsecase{contextFactory: contextFactory}
+}
+
+// Execute stores a recurring order template for the user
+func (u *createUsecase) Execute(ctx context.Context, input CreateInput) (*CreateOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	now := time.Now()
+	subscription := &domain.Subscription{
+		UserID:     input.UserID,
+		Items:      input.Items,
+		Frequency:  domain.SubscriptionFrequency(input.Frequency),
+		Status:     domain.SubscriptionActive,
+		AutoCharge: input.AutoCharge,
+	}
+	// Fulfilment state belongs to placed orders, not to the template
+	for i := range subscription.Items {
+		subscription.Items[i].ResetFulfilment()
+	}
+
+	if validateErr := subscription.Validate(); validateErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionInvalidError, validateErr)
+	}
+
+	if input.FirstRunAt != nil {
+		if !input.FirstRunAt.After(now) {
+			return nil, apperrors.NewApplicationError(mappings.SubscriptionInvalidError, errors.New("first_run_at must be in the future"))
+		}
+		subscription.NextRunAt = *input.FirstRunAt
+	} else {
+		subscription.NextRunAt = subscription.Frequency.After(now, 0)
+	}
+	subscription.AnchorDay = domain.SubscriptionAnchorDay(subscription.NextRunAt)
+
+	if profile, _ := app.Repositories.Profile.GetByUserID(ctx, input.UserID); profile != nil {
+		subscription.ProfileID = &profile.ID
+	}
+
+	created, err := app.Repositories.Subscription.Create(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateOutput{Data: toSubscriptionOutput(created)}, nil
+}
@@ -0,0 +1,83 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+
+	"github.com/google/uuid"
+)
+
+// GetInput represents the input for getting a subscription
+type GetInput struct {
+	SubscriptionID string
+	UserID         string
+	AsAdmin        bool
+}
+
+// GetOutput represents a subscription and its run history
+type GetOutput struct {
+	Data SubscriptionOutput `json:"data"`
+	Runs []RunOutput        `json:"runs"`
+}
+
+// GetUsecase defines the interface for getting a subscription
+type GetUsecase interface {
+	Execute(ctx context.Context, input GetInput) (*GetOutput, apperrors.ApplicationError)
+}
+
+type getUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewGetUsecase creates a new instance of GetUsecase
+func NewGetUsecase(contextFactory appcontext.Factory) GetUsecase {
+	return &getUsecase{contextFactory: contextFactory}
+}
+
+// Execute returns a subscription with its runs, newest first
+func (u *getUsecase) Execute(ctx context.Context, input GetInput) (*GetOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	subscription, err := getOwnedSubscription(ctx, app, input.SubscriptionID, input.UserID, input.AsAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := app.Repositories.Subscription.ListRuns(ctx, subscription.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &GetOutput{
+		Data: toSubscriptionOutput(subscription),
+		Runs: make([]RunOutput, 0, len(runs)),
+	}
+	for _, r := range runs {
+		output.Runs = append(output.Runs, toRunOutput(r))
+	}
+
+	return output, nil
+}
+
+// getOwnedSubscription loads a subscription and checks that it belongs to the user unless asAdmin is set
+func getOwnedSubscription(ctx context.Context, app *appcontext.Context, id, userID string, asAdmin bool) (*domain.Subscription, apperrors.ApplicationError) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionInvalidIDError, err)
+	}
+
+	subscription, err := app.Repositories.Subscription.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !asAdmin && subscription.UserID != userID {
+		return nil, apperrors.NewApplicationError(mappings.UnauthorizedError, errors.New("subscription does not belong to this user"))
+	}
+
+	return subscription, nil
+}
@@ -0,0 +1,59 @@
+package subscription
+
+import (
+	"context"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+)
+
+// ListInput represents the input for listing subscriptions
+type ListInput struct {
+	UserID string
+	// AsAdmin lists the subscriptions of every user, optionally filtered by Status
+	AsAdmin bool
+	Status  string
+}
+
+// ListOutput represents a list of subscriptions
+type ListOutput struct {
+	Data []SubscriptionOutput `json:"data"`
+}
+
+// ListUsecase defines the interface for listing subscriptions
+type ListUsecase interface {
+	Execute(ctx context.Context, input ListInput) (*ListOutput, apperrors.ApplicationError)
+}
+
+type listUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewListUsecase creates a new instance of ListUsecase
+func NewListUsecase(contextFactory appcontext.Factory) ListUsecase {
+	return &listUsecase{contextFactory: contextFactory}
+}
+
+// Execute lists the user's subscriptions, or all of them for admins
+func (u *listUsecase) Execute(ctx context.Context, input ListInput) (*ListOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	var subscriptions []*domain.Subscription
+	var err apperrors.ApplicationError
+	if input.AsAdmin {
+		subscriptions, err = app.Repositories.Subscription.List(ctx, domain.SubscriptionStatus(input.Status))
+	} else {
+		subscriptions, err = app.Repositories.Subscription.ListByUserID(ctx, input.UserID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListOutput{Data: make([]SubscriptionOutput, 0, len(subscriptions))}
+	for _, s := range subscriptions {
+		output.Data = append(output.Data, toSubscriptionOutput(s))
+	}
+
+	return output, nil
+}
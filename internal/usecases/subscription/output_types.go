@@ -0,0 +1,65 @@
+package subscription
+
+import (
+	"yego/internal/domain"
+)
+
+// SubscriptionOutput represents a subscription in API responses
+type SubscriptionOutput struct {
+	ID          string             `json:"id"`
+	UserID      string             `json:"user_id"`
+	ProfileID   *string            `json:"profile_id,omitempty"`
+	Items       []domain.OrderItem `json:"items"`
+	Frequency   string             `json:"frequency"`
+	Status      string             `json:"status"`
+	NextRunAt   string             `json:"next_run_at"`
+	AutoCharge  bool               `json:"auto_charge"`
+	LastRunAt   *string            `json:"last_run_at,omitempty"`
+	LastOrderID *string            `json:"last_order_id,omitempty"`
+	CreatedAt   string             `json:"created_at"`
+	UpdatedAt   string             `json:"updated_at"`
+}
+
+// RunOutput represents a past scheduler run of a subscription
+type RunOutput struct {
+	ID           string  `json:"id"`
+	OrderID      *string `json:"order_id,omitempty"`
+	ScheduledFor string  `json:"scheduled_for"`
+	Status       string  `json:"status"`
+	Error        *string `json:"error,omitempty"`
+	CreatedAt    string  `json:"created_at"`
+}
+
+// toSubscriptionOutput converts a domain subscription to output
+func toSubscriptionOutput(s *domain.Subscription) SubscriptionOutput {
+	output := SubscriptionOutput{
+		ID:          s.ID,
+		UserID:      s.UserID,
+		ProfileID:   s.ProfileID,
+		Items:       s.Items,
+		Frequency:   string(s.Frequency),
+		Status:      string(s.Status),
+		NextRunAt:   s.NextRunAt.Format("2006-01-02T15:04:05Z"),
+		AutoCharge:  s.AutoCharge,
+		LastOrderID: s.LastOrderID,
+		CreatedAt:   s.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:   s.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if s.LastRunAt != nil {
+		lastRunAt := s.LastRunAt.Format("2006-01-02T15:04:05Z")
+		output.LastRunAt = &lastRunAt
+	}
+	return output
+}
+
+// toRunOutput converts a domain subscription run to output
+func toRunOutput(r *domain.SubscriptionRun) RunOutput {
+	return RunOutput{
+		ID:           r.ID,
+		OrderID:      r.OrderID,
+		ScheduledFor: r.ScheduledFor.Format("2006-01-02T15:04:05Z"),
+		Status:       string(r.Status),
+		Error:        r.Error,
+		CreatedAt:    r.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
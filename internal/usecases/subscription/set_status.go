@@ -0,0 +1,73 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// SetStatusInput represents a pause, resume or cancel request
+type SetStatusInput struct {
+	SubscriptionID string
+	UserID         string
+	Status         domain.SubscriptionStatus
+}
+
+// SetStatusOutput represents the subscription after the change
+type SetStatusOutput struct {
+	Data SubscriptionOutput `json:"data"`
+}
+
+// SetStatusUsecase defines the interface for pausing, resuming and cancelling subscriptions
+type SetStatusUsecase interface {
+	Execute(ctx context.Context, input SetStatusInput) (*SetStatusOutput, apperrors.ApplicationError)
+}
+
+type setStatusUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewSetStatusUsecase creates a new instance of SetStatusUsecase
+func NewSetStatusUsecase(contextFactory appcontext.Factory) SetStatusUsecase {
+	return &setStatusUsecase{contextFactory: contextFactory}
+}
+
+// Execute pauses, resumes or cancels a subscription. Resuming moves the next run
+// past the ones missed while paused instead of placing them all at once.
+func (u *setStatusUsecase) Execute(ctx context.Context, input SetStatusInput) (*SetStatusOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	subscription, err := getOwnedSubscription(ctx, app, input.SubscriptionID, input.UserID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.Status == domain.SubscriptionCancelled || subscription.Status == input.Status {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionStatusConflictError,
+			fmt.Errorf("subscription is %s", subscription.Status))
+	}
+
+	switch input.Status {
+	case domain.SubscriptionPaused, domain.SubscriptionCancelled:
+	case domain.SubscriptionActive:
+		now := time.Now()
+		if !subscription.NextRunAt.After(now) {
+			subscription.NextRunAt = subscription.NextRunAfter(now)
+		}
+	default:
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionInvalidError, fmt.Errorf("unknown status %q", input.Status))
+	}
+	subscription.Status = input.Status
+
+	updated, err := app.Repositories.Subscription.Update(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetStatusOutput{Data: toSubscriptionOutput(updated)}, nil
+}
@@ -0,0 +1,91 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// UpdateInput represents the changes to a subscription; nil fields are left unchanged
+type UpdateInput struct {
+	SubscriptionID string
+	UserID         string
+	Items          []domain.OrderItem
+	Frequency      *string
+	AutoCharge     *bool
+	NextRunAt      *time.Time
+}
+
+// UpdateOutput represents the updated subscription
+type UpdateOutput struct {
+	Data SubscriptionOutput `json:"data"`
+}
+
+// UpdateUsecase defines the interface for editing a subscription
+type UpdateUsecase interface {
+	Execute(ctx context.Context, input UpdateInput) (*UpdateOutput, apperrors.ApplicationError)
+}
+
+type updateUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewUpdateUsecase creates a new instance of UpdateUsecase
+func NewUpdateUsecase(contextFactory appcontext.Factory) UpdateUsecase {
+	return &updateUsecase{contextFactory: contextFactory}
+}
+
+// Execute changes the items, frequency, auto charge or next run of a subscription
+func (u *updateUsecase) Execute(ctx context.Context, input UpdateInput) (*UpdateOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	subscription, err := getOwnedSubscription(ctx, app, input.SubscriptionID, input.UserID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.Status == domain.SubscriptionCancelled {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionStatusConflictError, errors.New("subscription is cancelled"))
+	}
+
+	if input.Items != nil {
+		subscription.Items = input.Items
+	}
+	if input.Frequency != nil {
+		subscription.Frequency = domain.SubscriptionFrequency(*input.Frequency)
+	}
+	if input.AutoCharge != nil {
+		subscription.AutoCharge = *input.AutoCharge
+	}
+	if input.NextRunAt != nil {
+		if !input.NextRunAt.After(time.Now()) {
+			return nil, apperrors.NewApplicationError(mappings.SubscriptionInvalidError, errors.New("next_run_at must be in the future"))
+		}
+		subscription.NextRunAt = *input.NextRunAt
+	}
+	if input.NextRunAt != nil || input.Frequency != nil {
+		// Monthly runs come back to the day of the run the customer scheduled last
+		subscription.AnchorDay = domain.SubscriptionAnchorDay(subscription.NextRunAt)
+	}
+
+	// Fulfilment state belongs to placed orders, not to the template
+	for i := range subscription.Items {
+		subscription.Items[i].ResetFulfilment()
+	}
+
+	if validateErr := subscription.Validate(); validateErr != nil {
+		return nil, apperrors.NewApplicationError(mappings.SubscriptionInvalidError, validateErr)
+	}
+
+	updated, err := app.Repositories.Subscription.Update(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateOutput{Data: toSubscriptionOutput(updated)}, nil
+}
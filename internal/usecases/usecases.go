@@ -3,18 +3,24 @@ package usecases
 import (
 	"yego/internal/adapters/web/websocket"
 	"yego/internal/platform/appcontext"
+	"yego/internal/platform/config"
 	s3service "yego/internal/services/s3"
+	"yego/internal/services/tracking"
 	"yego/internal/usecases/admin"
 	"yego/internal/usecases/order"
 	"yego/internal/usecases/profile"
 	"yego/internal/usecases/settings"
+	"yego/internal/usecases/subscription"
+	"yego/internal/usecases/webhook"
 )
 
 type Usecases struct {
-	Order    Order
-	Profile  Profile
-	Admin    Admin
-	Settings Settings
+	Order        Order
+	Profile      Profile
+	Admin        Admin
+	Settings     Settings
+	Subscription Subscription
+	Webhook      Webhook
 }
 
 type Order struct {
@@ -28,6 +34,26 @@ type Order struct {
 	HandlePaymentWebhookUsecase order.HandlePaymentWebhookUsecase
 	UpdateStatusUsecase         order.UpdateStatusUsecase
 	ListMyOrdersUsecase         order.ListMyOrdersUsecase
+	GetTimelineUsecase          order.GetTimelineUsecase
+	CancelUsecase               order.CancelUsecase
+	RequestModificationUsecase  order.RequestModificationUsecase
+	ListModificationsUsecase    order.ListModificationsUsecase
+	ReviewModificationUsecase   order.ReviewModificationUsecase
+	PostCommentUsecase          order.PostCommentUsecase
+	ListCommentsUsecase         order.ListCommentsUsecase
+	PresignCommentAttachment    order.PresignCommentAttachmentUsecase
+	ReorderUsecase              order.ReorderUsecase
+	RunDueSubscriptionsUsecase  order.RunDueSubscriptionsUsecase
+	FulfilItemsUsecase          order.FulfilItemsUsecase
+	GetReceiptUsecase           order.GetReceiptUsecase
+	BulkCreateWithLinkUsecase   order.BulkCreateWithLinkUsecase
+	ExpireUnpaidOrdersUsecase   order.ExpireUnpaidOrdersUsecase
+	CreateTrackingLinkUsecase   order.CreateTrackingLinkUsecase
+	TrackOrderUsecase           order.TrackOrderUsecase
+	RefundUsecase               order.RefundUsecase
+	ListRefundsUsecase          order.ListRefundsUsecase
+	ReconcilePaymentsUsecase    order.ReconcilePaymentsUsecase
+	ReceivePaymentUsecase       order.ReceivePaymentUsecase
 }
 
 type Profile struct {
@@ -44,7 +70,9 @@ type Admin struct {
 	ListProfilesUsecase     admin.ListProfilesUsecase
 	ListOrdersUsecase       admin.ListOrdersUsecase
 	ListTransactionsUsecase admin.ListTransactionsUsecase
+	GetTransactionUsecase   admin.GetTransactionUsecase
 	UpdateOrderUsecase      admin.UpdateOrderUsecase
+	GetNextStatusesUsecase  admin.GetNextStatusesUsecase
 	UploadImport            admin.UploadImportUsecase
 	ListImports             admin.ListImportsUsecase
 	CreateImport            admin.CreateImportUsecase
@@ -57,16 +85,40 @@ type Admin struct {
 	CreateCoupon            admin.CreateCouponUsecase
 	UpdateCoupon            admin.UpdateCouponUsecase
 	DeleteCoupon            admin.DeleteCouponUsecase
+	ListSlotBookings        admin.ListSlotBookingsUsecase
 }
 
 type Settings struct {
 	GetUsecase                  settings.GetUsecase
 	UpdateUsecase               settings.UpdateUsecase
 	CalculateDeliveryFeeUsecase settings.CalculateDeliveryFeeUsecase
+	EstimateDeliveryTimeUsecase settings.EstimateDeliveryTimeUsecase
+	ListDeliverySlotsUsecase    settings.ListDeliverySlotsUsecase
+	CreateDeliverySlotUsecase   settings.CreateDeliverySlotUsecase
+	UpdateDeliverySlotUsecase   settings.UpdateDeliverySlotUsecase
+	DeleteDeliverySlotUsecase   settings.DeleteDeliverySlotUsecase
+	GetSlotAvailabilityUsecase  settings.GetSlotAvailabilityUsecase
 }
 
-func CreateUsecases(contextFactory appcontext.Factory, s3Client *s3service.Client) *Usecases {
+type Subscription struct {
+	CreateUsecase    subscription.CreateUsecase
+	ListUsecase      subscription.ListUsecase
+	GetUsecase       subscription.GetUsecase
+	UpdateUsecase    subscription.UpdateUsecase
+	SetStatusUsecase subscription.SetStatusUsecase
+}
+
+type Webhook struct {
+	RecordUsecase     webhook.RecordUsecase
+	ProcessDueUsecase webhook.ProcessDueUsecase
+	ListUsecase       webhook.ListUsecase
+	GetUsecase        webhook.GetUsecase
+	ReplayUsecase     webhook.ReplayUsecase
+}
+
+func CreateUsecases(contextFactory appcontext.Factory, s3Client *s3service.Client, trackingSigner *tracking.Signer) *Usecases {
 	app := contextFactory()
+	cfg := config.GetInstance()
 	hub := app.Integrations.WebSocket.GetHub()
 	notifier := websocket.NewNotifier(hub)
 
@@ -74,20 +126,48 @@ func CreateUsecases(contextFactory appcontext.Factory, s3Client *s3service.Clien
 		GetUsecase:                  settings.NewGetUsecase(contextFactory),
 		UpdateUsecase:               settings.NewUpdateUsecase(contextFactory),
 		CalculateDeliveryFeeUsecase: settings.NewCalculateDeliveryFeeUsecase(contextFactory),
+		EstimateDeliveryTimeUsecase: settings.NewEstimateDeliveryTimeUsecase(contextFactory),
+		ListDeliverySlotsUsecase:    settings.NewListDeliverySlotsUsecase(contextFactory),
+		CreateDeliverySlotUsecase:   settings.NewCreateDeliverySlotUsecase(contextFactory),
+		UpdateDeliverySlotUsecase:   settings.NewUpdateDeliverySlotUsecase(contextFactory),
+		DeleteDeliverySlotUsecase:   settings.NewDeleteDeliverySlotUsecase(contextFactory),
+		GetSlotAvailabilityUsecase:  settings.NewGetSlotAvailabilityUsecase(contextFactory),
 	}
 
+	paymentWebhookUsecase := order.NewHandlePaymentWebhookUsecase(contextFactory, notifier)
+
 	return &Usecases{
 		Order: Order{
-			CreateUsecase:               order.NewCreateUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase, notifier),
+			CreateUsecase:               order.NewCreateUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase, settingsUsecases.EstimateDeliveryTimeUsecase, notifier),
 			CreateWithLinkUsecase:       order.NewCreateWithLinkUsecase(contextFactory),
-			ClaimUsecase:                order.NewClaimUsecase(contextFactory, notifier, settingsUsecases.CalculateDeliveryFeeUsecase),
+			ClaimUsecase:                order.NewClaimUsecase(contextFactory, notifier, settingsUsecases.CalculateDeliveryFeeUsecase, settingsUsecases.EstimateDeliveryTimeUsecase),
 			GetUsecase:                  order.NewGetUsecase(contextFactory),
 			GetClaimInfoUsecase:         order.NewGetClaimInfoUsecase(contextFactory),
 			PayForOrderUsecase:          order.NewPayForOrderUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase),
 			CreatePaymentLinkUsecase:    order.NewCreatePaymentLinkUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase),
-			HandlePaymentWebhookUsecase: order.NewHandlePaymentWebhookUsecase(contextFactory),
+			HandlePaymentWebhookUsecase: paymentWebhookUsecase,
 			UpdateStatusUsecase:         order.NewUpdateStatusUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase, notifier),
 			ListMyOrdersUsecase:         order.NewListMyOrdersUsecase(contextFactory),
+			GetTimelineUsecase:          order.NewGetTimelineUsecase(contextFactory),
+			CancelUsecase:               order.NewCancelUsecase(contextFactory, notifier),
+			RequestModificationUsecase:  order.NewRequestModificationUsecase(contextFactory, notifier),
+			ListModificationsUsecase:    order.NewListModificationsUsecase(contextFactory),
+			ReviewModificationUsecase:   order.NewReviewModificationUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase, notifier),
+			PostCommentUsecase:          order.NewPostCommentUsecase(contextFactory, notifier),
+			ListCommentsUsecase:         order.NewListCommentsUsecase(contextFactory),
+			PresignCommentAttachment:    order.NewPresignCommentAttachmentUsecase(contextFactory, s3Client),
+			ReorderUsecase:              order.NewReorderUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase, settingsUsecases.EstimateDeliveryTimeUsecase, notifier),
+			RunDueSubscriptionsUsecase:  order.NewRunDueSubscriptionsUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase, settingsUsecases.EstimateDeliveryTimeUsecase, notifier),
+			FulfilItemsUsecase:          order.NewFulfilItemsUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase, notifier),
+			GetReceiptUsecase:           order.NewGetReceiptUsecase(contextFactory, s3Client, cfg.StoreReceiptsInS3 == "true"),
+			BulkCreateWithLinkUsecase:   order.NewBulkCreateWithLinkUsecase(contextFactory),
+			ExpireUnpaidOrdersUsecase:   order.NewExpireUnpaidOrdersUsecase(contextFactory, notifier),
+			CreateTrackingLinkUsecase:   order.NewCreateTrackingLinkUsecase(contextFactory, trackingSigner),
+			TrackOrderUsecase:           order.NewTrackOrderUsecase(contextFactory, trackingSigner),
+			RefundUsecase:               order.NewRefundUsecase(contextFactory),
+			ListRefundsUsecase:          order.NewListRefundsUsecase(contextFactory),
+			ReconcilePaymentsUsecase:    order.NewReconcilePaymentsUsecase(contextFactory, notifier),
+			ReceivePaymentUsecase:       order.NewReceivePaymentUsecase(contextFactory, notifier),
 		},
 		Profile: Profile{
 			GenerateLinkUsecase:    profile.NewGenerateLinkUsecase(contextFactory),
@@ -102,7 +182,9 @@ func CreateUsecases(contextFactory appcontext.Factory, s3Client *s3service.Clien
 			ListProfilesUsecase:     admin.NewListProfilesUsecase(contextFactory),
 			ListOrdersUsecase:       admin.NewListOrdersUsecase(contextFactory),
 			ListTransactionsUsecase: admin.NewListTransactionsUsecase(contextFactory),
+			GetTransactionUsecase:   admin.NewGetTransactionUsecase(contextFactory),
 			UpdateOrderUsecase:      admin.NewUpdateOrderUsecase(contextFactory, settingsUsecases.CalculateDeliveryFeeUsecase),
+			GetNextStatusesUsecase:  admin.NewGetNextStatusesUsecase(contextFactory),
 			UploadImport:            admin.NewUploadImportUsecase(contextFactory),
 			ListImports:             admin.NewListImportsUsecase(contextFactory),
 			CreateImport:            admin.NewCreateImportUsecase(contextFactory),
@@ -115,7 +197,22 @@ func CreateUsecases(contextFactory appcontext.Factory, s3Client *s3service.Clien
 			CreateCoupon:            admin.NewCreateCouponUsecase(contextFactory),
 			UpdateCoupon:            admin.NewUpdateCouponUsecase(contextFactory),
 			DeleteCoupon:            admin.NewDeleteCouponUsecase(contextFactory),
+			ListSlotBookings:        admin.NewListSlotBookingsUsecase(contextFactory),
 		},
 		Settings: settingsUsecases,
+		Subscription: Subscription{
+			CreateUsecase:    subscription.NewCreateUsecase(contextFactory),
+			ListUsecase:      subscription.NewListUsecase(contextFactory),
+			GetUsecase:       subscription.NewGetUsecase(contextFactory),
+			UpdateUsecase:    subscription.NewUpdateUsecase(contextFactory),
+			SetStatusUsecase: subscription.NewSetStatusUsecase(contextFactory),
+		},
+		Webhook: Webhook{
+			RecordUsecase:     webhook.NewRecordUsecase(contextFactory),
+			ProcessDueUsecase: webhook.NewProcessDueUsecase(contextFactory, paymentWebhookUsecase),
+			ListUsecase:       webhook.NewListUsecase(contextFactory),
+			GetUsecase:        webhook.NewGetUsecase(contextFactory),
+			ReplayUsecase:     webhook.NewReplayUsecase(contextFactory),
+		},
 	}
 }
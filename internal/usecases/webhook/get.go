@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"context"
+
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+)
+
+// GetOutput represents a single webhook event with the request as received
+type GetOutput struct {
+	Event WebhookEventOutput `json:"event"`
+}
+
+// GetUsecase defines the interface for inspecting a webhook event
+type GetUsecase interface {
+	Execute(ctx context.Context, eventID string) (*GetOutput, apperrors.ApplicationError)
+}
+
+type getUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewGetUsecase creates a new instance of GetUsecase
+func NewGetUsecase(contextFactory appcontext.Factory) GetUsecase {
+	return &getUsecase{contextFactory: contextFactory}
+}
+
+// Execute returns a webhook event including its raw query and body
+func (u *getUsecase) Execute(ctx context.Context, eventID string) (*GetOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	event, appErr := app.Repositories.WebhookEvent.GetByID(ctx, eventID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &GetOutput{Event: toWebhookEventOutput(event, true)}, nil
+}
@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// ListInput represents the filters for listing webhook events
+type ListInput struct {
+	Status     string
+	Topic      string
+	ResourceID string
+	Limit      int
+}
+
+// ListOutput represents a list of webhook events, newest first
+type ListOutput struct {
+	Data []WebhookEventOutput `json:"data"`
+}
+
+// ListUsecase defines the interface for listing webhook events
+type ListUsecase interface {
+	Execute(ctx context.Context, input ListInput) (*ListOutput, apperrors.ApplicationError)
+}
+
+type listUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewListUsecase creates a new instance of ListUsecase
+func NewListUsecase(contextFactory appcontext.Factory) ListUsecase {
+	return &listUsecase{contextFactory: contextFactory}
+}
+
+// Execute lists the most recent webhook events matching the filters
+func (u *listUsecase) Execute(ctx context.Context, input ListInput) (*ListOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	if input.Status != "" && !domain.IsValidWebhookEventStatus(input.Status) {
+		return nil, apperrors.NewApplicationError(mappings.WebhookEventInvalidFilterError, fmt.Errorf("unknown status %q", input.Status))
+	}
+
+	events, appErr := app.Repositories.WebhookEvent.List(ctx, domain.WebhookEventFilter{
+		Status:     domain.WebhookEventStatus(input.Status),
+		Topic:      input.Topic,
+		ResourceID: input.ResourceID,
+		Limit:      input.Limit,
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	output := &ListOutput{Data: make([]WebhookEventOutput, 0, len(events))}
+	for _, e := range events {
+		output.Data = append(output.Data, toWebhookEventOutput(e, false))
+	}
+
+	return output, nil
+}
@@ -0,0 +1,55 @@
+package webhook
+
+import "yego/internal/domain"
+
+// WebhookEventOutput represents a stored webhook notification
+type WebhookEventOutput struct {
+	ID            string  `json:"id"`
+	Provider      string  `json:"provider"`
+	Topic         string  `json:"topic"`
+	ResourceID    string  `json:"resource_id"`
+	RequestID     *string `json:"request_id,omitempty"`
+	Status        string  `json:"status"`
+	Attempts      int     `json:"attempts"`
+	MaxAttempts   int     `json:"max_attempts"`
+	LastError     *string `json:"last_error,omitempty"`
+	NextAttemptAt *string `json:"next_attempt_at,omitempty"`
+	ProcessedAt   *string `json:"processed_at,omitempty"`
+	CreatedAt     string  `json:"created_at"`
+	UpdatedAt     string  `json:"updated_at"`
+	// Query and RawBody are the request as received, only included when inspecting one event
+	Query   *string `json:"query,omitempty"`
+	RawBody *string `json:"raw_body,omitempty"`
+}
+
+// toWebhookEventOutput converts a domain webhook event to output
+func toWebhookEventOutput(e *domain.WebhookEvent, includePayload bool) WebhookEventOutput {
+	output := WebhookEventOutput{
+		ID:          e.ID,
+		Provider:    e.Provider,
+		Topic:       e.Topic,
+		ResourceID:  e.ResourceID,
+		RequestID:   e.RequestID,
+		Status:      string(e.Status),
+		Attempts:    e.Attempts,
+		MaxAttempts: domain.MaxWebhookAttempts,
+		LastError:   e.LastError,
+		CreatedAt:   e.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:   e.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if e.Status == domain.WebhookEventPending {
+		nextAttemptAt := e.NextAttemptAt.Format("2006-01-02T15:04:05Z")
+		output.NextAttemptAt = &nextAttemptAt
+	}
+	if e.ProcessedAt != nil {
+		processedAt := e.ProcessedAt.Format("2006-01-02T15:04:05Z")
+		output.ProcessedAt = &processedAt
+	}
+	if includePayload {
+		output.Query = &e.Query
+		output.RawBody = &e.RawBody
+	}
+
+	return output
+}
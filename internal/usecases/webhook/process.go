@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/usecases/order"
+)
+
+// processBatchSize is how many events a single run claims
+const processBatchSize = 50
+
+// ProcessDueOutput summarizes a processing run
+type ProcessDueOutput struct {
+	Processed int `json:"processed"`
+	Retrying  int `json:"retrying"`
+	Failed    int `json:"failed"`
+}
+
+// ProcessDueUsecase processes the stored notifications that are due
+type ProcessDueUsecase interface {
+	Execute(ctx context.Context, now time.Time) (*ProcessDueOutput, apperrors.ApplicationError)
+}
+
+type processDueUsecase struct {
+	contextFactory appcontext.Factory
+	paymentWebhook order.HandlePaymentWebhookUsecase
+}
+
+// NewProcessDueUsecase creates a new instance of ProcessDueUsecase
+func NewProcessDueUsecase(contextFactory appcontext.Factory, paymentWebhook order.HandlePaymentWebhookUsecase) ProcessDueUsecase {
+	return &processDueUsecase{contextFactory: contextFactory, paymentWebhook: paymentWebhook}
+}
+
+// Execute claims the due events and applies each one. A failed event is retried
+// with exponential backoff until it runs out of attempts and is left FAILED.
+func (u *processDueUsecase) Execute(ctx context.Context, now time.Time) (*ProcessDueOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	events, appErr := app.Repositories.WebhookEvent.ClaimDue(ctx, now, processBatchSize)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	output := &ProcessDueOutput{}
+	for _, event := range events {
+		if err := u.paymentWebhook.Execute(ctx, event.Provider, event.ResourceID, event.Topic); err != nil {
+			lastError := err.Error()
+			if err.OriginalError() != nil {
+				lastError += ": " + err.OriginalError().Error()
+			}
+
+			retryAt := event.NextRetryAt(time.Now())
+			if retryAt == nil {
+				output.Failed++
+				log.Printf("Webhook: event %s (%s %s) failed after %d attempts: %s",
+					event.ID, event.Topic, event.ResourceID, event.Attempts, lastError)
+			} else {
+				output.Retrying++
+			}
+
+			if appErr := app.Repositories.WebhookEvent.MarkFailed(ctx, event.ID, lastError, retryAt); appErr != nil {
+				log.Printf("Warning: failed to record webhook event %s failure: %v", event.ID, appErr)
+			}
+			continue
+		}
+
+		if appErr := app.Repositories.WebhookEvent.MarkProcessed(ctx, event.ID); appErr != nil {
+			log.Printf("Warning: failed to mark webhook event %s processed: %v", event.ID, appErr)
+			continue
+		}
+		output.Processed++
+	}
+
+	return output, nil
+}
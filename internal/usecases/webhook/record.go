@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+
+	"yego/internal/adapters/web/integrations/payments"
+	"yego/internal/domain"
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+)
+
+// processedTopics are the notification topics the processor acts on
+var processedTopics = map[string]bool{
+	"payment":        true,
+	"merchant_order": true,
+}
+
+// RecordInput represents a notification as it was received
+type RecordInput struct {
+	Provider   string
+	Topic      string
+	ResourceID string
+	RequestID  string
+	Query      string
+	RawBody    string
+}
+
+// RecordOutput represents the stored notification
+type RecordOutput struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// RecordUsecase stores a notification so it can be processed in the background
+type RecordUsecase interface {
+	Execute(ctx context.Context, input RecordInput) (*RecordOutput, apperrors.ApplicationError)
+}
+
+type recordUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewRecordUsecase creates a new instance of RecordUsecase
+func NewRecordUsecase(contextFactory appcontext.Factory) RecordUsecase {
+	return &recordUsecase{contextFactory: contextFactory}
+}
+
+// Execute stores the notification. Topics we do not handle and notifications without
+// a resource ID are kept as IGNORED, a resource that is already waiting as DUPLICATE.
+func (u *recordUsecase) Execute(ctx context.Context, input RecordInput) (*RecordOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	event := &domain.WebhookEvent{
+		Provider:   input.Provider,
+		Topic:      input.Topic,
+		ResourceID: strings.TrimSpace(input.ResourceID),
+		Query:      input.Query,
+		RawBody:    input.RawBody,
+		Status:     domain.WebhookEventPending,
+	}
+	if event.Provider == "" {
+		event.Provider = payments.ProviderMercadoPago
+	}
+	if input.RequestID != "" {
+		event.RequestID = &input.RequestID
+	}
+	if !processedTopics[event.Topic] || event.ResourceID == "" {
+		event.Status = domain.WebhookEventIgnored
+	}
+
+	event, appErr := app.Repositories.WebhookEvent.Create(ctx, event)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &RecordOutput{ID: event.ID, Status: string(event.Status)}, nil
+}
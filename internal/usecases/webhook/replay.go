@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"yego/internal/platform/appcontext"
+	apperrors "yego/internal/platform/errors"
+	"yego/internal/platform/errors/mappings"
+)
+
+// ReplayUsecase queues a stored webhook event to be processed again
+type ReplayUsecase interface {
+	Execute(ctx context.Context, eventID string) (*GetOutput, apperrors.ApplicationError)
+}
+
+type replayUsecase struct {
+	contextFactory appcontext.Factory
+}
+
+// NewReplayUsecase creates a new instance of ReplayUsecase
+func NewReplayUsecase(contextFactory appcontext.Factory) ReplayUsecase {
+	return &replayUsecase{contextFactory: contextFactory}
+}
+
+// Execute resets the event to PENDING with a fresh set of attempts; the processing
+// job picks it up on its next run. Events still queued cannot be replayed.
+func (u *replayUsecase) Execute(ctx context.Context, eventID string) (*GetOutput, apperrors.ApplicationError) {
+	app := u.contextFactory()
+
+	event, appErr := app.Repositories.WebhookEvent.GetByID(ctx, eventID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !event.CanBeReplayed() {
+		return nil, apperrors.NewApplicationError(mappings.WebhookEventReplayNotAllowedError,
+			fmt.Errorf("webhook event %s is %s", event.ID, event.Status))
+	}
+
+	event, appErr = app.Repositories.WebhookEvent.Replay(ctx, eventID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &GetOutput{Event: toWebhookEventOutput(event, true)}, nil
+}
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_transactions_parent_transaction_id;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS parent_transaction_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS type;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS cancellation_reason;
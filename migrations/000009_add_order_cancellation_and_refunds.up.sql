@@ -0,0 +1,6 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancellation_reason VARCHAR(50);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS type VARCHAR(20) NOT NULL DEFAULT 'payment';
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS parent_transaction_id UUID REFERENCES transactions(id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_parent_transaction_id ON transactions(parent_transaction_id);
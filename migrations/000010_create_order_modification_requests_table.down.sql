@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_order_modification_requests_pending;
+DROP INDEX IF EXISTS idx_order_modification_requests_order_id;
+DROP TABLE IF EXISTS order_modification_requests;
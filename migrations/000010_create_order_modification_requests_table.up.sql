@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS order_modification_requests (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    requested_by_user_id VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    previous_status VARCHAR(50) NOT NULL,
+    changes JSONB NOT NULL,
+    proposed_data JSONB NOT NULL,
+    note VARCHAR(500),
+    reviewed_by_user_id VARCHAR(255),
+    review_note VARCHAR(500),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_modification_requests_order_id ON order_modification_requests(order_id, created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_order_modification_requests_pending ON order_modification_requests(order_id) WHERE status = 'PENDING';
@@ -0,0 +1,9 @@
+ALTER TABLE settings DROP COLUMN IF EXISTS eta_window_minutes;
+ALTER TABLE settings DROP COLUMN IF EXISTS delivery_minutes_per_km;
+ALTER TABLE settings DROP COLUMN IF EXISTS queue_minutes_per_order;
+ALTER TABLE settings DROP COLUMN IF EXISTS preparation_time_minutes;
+
+DROP INDEX IF EXISTS idx_orders_estimated_delivery_at;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS estimated_delivery_window_end;
+ALTER TABLE orders DROP COLUMN IF EXISTS estimated_delivery_at;
@@ -0,0 +1,9 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS estimated_delivery_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS estimated_delivery_window_end TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_orders_estimated_delivery_at ON orders(estimated_delivery_at);
+
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS preparation_time_minutes INTEGER NOT NULL DEFAULT 20;
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS queue_minutes_per_order INTEGER NOT NULL DEFAULT 5;
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS delivery_minutes_per_km DOUBLE PRECISION NOT NULL DEFAULT 3;
+ALTER TABLE settings ADD COLUMN IF NOT EXISTS eta_window_minutes INTEGER NOT NULL DEFAULT 15;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_order_tokens_phone_number;
+DROP INDEX IF EXISTS idx_profiles_phone_number;
+DROP INDEX IF EXISTS idx_orders_status_created_at;
+DROP INDEX IF EXISTS idx_orders_updated_at_id;
+DROP INDEX IF EXISTS idx_orders_created_at_id;
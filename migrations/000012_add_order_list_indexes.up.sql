@@ -0,0 +1,8 @@
+-- Keyset pagination for the admin order list (sort key + id tie-breaker)
+CREATE INDEX IF NOT EXISTS idx_orders_created_at_id ON orders(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_orders_updated_at_id ON orders(updated_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_orders_status_created_at ON orders(status, created_at DESC, id DESC);
+
+-- Phone number lookups
+CREATE INDEX IF NOT EXISTS idx_profiles_phone_number ON profiles(phone_number);
+CREATE INDEX IF NOT EXISTS idx_order_tokens_phone_number ON order_tokens(phone_number);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS order_comments (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    author_user_id VARCHAR(255) NOT NULL,
+    author_role VARCHAR(20) NOT NULL,
+    visibility VARCHAR(20) NOT NULL,
+    body TEXT NOT NULL,
+    attachment_key VARCHAR(512),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_comments_order_id ON order_comments(order_id, created_at);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_orders_delivery_slot;
+ALTER TABLE orders DROP COLUMN IF EXISTS delivery_date;
+ALTER TABLE orders DROP COLUMN IF EXISTS delivery_slot_id;
+
+DROP TABLE IF EXISTS delivery_slot_reservations;
+DROP TABLE IF EXISTS delivery_slots;
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS delivery_slots (
+    id UUID PRIMARY KEY,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    capacity INTEGER NOT NULL CHECK (capacity >= 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (end_time > start_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_delivery_slots_weekday ON delivery_slots(weekday, start_time);
+
+-- One counter per slot and day; bookings increment it atomically up to the slot capacity
+CREATE TABLE IF NOT EXISTS delivery_slot_reservations (
+    slot_id UUID NOT NULL REFERENCES delivery_slots(id) ON DELETE CASCADE,
+    delivery_date DATE NOT NULL,
+    booked INTEGER NOT NULL DEFAULT 0 CHECK (booked >= 0),
+    PRIMARY KEY (slot_id, delivery_date)
+);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_slot_id UUID REFERENCES delivery_slots(id) ON DELETE SET NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_date DATE;
+
+CREATE INDEX IF NOT EXISTS idx_orders_delivery_slot ON orders(delivery_slot_id, delivery_date) WHERE delivery_slot_id IS NOT NULL;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    profile_id UUID REFERENCES profiles(id) ON DELETE SET NULL,
+    items JSONB NOT NULL,
+    frequency VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+    next_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    auto_charge BOOLEAN NOT NULL DEFAULT FALSE,
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    last_order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_id ON subscriptions(user_id);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_due ON subscriptions(next_run_at) WHERE status = 'ACTIVE';
+
+-- One row per scheduler run of a subscription, including failed charges
+CREATE TABLE IF NOT EXISTS subscription_runs (
+    id UUID PRIMARY KEY,
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(30) NOT NULL,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_runs_subscription_id ON subscription_runs(subscription_id, created_at);
@@ -0,0 +1,2 @@
+-- Snapshot of subtotal, delivery fee components, discounts and total taken when the order is priced
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS price_breakdown JSONB;
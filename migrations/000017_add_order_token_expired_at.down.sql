@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_order_tokens_unexpired;
+ALTER TABLE order_tokens DROP COLUMN IF EXISTS expired_at;
@@ -0,0 +1,5 @@
+-- Set by the expiry job once a token can no longer be claimed
+ALTER TABLE order_tokens ADD COLUMN IF NOT EXISTS expired_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_order_tokens_unexpired ON order_tokens(expires_at)
+    WHERE claimed_at IS NULL AND expired_at IS NULL;
@@ -0,0 +1,24 @@
+-- Every payment notification received, stored before it is processed
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id UUID PRIMARY KEY,
+    provider VARCHAR(30) NOT NULL,
+    topic VARCHAR(50) NOT NULL,
+    resource_id VARCHAR(255) NOT NULL,
+    request_id VARCHAR(255),
+    query TEXT,
+    raw_body TEXT,
+    status VARCHAR(20) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- At most one event per resource waits to be processed; later notifications for it are duplicates
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_events_open_resource
+    ON webhook_events(provider, topic, resource_id) WHERE status IN ('PENDING', 'PROCESSING');
+
+CREATE INDEX IF NOT EXISTS idx_webhook_events_due ON webhook_events(next_attempt_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_webhook_events_created_at ON webhook_events(created_at);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_orders_payment_flag;
+ALTER TABLE orders DROP COLUMN IF EXISTS payment_flagged_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS payment_flag;
+
+DROP TABLE IF EXISTS transaction_status_events;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS status_detail;
+DROP INDEX IF EXISTS idx_transactions_gateway_payment_id;
@@ -0,0 +1,52 @@
+-- Earlier versions could store a gateway payment more than once. Keep its most recently
+-- updated row, move the refunds of the other rows onto it and delete them, so the unique
+-- index below can be built
+WITH ranked AS (
+    SELECT id, FIRST_VALUE(id) OVER (
+        PARTITION BY gateway_payment_id
+        ORDER BY updated_at DESC NULLS LAST, created_at DESC NULLS LAST, id
+    ) AS keep_id
+    FROM transactions
+    WHERE type = 'payment' AND gateway_payment_id IS NOT NULL AND gateway_payment_id <> ''
+)
+UPDATE transactions t
+SET parent_transaction_id = ranked.keep_id
+FROM ranked
+WHERE t.parent_transaction_id = ranked.id AND ranked.id <> ranked.keep_id;
+
+WITH ranked AS (
+    SELECT id, FIRST_VALUE(id) OVER (
+        PARTITION BY gateway_payment_id
+        ORDER BY updated_at DESC NULLS LAST, created_at DESC NULLS LAST, id
+    ) AS keep_id
+    FROM transactions
+    WHERE type = 'payment' AND gateway_payment_id IS NOT NULL AND gateway_payment_id <> ''
+)
+DELETE FROM transactions t
+USING ranked
+WHERE t.id = ranked.id AND ranked.id <> ranked.keep_id;
+
+-- A gateway payment is stored once and its status follows the gateway lifecycle
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_gateway_payment_id
+    ON transactions(gateway_payment_id)
+    WHERE type = 'payment' AND gateway_payment_id IS NOT NULL AND gateway_payment_id <> '';
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS status_detail VARCHAR(100);
+
+CREATE TABLE IF NOT EXISTS transaction_status_events (
+    id UUID PRIMARY KEY,
+    transaction_id UUID NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    status_detail VARCHAR(100),
+    source VARCHAR(30) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_status_events_transaction_id ON transaction_status_events(transaction_id);
+
+-- Orders whose payment needs attention, e.g. after a chargeback
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_flag VARCHAR(30);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_flagged_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_orders_payment_flag ON orders(payment_flag) WHERE payment_flag IS NOT NULL;
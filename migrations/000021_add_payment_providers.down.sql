@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_transactions_provider_gateway_payment_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_gateway_payment_id
+    ON transactions(gateway_payment_id)
+    WHERE type = 'payment' AND gateway_payment_id IS NOT NULL AND gateway_payment_id <> '';
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS provider;
+ALTER TABLE orders DROP COLUMN IF EXISTS payment_provider;
@@ -0,0 +1,11 @@
+-- The payment provider an order is paid through; NULL follows the configured default
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_provider VARCHAR(30);
+
+-- Every transaction so far went through MercadoPago
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS provider VARCHAR(30) NOT NULL DEFAULT 'mercadopago';
+
+-- Gateway payment IDs are only unique within their provider
+DROP INDEX IF EXISTS idx_transactions_gateway_payment_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_provider_gateway_payment_id
+    ON transactions(provider, gateway_payment_id)
+    WHERE type = 'payment' AND gateway_payment_id IS NOT NULL AND gateway_payment_id <> '';
@@ -0,0 +1,5 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS proof_key;
+ALTER TABLE transactions DROP COLUMN IF EXISTS confirmed_by_user_id;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS payment_received_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS payment_method;
@@ -0,0 +1,8 @@
+-- How the customer pays the order; NULL orders are paid online like before
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_method VARCHAR(20);
+-- Set when a courier or manager confirms receipt of a cash or transfer payment
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_received_at TIMESTAMP WITH TIME ZONE;
+
+-- Who confirmed a manually received payment, and the S3 key of the transfer proof
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS confirmed_by_user_id VARCHAR(255);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS proof_key TEXT;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_orders_items_search_trgm;
+DROP FUNCTION IF EXISTS order_items_search_text(JSONB);
+
+DROP INDEX IF EXISTS idx_order_tokens_phone_number_trgm;
+DROP INDEX IF EXISTS idx_profiles_phone_number_trgm;
+CREATE INDEX IF NOT EXISTS idx_profiles_phone_number ON profiles(phone_number);
+CREATE INDEX IF NOT EXISTS idx_order_tokens_phone_number ON order_tokens(phone_number);
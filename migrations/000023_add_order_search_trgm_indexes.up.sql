@@ -0,0 +1,16 @@
+-- Substring search (ILIKE '%...%') needs trigram indexes; the btree phone indexes cannot serve it
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+DROP INDEX IF EXISTS idx_profiles_phone_number;
+DROP INDEX IF EXISTS idx_order_tokens_phone_number;
+CREATE INDEX IF NOT EXISTS idx_profiles_phone_number_trgm ON profiles USING GIN (phone_number gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_order_tokens_phone_number_trgm ON order_tokens USING GIN (phone_number gin_trgm_ops);
+
+-- Item names and codes of an order as one searchable string, so the item search can be indexed
+CREATE OR REPLACE FUNCTION order_items_search_text(data JSONB) RETURNS TEXT
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT COALESCE(string_agg(COALESCE(i->>'name', '') || ' ' || COALESCE(i->>'code', ''), ' '), '')
+    FROM jsonb_array_elements(COALESCE(data->'items', '[]'::jsonb)) i
+$$;
+
+CREATE INDEX IF NOT EXISTS idx_orders_items_search_trgm ON orders USING GIN (order_items_search_text(data) gin_trgm_ops);
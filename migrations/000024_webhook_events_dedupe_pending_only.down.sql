@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_webhook_events_pending_resource;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_events_open_resource
+    ON webhook_events(provider, topic, resource_id) WHERE status IN ('PENDING', 'PROCESSING');
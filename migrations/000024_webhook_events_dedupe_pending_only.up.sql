@@ -0,0 +1,5 @@
+-- Only events still waiting are deduplicated: a notification that arrives while an earlier
+-- one for the same resource is being processed is queued, so the newer state is not lost
+DROP INDEX IF EXISTS idx_webhook_events_open_resource;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_events_pending_resource
+    ON webhook_events(provider, topic, resource_id) WHERE status = 'PENDING';
@@ -0,0 +1,2 @@
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS subscriptions_anchor_day_check;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS anchor_day;
@@ -0,0 +1,7 @@
+-- Day of the month monthly subscriptions come back to after a shorter month
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS anchor_day SMALLINT;
+
+UPDATE subscriptions SET anchor_day = EXTRACT(DAY FROM next_run_at) WHERE anchor_day IS NULL;
+
+ALTER TABLE subscriptions ALTER COLUMN anchor_day SET NOT NULL;
+ALTER TABLE subscriptions ADD CONSTRAINT subscriptions_anchor_day_check CHECK (anchor_day BETWEEN 1 AND 31);